@@ -134,6 +134,58 @@ func TestGoldenJSONWithoutCredentials(t *testing.T) {
 	}
 }
 
+// TestHelpListsEverySchemaAction keeps the usage text in step with the schema:
+// every command has a line, and every action other than the command itself
+// is listed on it.
+func TestHelpListsEverySchemaAction(t *testing.T) {
+	help, err := exec.Command(testBinaryPath, "--help").Output()
+	if err != nil {
+		t.Fatalf("--help failed: %v", err)
+	}
+	lines := map[string]string{}
+	for _, line := range strings.Split(string(help), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "searchads" {
+			lines[fields[1]] = line
+		}
+	}
+
+	schema := runCLIForJSON(t, "schema", "--json")
+	commands, _ := schema["commands"].([]any)
+	if len(commands) == 0 {
+		t.Fatalf("schema lists no commands: %v", schema)
+	}
+	for _, raw := range commands {
+		command, _ := raw.(map[string]any)
+		name, _ := command["name"].(string)
+		line, ok := lines[name]
+		if !ok {
+			t.Errorf("help has no line for %s", name)
+			continue
+		}
+		actions, _ := command["actions"].([]any)
+		for _, rawAction := range actions {
+			action, _ := rawAction.(map[string]any)
+			actionName, _ := action["name"].(string)
+			if actionName == name {
+				continue
+			}
+			if !containsWord(line, actionName) {
+				t.Errorf("help line for %s does not list %s: %s", name, actionName, strings.TrimSpace(line))
+			}
+		}
+	}
+}
+
+func containsWord(line, word string) bool {
+	for _, field := range strings.FieldsFunc(line, func(r rune) bool { return r == ' ' || r == '[' || r == ']' || r == '|' }) {
+		if field == word {
+			return true
+		}
+	}
+	return false
+}
+
 func runCLIForJSON(t *testing.T, args ...string) map[string]any {
 	t.Helper()
 	cmd := exec.Command(testBinaryPath, args...)
@@ -0,0 +1,229 @@
+package appleads
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AuditRecord describes one change a Client sent to the API: what was asked
+// for, the entity as it was just before, and what the API answered.
+type AuditRecord struct {
+	Time       time.Time       `json:"time"`
+	OrgID      string          `json:"orgId,omitempty"`
+	Method     string          `json:"method"`
+	Path       string          `json:"path"`
+	Entities   []string        `json:"entities,omitempty"`
+	Request    json.RawMessage `json:"request,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	StatusCode int             `json:"statusCode"`
+	Response   json.RawMessage `json:"response,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// SetAuditRecorder calls record after every POST, PUT, PATCH or DELETE that
+// changes state; token requests, /find selectors and reports are not
+// recorded. A nil record turns auditing off.
+func (c *Client) SetAuditRecorder(record func(AuditRecord)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.auditRecorder = record
+}
+
+func (c *Client) auditRecorderFor(req *http.Request) func(AuditRecord) {
+	c.mu.Lock()
+	record := c.auditRecorder
+	c.mu.Unlock()
+	if record == nil || readOnlyAllows(req.Method, req.URL) {
+		return nil
+	}
+	return record
+}
+
+// startAudit captures the request body and the prior state of the entities
+// req is about to change.
+func (c *Client) startAudit(req *http.Request) AuditRecord {
+	record := AuditRecord{
+		Time:   time.Now().UTC(),
+		OrgID:  strings.TrimPrefix(req.Header.Get("X-AP-Context"), "orgId="),
+		Method: req.Method,
+		Path:   req.URL.Path,
+	}
+	if req.GetBody != nil {
+		if reader, err := req.GetBody(); err == nil {
+			body, _ := io.ReadAll(reader)
+			if json.Valid(body) {
+				record.Request = body
+			}
+		}
+	}
+	collection, pathEntities := auditPathEntities(req.URL.Path)
+	record.Entities = pathEntities
+	bodyIDs := auditBodyIDs(record.Request)
+	for _, id := range bodyIDs {
+		record.Entities = append(record.Entities, fmt.Sprintf("%s:%d", auditEntityKind(collection), id))
+	}
+	record.Before = c.fetchPriorState(req, bodyIDs)
+	return record
+}
+
+func (c *Client) finishAudit(record AuditRecord, respBody []byte, statusCode int, err error) AuditRecord {
+	record.StatusCode = statusCode
+	if err != nil {
+		record.Error = sanitizeForDisplay(err.Error())
+	}
+	if json.Valid(respBody) {
+		record.Response = respBody
+	}
+	if record.Method == http.MethodPost && statusCode >= 200 && statusCode <= 299 {
+		// Creations only learn their entity ID from the response.
+		collection, _ := auditPathEntities(record.Path)
+		var payload map[string]any
+		if json.Unmarshal(respBody, &payload) == nil {
+			if id := intFromAny(mapFromAny(payload["data"])["id"]); id > 0 {
+				record.Entities = append(record.Entities, fmt.Sprintf("%s:%d", auditEntityKind(collection), id))
+			}
+		}
+	}
+	return record
+}
+
+// fetchPriorState GETs the entity a PUT, PATCH or DELETE targets. Bulk
+// writes page through the collection and keep the items whose IDs the body
+// lists.
+func (c *Client) fetchPriorState(req *http.Request, bodyIDs []int) json.RawMessage {
+	path := req.URL.Path
+	bulk := strings.HasSuffix(path, "/bulk")
+	if bulk {
+		path = strings.TrimSuffix(strings.TrimSuffix(path, "/bulk"), "/delete")
+		if len(bodyIDs) == 0 {
+			return nil
+		}
+	} else if req.Method == http.MethodPost {
+		return nil
+	}
+	target := *req.URL
+	target.Path = path
+	target.RawQuery = ""
+	if !bulk {
+		payload, ok := c.fetchPriorPage(req, target)
+		if !ok {
+			return nil
+		}
+		encoded, err := json.Marshal(payload["data"])
+		if err != nil {
+			return nil
+		}
+		return encoded
+	}
+
+	wanted := make(map[int]bool, len(bodyIDs))
+	for _, id := range bodyIDs {
+		wanted[id] = true
+	}
+	items := make([]any, 0, len(bodyIDs))
+	for offset := 0; len(items) < len(wanted); offset += campaignsPerPage {
+		target.RawQuery = fmt.Sprintf("offset=%d&limit=%d", offset, campaignsPerPage)
+		payload, ok := c.fetchPriorPage(req, target)
+		if !ok {
+			return nil
+		}
+		page := toAnySlice(payload["data"])
+		for _, item := range page {
+			if wanted[intFromAny(mapFromAny(item)["id"])] {
+				items = append(items, item)
+			}
+		}
+		total := 0
+		if pagination, ok := payload["pagination"].(map[string]any); ok {
+			total = intFromAny(pagination["totalResults"])
+		}
+		if (total > 0 && offset+campaignsPerPage >= total) || len(page) < campaignsPerPage {
+			break
+		}
+	}
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		return nil
+	}
+	return encoded
+}
+
+// fetchPriorPage sends one prior-state GET with req's credentials.
+func (c *Client) fetchPriorPage(req *http.Request, target neturl.URL) (map[string]any, bool) {
+	getReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, false
+	}
+	getReq.Header.Set("Authorization", req.Header.Get("Authorization"))
+	getReq.Header.Set("X-AP-Context", req.Header.Get("X-AP-Context"))
+	body, statusCode, err := c.send(getReq)
+	if err != nil || statusCode < 200 || statusCode > 299 {
+		return nil, false
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, false
+	}
+	return payload, true
+}
+
+// auditPathEntities reads "campaigns/1/adgroups/2/targetingkeywords" as
+// campaign:1 and adgroup:2, and returns the last collection named.
+func auditPathEntities(path string) (string, []string) {
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(path, "/api/v5"), "/"), "/")
+	var collection string
+	var entities []string
+	for idx := 0; idx < len(segments); idx++ {
+		segment := segments[idx]
+		if segment == "" || segment == "bulk" || segment == "delete" {
+			continue
+		}
+		if _, err := strconv.Atoi(segment); err == nil {
+			continue
+		}
+		collection = segment
+		if idx+1 < len(segments) {
+			if id, err := strconv.Atoi(segments[idx+1]); err == nil {
+				entities = append(entities, fmt.Sprintf("%s:%d", auditEntityKind(segment), id))
+				idx++
+			}
+		}
+	}
+	return collection, entities
+}
+
+// auditBodyIDs returns the "id" of each item in a bulk request body, or the
+// bare IDs of a delete body.
+func auditBodyIDs(body json.RawMessage) []int {
+	var items []any
+	if json.Unmarshal(body, &items) != nil {
+		return nil
+	}
+	ids := make([]int, 0, len(items))
+	for _, item := range items {
+		id := intFromAny(item)
+		if object := mapFromAny(item); object != nil {
+			id = intFromAny(object["id"])
+		}
+		if id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func auditEntityKind(collection string) string {
+	switch collection {
+	case "targetingkeywords":
+		return "keyword"
+	case "negativekeywords":
+		return "negativekeyword"
+	}
+	return strings.TrimSuffix(collection, "s")
+}
@@ -0,0 +1,205 @@
+package appleads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BudgetOrder is a monthly budget order for organizations invoiced on a line
+// of credit.
+type BudgetOrder struct {
+	ID                int      `json:"id"`
+	Name              string   `json:"name"`
+	OrderNumber       string   `json:"orderNumber,omitempty"`
+	Status            string   `json:"status"`
+	StartDate         *string  `json:"startDate,omitempty"`
+	EndDate           *string  `json:"endDate,omitempty"`
+	BudgetAmount      *float64 `json:"budgetAmount,omitempty"`
+	Currency          *string  `json:"currency,omitempty"`
+	ClientName        string   `json:"clientName,omitempty"`
+	PrimaryBuyerName  string   `json:"primaryBuyerName,omitempty"`
+	PrimaryBuyerEmail string   `json:"primaryBuyerEmail,omitempty"`
+	BillingEmail      string   `json:"billingEmail,omitempty"`
+	SupplySources     []string `json:"supplySources,omitempty"`
+	ParentOrgID       int      `json:"parentOrgId,omitempty"`
+}
+
+// BudgetOrderWrite is a budget order create or partial update; nil and empty
+// fields are left out of the request.
+type BudgetOrderWrite struct {
+	Name              *string
+	OrderNumber       *string
+	StartDate         *string
+	EndDate           *string
+	BudgetAmount      *float64
+	Currency          string
+	ClientName        *string
+	PrimaryBuyerName  *string
+	PrimaryBuyerEmail *string
+	BillingEmail      *string
+	SupplySources     []string
+}
+
+func (c *Client) FetchBudgetOrders(ctx context.Context) ([]BudgetOrder, error) {
+	auth, err := c.auth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]BudgetOrder, 0, campaignsPerPage)
+	seen := map[int]struct{}{}
+	offset := 0
+	for {
+		payload, err := c.getJSON(ctx, fmt.Sprintf("%s/budgetorders?offset=%d&limit=%d", appleAdsAPIBase, offset, campaignsPerPage), auth)
+		if err != nil {
+			return nil, err
+		}
+		items := extractDataItems(payload)
+		for _, itemAny := range items {
+			order, ok := parseBudgetOrder(mapFromAny(itemAny))
+			if !ok {
+				continue
+			}
+			if _, exists := seen[order.ID]; exists {
+				continue
+			}
+			seen[order.ID] = struct{}{}
+			results = append(results, order)
+		}
+		total := 0
+		if page, ok := payload["pagination"].(map[string]any); ok {
+			total = intFromAny(page["totalResults"])
+		}
+		if (total > 0 && offset+campaignsPerPage >= total) || len(items) < campaignsPerPage {
+			break
+		}
+		offset += campaignsPerPage
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].ID < results[j].ID })
+	return results, nil
+}
+
+func (c *Client) FetchBudgetOrder(ctx context.Context, budgetOrderID int) (*BudgetOrder, error) {
+	auth, err := c.auth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := c.getJSON(ctx, fmt.Sprintf("%s/budgetorders/%d", appleAdsAPIBase, budgetOrderID), auth)
+	if err != nil {
+		return nil, err
+	}
+	order, ok := parseBudgetOrder(extractDataObject(payload))
+	if !ok {
+		return nil, errors.New("invalid budget order response payload")
+	}
+	return &order, nil
+}
+
+func (c *Client) CreateBudgetOrder(ctx context.Context, write BudgetOrderWrite) (*BudgetOrder, error) {
+	auth, err := c.auth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	body, err := budgetOrderPayload(auth.orgID, write)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := c.postJSON(ctx, appleAdsAPIBase+"/budgetorders", auth, body)
+	if err != nil {
+		return nil, err
+	}
+	order, ok := parseBudgetOrder(extractDataObject(payload))
+	if !ok {
+		return nil, errors.New("invalid budget order response payload")
+	}
+	return &order, nil
+}
+
+func (c *Client) UpdateBudgetOrder(ctx context.Context, budgetOrderID int, write BudgetOrderWrite) (*BudgetOrder, error) {
+	auth, err := c.auth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	body, err := budgetOrderPayload(auth.orgID, write)
+	if err != nil {
+		return nil, err
+	}
+	if len(mapFromAny(body["bo"])) == 0 {
+		return nil, errors.New("no budget order fields to update")
+	}
+	payload, err := c.putJSON(ctx, fmt.Sprintf("%s/budgetorders/%d", appleAdsAPIBase, budgetOrderID), auth, body)
+	if err != nil {
+		return nil, err
+	}
+	order, ok := parseBudgetOrder(extractDataObject(payload))
+	if !ok {
+		return nil, errors.New("invalid budget order response payload")
+	}
+	return &order, nil
+}
+
+// budgetOrderPayload wraps the order as {"orgIds": [...], "bo": {...}}. The
+// API needs the numeric org ID, so any other org ID is an error.
+func budgetOrderPayload(orgID string, write BudgetOrderWrite) (map[string]any, error) {
+	parsedOrgID, err := strconv.Atoi(strings.TrimSpace(orgID))
+	if err != nil || parsedOrgID <= 0 {
+		return nil, fmt.Errorf("budget orders need a numeric orgId, got %q", orgID)
+	}
+	order := map[string]any{}
+	for key, value := range map[string]*string{
+		"name":              write.Name,
+		"orderNumber":       write.OrderNumber,
+		"startDate":         write.StartDate,
+		"endDate":           write.EndDate,
+		"clientName":        write.ClientName,
+		"primaryBuyerName":  write.PrimaryBuyerName,
+		"primaryBuyerEmail": write.PrimaryBuyerEmail,
+		"billingEmail":      write.BillingEmail,
+	} {
+		if value != nil {
+			order[key] = strings.TrimSpace(*value)
+		}
+	}
+	if write.BudgetAmount != nil {
+		order["budget"] = map[string]any{
+			"amount":   fmt.Sprintf("%.4f", *write.BudgetAmount),
+			"currency": strings.ToUpper(strings.TrimSpace(write.Currency)),
+		}
+	}
+	if len(write.SupplySources) > 0 {
+		order["supplySources"] = write.SupplySources
+	}
+	return map[string]any{"bo": order, "orgIds": []int{parsedOrgID}}, nil
+}
+
+// parseBudgetOrder accepts an order or the {"bo": {...}} envelope the API
+// returns it in.
+func parseBudgetOrder(source map[string]any) (BudgetOrder, bool) {
+	if inner := mapFromAny(source["bo"]); len(inner) > 0 {
+		source = inner
+	}
+	id := intFromAny(source["id"])
+	if id <= 0 {
+		return BudgetOrder{}, false
+	}
+	budget, currency := parseBid(mapFromAny(source["budget"]))
+	return BudgetOrder{
+		ID:                id,
+		Name:              strings.TrimSpace(stringFromAny(source["name"])),
+		OrderNumber:       strings.TrimSpace(stringFromAny(source["orderNumber"])),
+		Status:            strings.ToUpper(strings.TrimSpace(stringFromAny(source["status"]))),
+		StartDate:         toStringPtr(source["startDate"]),
+		EndDate:           toStringPtr(source["endDate"]),
+		BudgetAmount:      budget,
+		Currency:          currency,
+		ClientName:        strings.TrimSpace(stringFromAny(source["clientName"])),
+		PrimaryBuyerName:  strings.TrimSpace(stringFromAny(source["primaryBuyerName"])),
+		PrimaryBuyerEmail: strings.TrimSpace(stringFromAny(source["primaryBuyerEmail"])),
+		BillingEmail:      strings.TrimSpace(stringFromAny(source["billingEmail"])),
+		SupplySources:     toStringSlice(source["supplySources"]),
+		ParentOrgID:       intFromAny(source["parentOrgId"]),
+	}, true
+}
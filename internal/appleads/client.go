@@ -38,8 +38,13 @@ var (
 type Client struct {
 	httpClient *http.Client
 
-	mu     sync.Mutex
-	cached *authContext
+	mu         sync.Mutex
+	cached     *authContext
+	limiter    *rateLimiter
+	readOnly   bool
+	protection CampaignProtection
+
+	auditRecorder func(AuditRecord)
 }
 
 type authContext struct {
@@ -55,18 +60,37 @@ type TokenResponse struct {
 }
 
 type CampaignSummary struct {
-	ID     int    `json:"id"`
-	AdamID int    `json:"adamId,omitempty"`
-	Name   string `json:"name"`
-	Status string `json:"status"`
+	ID                                 int                 `json:"id"`
+	AdamID                             int                 `json:"adamId,omitempty"`
+	Name                               string              `json:"name"`
+	Status                             string              `json:"status"`
+	ServingStatus                      string              `json:"servingStatus,omitempty"`
+	ServingStateReasons                []string            `json:"servingStateReasons,omitempty"`
+	CountryOrRegionServingStateReasons map[string][]string `json:"countryOrRegionServingStateReasons,omitempty"`
+	DisplayStatus                      string              `json:"displayStatus,omitempty"`
+	BudgetAmount                       *float64            `json:"budgetAmount,omitempty"`
+	DailyBudgetAmount                  *float64            `json:"dailyBudgetAmount,omitempty"`
+	Currency                           *string             `json:"currency,omitempty"`
+	CountriesOrRegions                 []string            `json:"countriesOrRegions,omitempty"`
+	SupplySources                      []string            `json:"supplySources,omitempty"`
+	AdChannelType                      string              `json:"adChannelType,omitempty"`
+	BillingEvent                       string              `json:"billingEvent,omitempty"`
+	PaymentModel                       string              `json:"paymentModel,omitempty"`
+	StartTime                          *string             `json:"startTime,omitempty"`
+	EndTime                            *string             `json:"endTime,omitempty"`
+	BudgetOrders                       []int               `json:"budgetOrders,omitempty"`
+	Deleted                            bool                `json:"deleted,omitempty"`
+	CreationTime                       *string             `json:"creationTime,omitempty"`
+	ModificationTime                   *string             `json:"modificationTime,omitempty"`
 }
 
 type AdGroupSummary struct {
-	ID         int      `json:"id"`
-	Name       string   `json:"name"`
-	Status     string   `json:"status"`
-	DefaultBid *float64 `json:"defaultBid,omitempty"`
-	Currency   *string  `json:"currency,omitempty"`
+	ID                     int      `json:"id"`
+	Name                   string   `json:"name"`
+	Status                 string   `json:"status"`
+	DefaultBid             *float64 `json:"defaultBid,omitempty"`
+	Currency               *string  `json:"currency,omitempty"`
+	AutomatedKeywordsOptIn *bool    `json:"automatedKeywordsOptIn,omitempty"`
 }
 
 type KeywordSummary struct {
@@ -82,6 +106,15 @@ type KeywordSummary struct {
 type APIError struct {
 	StatusCode int
 	Message    string
+	Details    []APIErrorDetail
+}
+
+// APIErrorDetail is one entry of the API's error.errors list. Field carries
+// the offending path, e.g. "TargetingKeywordImport[3].text" for bulk writes.
+type APIErrorDetail struct {
+	MessageCode string
+	Message     string
+	Field       string
 }
 
 func (e *APIError) Error() string {
@@ -103,6 +136,24 @@ func (c *Client) ValidateCredentials(ctx context.Context) (string, error) {
 	return auth.orgID, nil
 }
 
+// Session is the access token and org a Client sends with each request.
+type Session struct {
+	AccessToken string
+	OrgID       string
+	ExpiresAt   time.Time
+	APIBase     string
+}
+
+// Session returns the cached access token, requesting a new one when it has
+// expired, so other processes can call the API as this client.
+func (c *Client) Session(ctx context.Context) (*Session, error) {
+	auth, err := c.auth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{AccessToken: auth.accessToken, OrgID: auth.orgID, ExpiresAt: auth.expiresAt, APIBase: appleAdsAPIBase}, nil
+}
+
 func (c *Client) FetchCampaigns(ctx context.Context) ([]CampaignSummary, error) {
 	auth, err := c.auth(ctx)
 	if err != nil {
@@ -141,25 +192,15 @@ func (c *Client) FetchCampaigns(ctx context.Context) ([]CampaignSummary, error)
 			if !ok {
 				continue
 			}
-			id := intFromAny(row["id"])
-			if id <= 0 {
+			campaign, ok := parseCampaignSummary(row)
+			if !ok {
 				continue
 			}
-			if _, already := seen[id]; already {
+			if _, already := seen[campaign.ID]; already {
 				continue
 			}
-			name := strings.TrimSpace(stringFromAny(row["name"]))
-			if name == "" {
-				name = fmt.Sprintf("Campaign %d", id)
-			}
-			status := strings.ToUpper(strings.TrimSpace(stringFromAny(row["status"])))
-			results = append(results, CampaignSummary{
-				ID:     id,
-				AdamID: intFromAny(row["adamId"]),
-				Name:   name,
-				Status: status,
-			})
-			seen[id] = struct{}{}
+			results = append(results, campaign)
+			seen[campaign.ID] = struct{}{}
 		}
 
 		total := 0
@@ -175,6 +216,22 @@ func (c *Client) FetchCampaigns(ctx context.Context) ([]CampaignSummary, error)
 	return results, nil
 }
 
+func (c *Client) FetchCampaign(ctx context.Context, campaignID int) (*CampaignSummary, error) {
+	auth, err := c.auth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := c.getJSON(ctx, fmt.Sprintf("%s/campaigns/%d", appleAdsAPIBase, campaignID), auth)
+	if err != nil {
+		return nil, err
+	}
+	campaign, ok := parseCampaignSummary(extractDataObject(payload))
+	if !ok {
+		return nil, errors.New("invalid campaign response payload")
+	}
+	return &campaign, nil
+}
+
 func (c *Client) FetchAdGroups(ctx context.Context, campaignID int) ([]AdGroupSummary, error) {
 	auth, err := c.auth(ctx)
 	if err != nil {
@@ -243,12 +300,17 @@ func (c *Client) FetchAdGroups(ctx context.Context, campaignID int) ([]AdGroupSu
 				mapFromAny(row["defaultCpcBid"]),
 				mapFromAny(row["defaultBidAmount"]),
 			)
+			var searchMatch *bool
+			if optIn, ok := row["automatedKeywordsOptIn"].(bool); ok {
+				searchMatch = &optIn
+			}
 			results = append(results, AdGroupSummary{
-				ID:         id,
-				Name:       name,
-				Status:     status,
-				DefaultBid: bidAmount,
-				Currency:   currency,
+				ID:                     id,
+				Name:                   name,
+				Status:                 status,
+				DefaultBid:             bidAmount,
+				Currency:               currency,
+				AutomatedKeywordsOptIn: searchMatch,
 			})
 			seen[id] = struct{}{}
 		}
@@ -512,6 +574,23 @@ func (c *Client) fetchOrgID(ctx context.Context, accessToken string) (string, er
 }
 
 func (c *Client) do(req *http.Request) ([]byte, int, error) {
+	if err := c.checkReadOnly(req); err != nil {
+		return nil, 0, err
+	}
+	record := c.auditRecorderFor(req)
+	if record == nil {
+		return c.send(req)
+	}
+	audit := c.startAudit(req)
+	body, statusCode, err := c.send(req)
+	record(c.finishAudit(audit, body, statusCode, err))
+	return body, statusCode, err
+}
+
+func (c *Client) send(req *http.Request) ([]byte, int, error) {
+	if err := c.waitForRateLimit(req.Context()); err != nil {
+		return nil, 0, err
+	}
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, 0, err
@@ -625,7 +704,28 @@ func httpStatusError(code int, body []byte) error {
 	if message == "" {
 		message = "Unknown error"
 	}
-	return &APIError{StatusCode: code, Message: message}
+	return &APIError{StatusCode: code, Message: message, Details: parseAPIErrorDetails(body)}
+}
+
+func parseAPIErrorDetails(body []byte) []APIErrorDetail {
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil
+	}
+	rawErrors, _ := mapFromAny(payload["error"])["errors"].([]any)
+	details := make([]APIErrorDetail, 0, len(rawErrors))
+	for _, raw := range rawErrors {
+		item := mapFromAny(raw)
+		if item == nil {
+			continue
+		}
+		details = append(details, APIErrorDetail{
+			MessageCode: sanitizeForDisplay(stringFromAny(item["messageCode"])),
+			Message:     sanitizeForDisplay(stringFromAny(item["message"])),
+			Field:       sanitizeForDisplay(stringFromAny(item["field"])),
+		})
+	}
+	return details
 }
 
 func sanitizeAPIErrorMessage(body []byte) string {
@@ -0,0 +1,121 @@
+package appleads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"regexp"
+	"strings"
+)
+
+// ErrReadOnly is returned for a request a read-only Client refuses to send.
+var ErrReadOnly = errors.New("read-only mode")
+
+// ErrProtectedCampaign is returned when a change targets a campaign listed in
+// the protection policy.
+var ErrProtectedCampaign = errors.New("campaign is protected")
+
+var (
+	readOnlyPostPattern = regexp.MustCompile(`^/api/v5/(reports/.+|custom-reports|.+/find)$`)
+	campaignPathPattern = regexp.MustCompile(`^/api/v5/campaigns/(\d+)/?$`)
+)
+
+// CampaignProtection lists campaigns that can never be deleted, paused or
+// have their budget changed. Names match case-insensitively; * matches any
+// run of characters.
+type CampaignProtection struct {
+	IDs   []int
+	Names []string
+}
+
+func (p CampaignProtection) empty() bool {
+	return len(p.IDs) == 0 && len(p.Names) == 0
+}
+
+// SetReadOnly makes the Client refuse every POST, PUT, PATCH and DELETE
+// except token requests, /find selectors and report creation.
+func (c *Client) SetReadOnly(readOnly bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readOnly = readOnly
+}
+
+// SetCampaignProtection replaces the protected campaign list.
+func (c *Client) SetCampaignProtection(protection CampaignProtection) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.protection = protection
+}
+
+func (c *Client) checkReadOnly(req *http.Request) error {
+	c.mu.Lock()
+	readOnly := c.readOnly
+	c.mu.Unlock()
+	if !readOnly || readOnlyAllows(req.Method, req.URL) {
+		return nil
+	}
+	return fmt.Errorf("%w: refusing %s %s", ErrReadOnly, req.Method, req.URL.Path)
+}
+
+func readOnlyAllows(method string, url *neturl.URL) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		return url.String() == appleIDTokenURL || readOnlyPostPattern.MatchString(url.Path)
+	}
+	return false
+}
+
+// checkCampaignProtection fails when campaignID is protected by ID or name.
+// Campaign names are only fetched when the policy lists names.
+func (c *Client) checkCampaignProtection(ctx context.Context, campaignID int, change string) error {
+	c.mu.Lock()
+	protection := c.protection
+	c.mu.Unlock()
+	if protection.empty() {
+		return nil
+	}
+	for _, id := range protection.IDs {
+		if id == campaignID {
+			return fmt.Errorf("%w: refusing to %s campaign %d", ErrProtectedCampaign, change, campaignID)
+		}
+	}
+	if len(protection.Names) == 0 {
+		return nil
+	}
+	campaign, err := c.FetchCampaign(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+	for _, pattern := range protection.Names {
+		if matchProtectedName(pattern, campaign.Name) {
+			return fmt.Errorf("%w: refusing to %s campaign %d (%s)", ErrProtectedCampaign, change, campaignID, campaign.Name)
+		}
+	}
+	return nil
+}
+
+// checkRawCampaignProtection guards passthrough writes to a campaign itself;
+// they can change status or budget, so any of them counts.
+func (c *Client) checkRawCampaignProtection(ctx context.Context, method string, url *neturl.URL) error {
+	if method == http.MethodGet || method == http.MethodPost {
+		return nil
+	}
+	match := campaignPathPattern.FindStringSubmatch(url.Path)
+	if match == nil {
+		return nil
+	}
+	return c.checkCampaignProtection(ctx, intFromAny(match[1]), "change")
+}
+
+func matchProtectedName(pattern, name string) bool {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(pattern)), "*")
+	for idx, part := range parts {
+		parts[idx] = regexp.QuoteMeta(part)
+	}
+	matched, err := regexp.MatchString("^"+strings.Join(parts, ".*")+"$", strings.ToLower(strings.TrimSpace(name)))
+	return err == nil && matched
+}
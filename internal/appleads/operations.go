@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	neturl "net/url"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -20,46 +21,59 @@ type NegativeKeywordSummary struct {
 	Status    string `json:"status"`
 }
 
-type AdGroupDailyReport struct {
-	Date         string  `json:"date"`
+// CampaignHourlySpend is one campaign's delivery in one hour of a day.
+type CampaignHourlySpend struct {
 	CampaignID   int     `json:"campaignId"`
-	AdGroupID    int     `json:"adGroupId"`
-	AdGroupName  string  `json:"adGroupName"`
+	Hour         int     `json:"hour"`
 	Impressions  int     `json:"impressions"`
 	Taps         int     `json:"taps"`
-	Installs     *int    `json:"installs,omitempty"`
 	Spend        float64 `json:"spend"`
-	CPT          float64 `json:"cpt"`
-	CurrencyCode *string `json:"currencyCode,omitempty"`
+	CurrencyCode *string `json:"currency,omitempty"`
+}
+
+type AdGroupDailyReport struct {
+	Date         string            `json:"date"`
+	CampaignID   int               `json:"campaignId"`
+	AdGroupID    int               `json:"adGroupId"`
+	AdGroupName  string            `json:"adGroupName"`
+	Impressions  int               `json:"impressions"`
+	Taps         int               `json:"taps"`
+	Installs     *int              `json:"installs,omitempty"`
+	Spend        float64           `json:"spend"`
+	CPT          float64           `json:"cpt"`
+	CurrencyCode *string           `json:"currencyCode,omitempty"`
+	Dimensions   map[string]string `json:"dimensions,omitempty"`
 }
 
 type SearchTermDailyReport struct {
-	Date           string  `json:"date"`
-	CampaignID     int     `json:"campaignId"`
-	AdGroupID      int     `json:"adGroupId"`
-	SearchTermText string  `json:"searchTermText"`
-	Impressions    int     `json:"impressions"`
-	Taps           int     `json:"taps"`
-	Installs       *int    `json:"installs,omitempty"`
-	Spend          float64 `json:"spend"`
-	CPT            float64 `json:"cpt"`
-	CurrencyCode   *string `json:"currencyCode,omitempty"`
+	Date           string            `json:"date"`
+	CampaignID     int               `json:"campaignId"`
+	AdGroupID      int               `json:"adGroupId"`
+	SearchTermText string            `json:"searchTermText"`
+	Impressions    int               `json:"impressions"`
+	Taps           int               `json:"taps"`
+	Installs       *int              `json:"installs,omitempty"`
+	Spend          float64           `json:"spend"`
+	CPT            float64           `json:"cpt"`
+	CurrencyCode   *string           `json:"currencyCode,omitempty"`
+	Dimensions     map[string]string `json:"dimensions,omitempty"`
 }
 
 type KeywordDailyReport struct {
-	Date         string  `json:"date"`
-	CampaignID   int     `json:"campaignId"`
-	AdGroupID    int     `json:"adGroupId"`
-	KeywordID    int     `json:"keywordId"`
-	KeywordText  string  `json:"keywordText"`
-	MatchType    string  `json:"matchType"`
-	Status       string  `json:"status"`
-	Impressions  int     `json:"impressions"`
-	Taps         int     `json:"taps"`
-	Installs     *int    `json:"installs,omitempty"`
-	Spend        float64 `json:"spend"`
-	CPT          float64 `json:"cpt"`
-	CurrencyCode *string `json:"currencyCode,omitempty"`
+	Date         string            `json:"date"`
+	CampaignID   int               `json:"campaignId"`
+	AdGroupID    int               `json:"adGroupId"`
+	KeywordID    int               `json:"keywordId"`
+	KeywordText  string            `json:"keywordText"`
+	MatchType    string            `json:"matchType"`
+	Status       string            `json:"status"`
+	Impressions  int               `json:"impressions"`
+	Taps         int               `json:"taps"`
+	Installs     *int              `json:"installs,omitempty"`
+	Spend        float64           `json:"spend"`
+	CPT          float64           `json:"cpt"`
+	CurrencyCode *string           `json:"currencyCode,omitempty"`
+	Dimensions   map[string]string `json:"dimensions,omitempty"`
 }
 
 type AdSummary struct {
@@ -125,7 +139,12 @@ func (c *Client) CreateCampaign(
 	countries []string,
 	startTime string,
 	endTime string,
+	placementName string,
 ) (*CampaignSummary, error) {
+	placement, err := LookupPlacement(placementName)
+	if err != nil {
+		return nil, err
+	}
 	auth, err := c.auth(ctx)
 	if err != nil {
 		return nil, err
@@ -147,9 +166,9 @@ func (c *Client) CreateCampaign(
 		"orgId":         auth.orgID,
 		"name":          name,
 		"status":        status,
-		"adChannelType": "SEARCH",
-		"supplySources": []string{"APPSTORE_SEARCH_RESULTS"},
-		"billingEvent":  "TAPS",
+		"adChannelType": placement.AdChannelType,
+		"supplySources": []string{placement.SupplySource},
+		"billingEvent":  placement.BillingEvent,
 		"paymentModel":  "PAYG",
 		"startTime":     resolvedStartTime,
 		"dailyBudgetAmount": map[string]any{
@@ -197,6 +216,11 @@ func (c *Client) UpdateCampaignStatus(ctx context.Context, campaignID int, statu
 		return nil, err
 	}
 	normalized := strings.ToUpper(strings.TrimSpace(status))
+	if normalized == "PAUSED" {
+		if err := c.checkCampaignProtection(ctx, campaignID, "pause"); err != nil {
+			return nil, err
+		}
+	}
 	payload, err := c.putJSON(
 		ctx,
 		fmt.Sprintf("%s/campaigns/%d", appleAdsAPIBase, campaignID),
@@ -230,6 +254,9 @@ func (c *Client) DeleteCampaign(ctx context.Context, campaignID int) error {
 	if err != nil {
 		return err
 	}
+	if err := c.checkCampaignProtection(ctx, campaignID, "delete"); err != nil {
+		return err
+	}
 	req, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodDelete,
@@ -256,6 +283,9 @@ func (c *Client) UpdateCampaignDailyBudget(ctx context.Context, campaignID int,
 	if err != nil {
 		return nil, err
 	}
+	if err := c.checkCampaignProtection(ctx, campaignID, "change the budget of"); err != nil {
+		return nil, err
+	}
 	normalizedCurrency := strings.ToUpper(strings.TrimSpace(budgetCurrency))
 	payload, err := c.putJSON(
 		ctx,
@@ -289,6 +319,67 @@ func (c *Client) UpdateCampaignDailyBudget(ctx context.Context, campaignID int,
 	return &CampaignSummary{ID: id, Name: name, Status: resolvedStatus}, nil
 }
 
+// CampaignUpdate is a partial campaign change; nil fields are left alone.
+// An empty EndTime clears the end date. ClearGeoTargeting lets Apple drop ad
+// group locations in countries the new list no longer includes.
+type CampaignUpdate struct {
+	Name               *string
+	CountriesOrRegions []string
+	ClearGeoTargeting  bool
+	EndTime            *string
+	BudgetAmount       *float64
+	BudgetCurrency     string
+}
+
+// UpdateCampaign sends only the fields set in update. Changing the lifetime
+// budget counts as a budget change for campaign protection.
+func (c *Client) UpdateCampaign(ctx context.Context, campaignID int, update CampaignUpdate) (*CampaignSummary, error) {
+	auth, err := c.auth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if update.BudgetAmount != nil {
+		if err := c.checkCampaignProtection(ctx, campaignID, "change the budget of"); err != nil {
+			return nil, err
+		}
+	}
+	campaign := map[string]any{}
+	if update.Name != nil {
+		campaign["name"] = strings.TrimSpace(*update.Name)
+	}
+	if update.CountriesOrRegions != nil {
+		campaign["countriesOrRegions"] = update.CountriesOrRegions
+	}
+	if update.EndTime != nil {
+		if strings.TrimSpace(*update.EndTime) == "" {
+			campaign["endTime"] = nil
+		} else {
+			campaign["endTime"] = strings.TrimSpace(*update.EndTime)
+		}
+	}
+	if update.BudgetAmount != nil {
+		campaign["budgetAmount"] = map[string]any{
+			"amount":   fmt.Sprintf("%.4f", *update.BudgetAmount),
+			"currency": strings.ToUpper(strings.TrimSpace(update.BudgetCurrency)),
+		}
+	}
+	if len(campaign) == 0 {
+		return nil, errors.New("no campaign fields to update")
+	}
+	payload, err := c.putJSON(ctx, fmt.Sprintf("%s/campaigns/%d", appleAdsAPIBase, campaignID), auth, map[string]any{
+		"campaign": campaign,
+		"clearGeoTargetingOnCountryOrRegionChange": update.ClearGeoTargeting,
+	})
+	if err != nil {
+		return nil, err
+	}
+	updated, ok := parseCampaignSummary(extractDataObject(payload))
+	if !ok {
+		return nil, errors.New("invalid campaign response payload")
+	}
+	return &updated, nil
+}
+
 func (c *Client) CreateAdGroup(
 	ctx context.Context,
 	campaignID int,
@@ -673,60 +764,196 @@ func (c *Client) CreateCreative(ctx context.Context, adamID int, name, creativeT
 	return &creative, nil
 }
 
+// KeywordBatchSize is the largest number of keywords sent in one
+// targetingkeywords/bulk request.
+const KeywordBatchSize = 1000
+
+// KeywordWrite describes one keyword to create (ID zero) or update.
+type KeywordWrite struct {
+	ID        int
+	Text      string
+	MatchType string
+	Status    string
+	BidAmount *float64
+	Currency  *string
+}
+
+// KeywordWriteResult is the outcome for the input row at the same index. ID is
+// the created keyword's ID when the API returned it.
+type KeywordWriteResult struct {
+	ID  int
+	Err error
+}
+
 func (c *Client) AddKeyword(ctx context.Context, campaignID, adGroupID int, text, matchType string, bidAmount *float64, currency *string, status string) error {
-	paths := []string{
-		fmt.Sprintf("%s/campaigns/%d/adgroups/%d/targetingkeywords", appleAdsAPIBase, campaignID, adGroupID),
-		fmt.Sprintf("%s/adgroups/%d/targetingkeywords", appleAdsAPIBase, adGroupID),
+	results, err := c.AddKeywords(ctx, campaignID, adGroupID, []KeywordWrite{{Text: text, MatchType: matchType, Status: status, BidAmount: bidAmount, Currency: currency}})
+	if err != nil {
+		return err
 	}
-	normalizedStatus := keywordStatusPayload(status)
-	if normalizedStatus == "" {
-		normalizedStatus = "ACTIVE"
+	return results[0].Err
+}
+
+func (c *Client) UpdateKeyword(ctx context.Context, campaignID, adGroupID, keywordID int, matchType, status string, bidAmount *float64, currency *string) error {
+	results, err := c.UpdateKeywords(ctx, campaignID, adGroupID, []KeywordWrite{{ID: keywordID, MatchType: matchType, Status: status, BidAmount: bidAmount, Currency: currency}})
+	if err != nil {
+		return err
 	}
-	payload := map[string]any{
-		"text":      text,
-		"matchType": strings.ToUpper(strings.TrimSpace(matchType)),
-		"status":    normalizedStatus,
+	return results[0].Err
+}
+
+// AddKeywords creates keywords in chunks of KeywordBatchSize. The returned
+// error is only set when nothing could be attempted; per-row failures are
+// reported in the results.
+func (c *Client) AddKeywords(ctx context.Context, campaignID, adGroupID int, keywords []KeywordWrite) ([]KeywordWriteResult, error) {
+	payloads := make([]map[string]any, 0, len(keywords))
+	for _, keyword := range keywords {
+		payload := keywordWritePayload(keyword)
+		payload["text"] = keyword.Text
+		if _, ok := payload["matchType"]; !ok {
+			payload["matchType"] = "BROAD"
+		}
+		if _, ok := payload["status"]; !ok {
+			payload["status"] = "ACTIVE"
+		}
+		payloads = append(payloads, payload)
+	}
+	return c.writeKeywordBatches(ctx, http.MethodPost, campaignID, adGroupID, payloads)
+}
+
+// UpdateKeywords updates keywords by ID in chunks of KeywordBatchSize.
+func (c *Client) UpdateKeywords(ctx context.Context, campaignID, adGroupID int, keywords []KeywordWrite) ([]KeywordWriteResult, error) {
+	payloads := make([]map[string]any, 0, len(keywords))
+	for _, keyword := range keywords {
+		payload := keywordWritePayload(keyword)
+		payload["id"] = keyword.ID
+		payloads = append(payloads, payload)
 	}
-	if payload["matchType"] == "" {
-		payload["matchType"] = "BROAD"
+	return c.writeKeywordBatches(ctx, http.MethodPut, campaignID, adGroupID, payloads)
+}
+
+func keywordWritePayload(keyword KeywordWrite) map[string]any {
+	payload := map[string]any{}
+	if matchType := strings.ToUpper(strings.TrimSpace(keyword.MatchType)); matchType != "" {
+		payload["matchType"] = matchType
 	}
-	if bidAmount != nil {
+	if normalized := keywordStatusPayload(keyword.Status); normalized != "" {
+		payload["status"] = normalized
+	}
+	if keyword.BidAmount != nil {
 		ccy := "USD"
-		if currency != nil && strings.TrimSpace(*currency) != "" {
-			ccy = *currency
+		if keyword.Currency != nil && strings.TrimSpace(*keyword.Currency) != "" {
+			ccy = *keyword.Currency
 		}
 		payload["bidAmount"] = map[string]any{
-			"amount":   fmt.Sprintf("%.4f", *bidAmount),
+			"amount":   fmt.Sprintf("%.4f", *keyword.BidAmount),
 			"currency": ccy,
 		}
 	}
-
-	return c.tryKeywordBulkWrite(ctx, http.MethodPost, paths, []any{payload})
+	return payload
 }
 
-func (c *Client) UpdateKeyword(ctx context.Context, campaignID, adGroupID, keywordID int, matchType, status string, bidAmount *float64, currency *string) error {
+func (c *Client) writeKeywordBatches(ctx context.Context, method string, campaignID, adGroupID int, payloads []map[string]any) ([]KeywordWriteResult, error) {
+	if len(payloads) == 0 {
+		return []KeywordWriteResult{}, nil
+	}
+	if _, err := c.auth(ctx); err != nil {
+		return nil, err
+	}
 	paths := []string{
 		fmt.Sprintf("%s/campaigns/%d/adgroups/%d/targetingkeywords", appleAdsAPIBase, campaignID, adGroupID),
 		fmt.Sprintf("%s/adgroups/%d/targetingkeywords", appleAdsAPIBase, adGroupID),
 	}
-	body := map[string]any{"id": keywordID}
-	if resolvedMatchType := strings.ToUpper(strings.TrimSpace(matchType)); resolvedMatchType != "" {
-		body["matchType"] = resolvedMatchType
+	results := make([]KeywordWriteResult, len(payloads))
+	for start := 0; start < len(payloads); start += KeywordBatchSize {
+		end := start + KeywordBatchSize
+		if end > len(payloads) {
+			end = len(payloads)
+		}
+		rows := make([]int, 0, end-start)
+		for idx := start; idx < end; idx++ {
+			rows = append(rows, idx)
+		}
+		c.writeKeywordChunk(ctx, method, paths, payloads, rows, results, true)
 	}
-	if normalized := keywordStatusPayload(status); normalized != "" {
-		body["status"] = normalized
+	return results, nil
+}
+
+// writeKeywordChunk sends rows (indexes into payloads) as one bulk request.
+// The API rejects the whole request when any row is invalid, naming the bad
+// rows in error fields such as "TargetingKeywordImport[3].text"; those rows
+// get the error and the rest are resent once.
+func (c *Client) writeKeywordChunk(ctx context.Context, method string, paths []string, payloads []map[string]any, rows []int, results []KeywordWriteResult, retry bool) {
+	body := make([]any, 0, len(rows))
+	for _, row := range rows {
+		body = append(body, payloads[row])
+	}
+	resp, err := c.tryKeywordBulkWrite(ctx, method, paths, body)
+	if err == nil {
+		items := extractDataItems(resp)
+		for pos, row := range rows {
+			results[row] = KeywordWriteResult{ID: intFromAny(payloads[row]["id"])}
+			if pos < len(items) {
+				if id := intFromAny(mapFromAny(items[pos])["id"]); id > 0 {
+					results[row].ID = id
+				}
+			}
+		}
+		return
 	}
-	if bidAmount != nil {
-		ccy := "USD"
-		if currency != nil && strings.TrimSpace(*currency) != "" {
-			ccy = *currency
+
+	rowErrors := keywordRowErrors(err, len(rows))
+	if len(rowErrors) == 0 {
+		for _, row := range rows {
+			results[row] = KeywordWriteResult{ID: intFromAny(payloads[row]["id"]), Err: err}
 		}
-		body["bidAmount"] = map[string]any{
-			"amount":   fmt.Sprintf("%.4f", *bidAmount),
-			"currency": ccy,
+		return
+	}
+	remaining := make([]int, 0, len(rows))
+	for pos, row := range rows {
+		if rowErr, ok := rowErrors[pos]; ok {
+			results[row] = KeywordWriteResult{ID: intFromAny(payloads[row]["id"]), Err: rowErr}
+			continue
+		}
+		remaining = append(remaining, row)
+	}
+	if len(remaining) == 0 {
+		return
+	}
+	if !retry {
+		for _, row := range remaining {
+			results[row] = KeywordWriteResult{ID: intFromAny(payloads[row]["id"]), Err: err}
+		}
+		return
+	}
+	c.writeKeywordChunk(ctx, method, paths, payloads, remaining, results, false)
+}
+
+var bulkFieldIndexPattern = regexp.MustCompile(`\[(\d+)\]`)
+
+// keywordRowErrors maps indexed API error details to positions in the
+// request body. It returns nil when the error does not name any row.
+func keywordRowErrors(err error, rowCount int) map[int]error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Details) == 0 {
+		return nil
+	}
+	rowErrors := map[int]error{}
+	for _, detail := range apiErr.Details {
+		match := bulkFieldIndexPattern.FindStringSubmatch(detail.Field)
+		if match == nil {
+			return nil
+		}
+		pos, convErr := strconv.Atoi(match[1])
+		if convErr != nil || pos < 0 || pos >= rowCount {
+			return nil
 		}
+		message := firstNonEmptyString(detail.Message, detail.MessageCode, apiErr.Message)
+		if detail.Field != "" {
+			message += " (" + detail.Field + ")"
+		}
+		rowErrors[pos] = &APIError{StatusCode: apiErr.StatusCode, Message: message, Details: []APIErrorDetail{detail}}
 	}
-	return c.tryKeywordBulkWrite(ctx, http.MethodPut, paths, []any{body})
+	return rowErrors
 }
 
 func (c *Client) DeleteKeyword(ctx context.Context, campaignID, adGroupID, keywordID int) error {
@@ -874,7 +1101,7 @@ func (c *Client) UpdateCampaignNegativeKeywordStatus(ctx context.Context, campai
 	return c.updateNegativeKeywordStatusWithFallbacks(ctx, fmt.Sprintf("campaigns/%d/negativekeywords", campaignID), negativeKeywordID, status)
 }
 
-func (c *Client) FetchAdGroupDailyMetrics(ctx context.Context, startDate, endDate time.Time, campaignID, adGroupID int) ([]AdGroupDailyReport, error) {
+func (c *Client) FetchAdGroupDailyMetrics(ctx context.Context, startDate, endDate time.Time, options ReportOptions, campaignID, adGroupID int) ([]AdGroupDailyReport, error) {
 	auth, err := c.auth(ctx)
 	if err != nil {
 		return nil, err
@@ -885,17 +1112,18 @@ func (c *Client) FetchAdGroupDailyMetrics(ctx context.Context, startDate, endDat
 	body := map[string]any{
 		"startTime":   start,
 		"endTime":     end,
-		"granularity": "DAILY",
+		"granularity": options.granularity(),
 		"selector": map[string]any{
 			"orderBy":    []any{map[string]any{"field": "impressions", "sortOrder": "DESCENDING"}},
 			"conditions": []any{map[string]any{"field": "adGroupId", "operator": "EQUALS", "values": []string{fmt.Sprintf("%d", adGroupID)}}},
 			"pagination": map[string]any{"offset": 0, "limit": 1000},
 		},
-		"timeZone":                   "UTC",
+		"timeZone":                   options.timeZone(),
 		"returnRecordsWithNoMetrics": true,
 		"returnRowTotals":            true,
 		"returnGrandTotals":          false,
 	}
+	options.apply(body)
 
 	payload, err := c.postJSON(ctx, fmt.Sprintf("%s/reports/campaigns/%d/adgroups", appleAdsAPIBase, campaignID), auth, body)
 	if err != nil {
@@ -906,6 +1134,7 @@ func (c *Client) FetchAdGroupDailyMetrics(ctx context.Context, startDate, endDat
 	for _, rowAny := range rows {
 		row := mapFromAny(rowAny)
 		meta := mapFromAny(row["metadata"])
+		dimensions := options.dimensions(meta)
 		rowCampaignID := intFromAny(meta["campaignId"])
 		rowAdGroupID := intFromAny(meta["adGroupId"])
 		if rowAdGroupID != 0 && rowAdGroupID != adGroupID {
@@ -919,7 +1148,7 @@ func (c *Client) FetchAdGroupDailyMetrics(ctx context.Context, startDate, endDat
 		if granular, ok := row["granularity"].([]any); ok && len(granular) > 0 {
 			for _, entryAny := range granular {
 				entry := mapFromAny(entryAny)
-				date := normalizeDateKey(firstNonEmptyString(stringFromAny(entry["date"]), start))
+				date := ReportPeriodKey(firstNonEmptyString(stringFromAny(entry["date"]), start), options.Granularity)
 				metrics := parseMetrics(entry)
 				cpt := 0.0
 				if metrics.taps > 0 {
@@ -936,13 +1165,14 @@ func (c *Client) FetchAdGroupDailyMetrics(ctx context.Context, startDate, endDat
 					Spend:        metrics.spend,
 					CPT:          cpt,
 					CurrencyCode: metrics.currency,
+					Dimensions:   dimensions,
 				})
 			}
 			continue
 		}
 
 		rawDate := firstNonEmptyString(stringFromAny(meta["date"]), stringFromAny(row["date"]), start)
-		date := normalizeDateKey(rawDate)
+		date := ReportPeriodKey(rawDate, options.Granularity)
 		metrics := parseMetrics(mapFromAny(row["total"]))
 		cpt := 0.0
 		if metrics.taps > 0 {
@@ -959,6 +1189,7 @@ func (c *Client) FetchAdGroupDailyMetrics(ctx context.Context, startDate, endDat
 			Spend:        metrics.spend,
 			CPT:          cpt,
 			CurrencyCode: metrics.currency,
+			Dimensions:   dimensions,
 		})
 	}
 
@@ -966,7 +1197,99 @@ func (c *Client) FetchAdGroupDailyMetrics(ctx context.Context, startDate, endDat
 	return results, nil
 }
 
-func (c *Client) FetchKeywordDailyMetrics(ctx context.Context, startDate, endDate time.Time, campaignID, adGroupID int) ([]KeywordDailyReport, error) {
+// FetchOrgTimeZone returns the IANA time zone of the org the client acts
+// for, as listed by /acls. ORTZ reports and daily budgets follow it.
+func (c *Client) FetchOrgTimeZone(ctx context.Context) (string, error) {
+	auth, err := c.auth(ctx)
+	if err != nil {
+		return "", err
+	}
+	payload, err := c.getJSON(ctx, appleAdsAPIBase+"/acls", auth)
+	if err != nil {
+		return "", err
+	}
+	for _, item := range toAnySlice(payload["data"]) {
+		org := mapFromAny(item)
+		if strconv.Itoa(intFromAny(org["orgId"])) != auth.orgID {
+			continue
+		}
+		zone := strings.TrimSpace(stringFromAny(org["timeZone"]))
+		if zone == "" {
+			return "", fmt.Errorf("org %s has no timeZone in /acls", auth.orgID)
+		}
+		return zone, nil
+	}
+	return "", fmt.Errorf("org %s is not listed in /acls", auth.orgID)
+}
+
+// FetchCampaignHourlySpend reports every campaign's spend per hour of day,
+// in the org's time zone so hours line up with daily budgets.
+func (c *Client) FetchCampaignHourlySpend(ctx context.Context, day time.Time) ([]CampaignHourlySpend, error) {
+	auth, err := c.auth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	date := dateOnly(day)
+	body := map[string]any{
+		"startTime":   date,
+		"endTime":     date,
+		"granularity": "HOURLY",
+		"selector": map[string]any{
+			"orderBy":    []any{map[string]any{"field": "campaignId", "sortOrder": "ASCENDING"}},
+			"pagination": map[string]any{"offset": 0, "limit": 1000},
+		},
+		"timeZone":                   ReportTimeZoneORTZ,
+		"returnRecordsWithNoMetrics": false,
+		"returnRowTotals":            false,
+		"returnGrandTotals":          false,
+	}
+	payload, err := c.postJSON(ctx, appleAdsAPIBase+"/reports/campaigns", auth, body)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]CampaignHourlySpend, 0, 64)
+	for _, rowAny := range getReportRows(payload) {
+		row := mapFromAny(rowAny)
+		campaignID := intFromAny(mapFromAny(row["metadata"])["campaignId"])
+		if campaignID <= 0 {
+			continue
+		}
+		granular, _ := row["granularity"].([]any)
+		for idx, entryAny := range granular {
+			entry := mapFromAny(entryAny)
+			metrics := parseMetrics(entry)
+			results = append(results, CampaignHourlySpend{
+				CampaignID:   campaignID,
+				Hour:         reportHour(stringFromAny(entry["date"]), idx),
+				Impressions:  metrics.impressions,
+				Taps:         metrics.taps,
+				Spend:        metrics.spend,
+				CurrencyCode: metrics.currency,
+			})
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].CampaignID != results[j].CampaignID {
+			return results[i].CampaignID < results[j].CampaignID
+		}
+		return results[i].Hour < results[j].Hour
+	})
+	return results, nil
+}
+
+// reportHour reads the hour from an hourly row's date, which the API has sent
+// as "2006-01-02 15:04" and as a full timestamp; fallback is the row's index.
+func reportHour(raw string, fallback int) int {
+	trimmed := strings.TrimSpace(raw)
+	if len(trimmed) > len("2006-01-02") {
+		if parsed, ok := parseReportTime(trimmed); ok {
+			return parsed.Hour()
+		}
+	}
+	return fallback
+}
+
+func (c *Client) FetchKeywordDailyMetrics(ctx context.Context, startDate, endDate time.Time, options ReportOptions, campaignID, adGroupID int) ([]KeywordDailyReport, error) {
 	auth, err := c.auth(ctx)
 	if err != nil {
 		return nil, err
@@ -977,16 +1300,17 @@ func (c *Client) FetchKeywordDailyMetrics(ctx context.Context, startDate, endDat
 	body := map[string]any{
 		"startTime":   start,
 		"endTime":     end,
-		"granularity": "DAILY",
+		"granularity": options.granularity(),
 		"selector": map[string]any{
 			"orderBy":    []any{map[string]any{"field": "impressions", "sortOrder": "DESCENDING"}},
 			"pagination": map[string]any{"offset": 0, "limit": 1000},
 		},
-		"timeZone":                   "UTC",
+		"timeZone":                   options.timeZone(),
 		"returnRecordsWithNoMetrics": true,
 		"returnRowTotals":            true,
 		"returnGrandTotals":          false,
 	}
+	options.apply(body)
 
 	payload, err := c.postJSON(
 		ctx,
@@ -1003,6 +1327,7 @@ func (c *Client) FetchKeywordDailyMetrics(ctx context.Context, startDate, endDat
 	for _, rowAny := range rows {
 		row := mapFromAny(rowAny)
 		meta := mapFromAny(row["metadata"])
+		dimensions := options.dimensions(meta)
 		keywordID := intFromAny(firstNonEmptyAny(meta["keywordId"], meta["targetingKeywordId"], meta["id"]))
 		if keywordID <= 0 {
 			continue
@@ -1027,7 +1352,7 @@ func (c *Client) FetchKeywordDailyMetrics(ctx context.Context, startDate, endDat
 		if granular, ok := row["granularity"].([]any); ok && len(granular) > 0 {
 			for _, entryAny := range granular {
 				entry := mapFromAny(entryAny)
-				date := normalizeDateKey(firstNonEmptyString(stringFromAny(entry["date"]), start))
+				date := ReportPeriodKey(firstNonEmptyString(stringFromAny(entry["date"]), start), options.Granularity)
 				metrics := parseMetrics(entry)
 				cpt := 0.0
 				if metrics.taps > 0 {
@@ -1047,13 +1372,14 @@ func (c *Client) FetchKeywordDailyMetrics(ctx context.Context, startDate, endDat
 					Spend:        metrics.spend,
 					CPT:          cpt,
 					CurrencyCode: metrics.currency,
+					Dimensions:   dimensions,
 				})
 			}
 			continue
 		}
 
 		rawDate := firstNonEmptyString(stringFromAny(meta["date"]), stringFromAny(row["date"]), start)
-		date := normalizeDateKey(rawDate)
+		date := ReportPeriodKey(rawDate, options.Granularity)
 		metrics := parseMetrics(mapFromAny(row["total"]))
 		cpt := 0.0
 		if metrics.taps > 0 {
@@ -1073,6 +1399,7 @@ func (c *Client) FetchKeywordDailyMetrics(ctx context.Context, startDate, endDat
 			Spend:        metrics.spend,
 			CPT:          cpt,
 			CurrencyCode: metrics.currency,
+			Dimensions:   dimensions,
 		})
 	}
 
@@ -1080,7 +1407,7 @@ func (c *Client) FetchKeywordDailyMetrics(ctx context.Context, startDate, endDat
 	return results, nil
 }
 
-func (c *Client) FetchSearchTermDailyMetrics(ctx context.Context, startDate, endDate time.Time, campaignID, adGroupID int) ([]SearchTermDailyReport, error) {
+func (c *Client) FetchSearchTermDailyMetrics(ctx context.Context, startDate, endDate time.Time, options ReportOptions, campaignID, adGroupID int) ([]SearchTermDailyReport, error) {
 	auth, err := c.auth(ctx)
 	if err != nil {
 		return nil, err
@@ -1091,16 +1418,17 @@ func (c *Client) FetchSearchTermDailyMetrics(ctx context.Context, startDate, end
 	body := map[string]any{
 		"startTime":   start,
 		"endTime":     end,
-		"granularity": "DAILY",
+		"granularity": options.granularity(),
 		"selector": map[string]any{
 			"orderBy":    []any{map[string]any{"field": "impressions", "sortOrder": "DESCENDING"}},
 			"pagination": map[string]any{"offset": 0, "limit": 1000},
 		},
-		"timeZone":                   "ORTZ",
+		"timeZone":                   options.timeZone(),
 		"returnRecordsWithNoMetrics": false,
 		"returnRowTotals":            false,
 		"returnGrandTotals":          false,
 	}
+	options.apply(body)
 
 	payload, err := c.postJSON(
 		ctx,
@@ -1117,6 +1445,7 @@ func (c *Client) FetchSearchTermDailyMetrics(ctx context.Context, startDate, end
 	for _, rowAny := range rows {
 		row := mapFromAny(rowAny)
 		meta := mapFromAny(row["metadata"])
+		dimensions := options.dimensions(meta)
 		term := firstNonEmptyString(stringFromAny(meta["searchTermText"]), stringFromAny(meta["searchTerm"]), stringFromAny(meta["term"]))
 		term = strings.TrimSpace(term)
 		if term == "" {
@@ -1126,7 +1455,7 @@ func (c *Client) FetchSearchTermDailyMetrics(ctx context.Context, startDate, end
 		if granular, ok := row["granularity"].([]any); ok && len(granular) > 0 {
 			for _, entryAny := range granular {
 				entry := mapFromAny(entryAny)
-				date := normalizeDateKey(firstNonEmptyString(stringFromAny(entry["date"]), start))
+				date := ReportPeriodKey(firstNonEmptyString(stringFromAny(entry["date"]), start), options.Granularity)
 				metrics := parseMetrics(entry)
 				cpt := 0.0
 				if metrics.taps > 0 {
@@ -1143,13 +1472,14 @@ func (c *Client) FetchSearchTermDailyMetrics(ctx context.Context, startDate, end
 					Spend:          metrics.spend,
 					CPT:            cpt,
 					CurrencyCode:   metrics.currency,
+					Dimensions:     dimensions,
 				})
 			}
 			continue
 		}
 
 		rawDate := firstNonEmptyString(stringFromAny(meta["date"]), stringFromAny(row["date"]), start)
-		date := normalizeDateKey(rawDate)
+		date := ReportPeriodKey(rawDate, options.Granularity)
 		metrics := parseMetrics(mapFromAny(row["total"]))
 		cpt := 0.0
 		if metrics.taps > 0 {
@@ -1166,6 +1496,7 @@ func (c *Client) FetchSearchTermDailyMetrics(ctx context.Context, startDate, end
 			Spend:          metrics.spend,
 			CPT:            cpt,
 			CurrencyCode:   metrics.currency,
+			Dimensions:     dimensions,
 		})
 	}
 
@@ -1396,10 +1727,10 @@ func isTrustedAppleHost(host string) bool {
 	return host == "apple.com" || strings.HasSuffix(host, ".apple.com")
 }
 
-func (c *Client) tryKeywordBulkWrite(ctx context.Context, method string, paths []string, body any) error {
+func (c *Client) tryKeywordBulkWrite(ctx context.Context, method string, paths []string, body any) (map[string]any, error) {
 	auth, err := c.auth(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	var lastErr error
 	for i, path := range paths {
@@ -1413,20 +1744,19 @@ func (c *Client) tryKeywordBulkWrite(ctx context.Context, method string, paths [
 		default:
 			err = errors.New("unsupported method")
 		}
-		_ = resp
 		if err == nil {
-			return nil
+			return resp, nil
 		}
 		lastErr = err
 		if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == http.StatusNotFound && i == 0 {
 			continue
 		}
-		return err
+		return nil, err
 	}
 	if lastErr != nil {
-		return lastErr
+		return nil, lastErr
 	}
-	return errors.New("keyword bulk write failed")
+	return nil, errors.New("keyword bulk write failed")
 }
 
 func (c *Client) tryNegativeBulkWrite(ctx context.Context, method string, paths []string, body any) error {
@@ -1720,6 +2050,60 @@ func parseCustomReport(source map[string]any) *CustomReport {
 	}
 }
 
+func parseCampaignSummary(source map[string]any) (CampaignSummary, bool) {
+	id := intFromAny(source["id"])
+	if id <= 0 {
+		return CampaignSummary{}, false
+	}
+	name := strings.TrimSpace(stringFromAny(source["name"]))
+	if name == "" {
+		name = fmt.Sprintf("Campaign %d", id)
+	}
+	budget, budgetCurrency := parseBid(mapFromAny(source["budgetAmount"]))
+	dailyBudget, dailyCurrency := parseBid(mapFromAny(source["dailyBudgetAmount"]))
+	currency := dailyCurrency
+	if currency == nil {
+		currency = budgetCurrency
+	}
+	var countryReasons map[string][]string
+	for country, reasons := range mapFromAny(source["countryOrRegionServingStateReasons"]) {
+		if countryReasons == nil {
+			countryReasons = map[string][]string{}
+		}
+		countryReasons[country] = toStringSlice(reasons)
+	}
+	var budgetOrders []int
+	for _, order := range toAnySlice(source["budgetOrders"]) {
+		if orderID := intFromAny(order); orderID > 0 {
+			budgetOrders = append(budgetOrders, orderID)
+		}
+	}
+	return CampaignSummary{
+		ID:                                 id,
+		AdamID:                             intFromAny(source["adamId"]),
+		Name:                               name,
+		Status:                             strings.ToUpper(strings.TrimSpace(stringFromAny(source["status"]))),
+		ServingStatus:                      strings.ToUpper(strings.TrimSpace(stringFromAny(source["servingStatus"]))),
+		ServingStateReasons:                toStringSlice(source["servingStateReasons"]),
+		CountryOrRegionServingStateReasons: countryReasons,
+		DisplayStatus:                      strings.ToUpper(strings.TrimSpace(stringFromAny(source["displayStatus"]))),
+		BudgetAmount:                       budget,
+		DailyBudgetAmount:                  dailyBudget,
+		Currency:                           currency,
+		CountriesOrRegions:                 toStringSlice(source["countriesOrRegions"]),
+		SupplySources:                      toStringSlice(source["supplySources"]),
+		AdChannelType:                      strings.ToUpper(strings.TrimSpace(stringFromAny(source["adChannelType"]))),
+		BillingEvent:                       strings.ToUpper(strings.TrimSpace(stringFromAny(source["billingEvent"]))),
+		PaymentModel:                       strings.ToUpper(strings.TrimSpace(stringFromAny(source["paymentModel"]))),
+		StartTime:                          toStringPtr(source["startTime"]),
+		EndTime:                            toStringPtr(source["endTime"]),
+		BudgetOrders:                       budgetOrders,
+		Deleted:                            boolFromAny(source["deleted"]),
+		CreationTime:                       toStringPtr(source["creationTime"]),
+		ModificationTime:                   toStringPtr(source["modificationTime"]),
+	}, true
+}
+
 func parseAdSummary(source map[string]any) (AdSummary, bool) {
 	id := intFromAny(source["id"])
 	if id <= 0 {
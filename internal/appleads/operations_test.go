@@ -8,10 +8,14 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 type roundTripFunc func(*http.Request) (*http.Response, error)
@@ -56,6 +60,93 @@ func TestUpdateKeywordIncludesMatchType(t *testing.T) {
 	}
 }
 
+func TestAddKeywordsMapsRowErrorsAndRetriesRemainingRows(t *testing.T) {
+	t.Setenv(credentialsEnvJSON, testCredentialsJSON(t))
+	var seenBodies []string
+	client := NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.String() == appleIDTokenURL:
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.String() == appleAdsAPIBase+"/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case strings.HasSuffix(req.URL.Path, "/targetingkeywords/bulk"):
+				body, _ := io.ReadAll(req.Body)
+				seenBodies = append(seenBodies, string(body))
+				if len(seenBodies) == 1 {
+					return jsonResponse(http.StatusBadRequest, `{"data":null,"error":{"errors":[{"messageCode":"INVALID_INPUT","message":"text is too long","field":"TargetingKeywordImport[1].text"}]}}`), nil
+				}
+				return jsonResponse(http.StatusOK, `{"data":[{"id":501,"text":"alpha"},{"id":503,"text":"gamma"}]}`), nil
+			default:
+				return jsonResponse(http.StatusNotFound, `{"error":"unexpected request: `+req.Method+` `+req.URL.String()+`"}`), nil
+			}
+		}),
+	})
+
+	results, err := client.AddKeywords(context.Background(), 10, 20, []KeywordWrite{
+		{Text: "alpha", MatchType: "EXACT"},
+		{Text: strings.Repeat("x", 120), MatchType: "EXACT"},
+		{Text: "gamma", MatchType: "BROAD"},
+	})
+	if err != nil {
+		t.Fatalf("add keywords failed: %v", err)
+	}
+	if len(seenBodies) != 2 {
+		t.Fatalf("expected a rejected request and one retry, got %d requests", len(seenBodies))
+	}
+	if strings.Contains(seenBodies[1], "xxxx") || !strings.Contains(seenBodies[1], `"gamma"`) {
+		t.Fatalf("expected retry to drop the rejected row, got %s", seenBodies[1])
+	}
+	if results[0].Err != nil || results[0].ID != 501 {
+		t.Fatalf("expected row 0 to succeed with id 501, got %+v", results[0])
+	}
+	if results[1].Err == nil || !strings.Contains(results[1].Err.Error(), "text is too long") {
+		t.Fatalf("expected row 1 to carry the API error, got %+v", results[1])
+	}
+	if results[2].Err != nil || results[2].ID != 503 {
+		t.Fatalf("expected row 2 to succeed with id 503, got %+v", results[2])
+	}
+}
+
+func TestUpdateKeywordsChunksRequests(t *testing.T) {
+	t.Setenv(credentialsEnvJSON, testCredentialsJSON(t))
+	var batchSizes []int
+	client := NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.String() == appleIDTokenURL:
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.String() == appleAdsAPIBase+"/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case strings.HasSuffix(req.URL.Path, "/targetingkeywords/bulk"):
+				var rows []map[string]any
+				if err := json.NewDecoder(req.Body).Decode(&rows); err != nil {
+					return jsonResponse(http.StatusBadRequest, `{"error":"bad body"}`), nil
+				}
+				batchSizes = append(batchSizes, len(rows))
+				return jsonResponse(http.StatusOK, `{"data":[]}`), nil
+			default:
+				return jsonResponse(http.StatusNotFound, `{"error":"unexpected request: `+req.Method+` `+req.URL.String()+`"}`), nil
+			}
+		}),
+	})
+
+	writes := make([]KeywordWrite, KeywordBatchSize+5)
+	for idx := range writes {
+		writes[idx] = KeywordWrite{ID: idx + 1, Status: "PAUSED"}
+	}
+	results, err := client.UpdateKeywords(context.Background(), 10, 20, writes)
+	if err != nil {
+		t.Fatalf("update keywords failed: %v", err)
+	}
+	if len(batchSizes) != 2 || batchSizes[0] != KeywordBatchSize || batchSizes[1] != 5 {
+		t.Fatalf("expected batches of %d and 5, got %v", KeywordBatchSize, batchSizes)
+	}
+	if len(results) != len(writes) || results[len(results)-1].ID != len(writes) || results[0].Err != nil {
+		t.Fatalf("unexpected results: first=%+v last=%+v", results[0], results[len(results)-1])
+	}
+}
+
 func TestFetchKeywordsSkipsDeletedRows(t *testing.T) {
 	t.Setenv(credentialsEnvJSON, testCredentialsJSON(t))
 
@@ -126,6 +217,603 @@ func TestUpdateCampaignStatusReturnsUpdatedSummary(t *testing.T) {
 	}
 }
 
+func TestFetchCampaignParsesServingDetail(t *testing.T) {
+	t.Setenv(credentialsEnvJSON, testCredentialsJSON(t))
+
+	client := NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.String() == appleIDTokenURL:
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.String() == appleAdsAPIBase+"/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.Method == http.MethodGet && req.URL.Path == "/api/v5/campaigns/7":
+				return jsonResponse(http.StatusOK, `{"data":{"id":7,"adamId":99,"name":"Brand","status":"ENABLED","servingStatus":"NOT_RUNNING","servingStateReasons":["NO_PAYMENT_METHOD_ON_FILE"],"countryOrRegionServingStateReasons":{"GB":["APP_NOT_ELIGIBLE"]},"displayStatus":"ON_HOLD","dailyBudgetAmount":{"amount":"50.00","currency":"GBP"},"countriesOrRegions":["GB","US"],"supplySources":["APPSTORE_SEARCH_RESULTS"],"startTime":"2026-01-01T00:00:00.000"}}`), nil
+			default:
+				return jsonResponse(http.StatusNotFound, `{"error":"unexpected request: `+req.Method+` `+req.URL.String()+`"}`), nil
+			}
+		}),
+	})
+
+	campaign, err := client.FetchCampaign(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("fetch campaign failed: %v", err)
+	}
+	if campaign.ServingStatus != "NOT_RUNNING" || campaign.DisplayStatus != "ON_HOLD" || strings.Join(campaign.ServingStateReasons, ",") != "NO_PAYMENT_METHOD_ON_FILE" {
+		t.Fatalf("unexpected serving state: %+v", campaign)
+	}
+	if campaign.DailyBudgetAmount == nil || *campaign.DailyBudgetAmount != 50 || campaign.Currency == nil || *campaign.Currency != "GBP" || campaign.BudgetAmount != nil {
+		t.Fatalf("unexpected budgets: %+v", campaign)
+	}
+	if strings.Join(campaign.CountryOrRegionServingStateReasons["GB"], ",") != "APP_NOT_ELIGIBLE" || strings.Join(campaign.CountriesOrRegions, ",") != "GB,US" ||
+		campaign.StartTime == nil || campaign.EndTime != nil {
+		t.Fatalf("unexpected countries or dates: %+v", campaign)
+	}
+}
+
+func TestUpdateCampaignSendsOnlyChangedFields(t *testing.T) {
+	t.Setenv(credentialsEnvJSON, testCredentialsJSON(t))
+
+	var seenBody string
+	client := NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.String() == appleIDTokenURL:
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.String() == appleAdsAPIBase+"/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.Method == http.MethodPut && req.URL.Path == "/api/v5/campaigns/7":
+				body, _ := io.ReadAll(req.Body)
+				seenBody = string(body)
+				return jsonResponse(http.StatusOK, `{"data":{"id":7,"name":"Brand UK","status":"ENABLED","countriesOrRegions":["GB"]}}`), nil
+			default:
+				return jsonResponse(http.StatusNotFound, `{"error":"unexpected request: `+req.Method+` `+req.URL.String()+`"}`), nil
+			}
+		}),
+	})
+
+	name, cleared := "Brand UK", ""
+	updated, err := client.UpdateCampaign(context.Background(), 7, CampaignUpdate{Name: &name, EndTime: &cleared})
+	if err != nil {
+		t.Fatalf("update campaign failed: %v", err)
+	}
+	if updated.Name != "Brand UK" {
+		t.Fatalf("unexpected campaign: %+v", updated)
+	}
+	if seenBody != `{"campaign":{"endTime":null,"name":"Brand UK"},"clearGeoTargetingOnCountryOrRegionChange":false}` {
+		t.Fatalf("unexpected request body: %s", seenBody)
+	}
+
+	client.SetCampaignProtection(CampaignProtection{IDs: []int{7}})
+	budget := 1000.0
+	if _, err := client.UpdateCampaign(context.Background(), 7, CampaignUpdate{BudgetAmount: &budget, BudgetCurrency: "GBP"}); !errors.Is(err, ErrProtectedCampaign) {
+		t.Fatalf("expected a lifetime budget change to be protected, got %v", err)
+	}
+	if _, err := client.UpdateCampaign(context.Background(), 7, CampaignUpdate{Name: &name}); err != nil {
+		t.Fatalf("expected a rename of a protected campaign to be allowed, got %v", err)
+	}
+}
+
+func TestCreateCampaignUsesPlacementCombination(t *testing.T) {
+	t.Setenv(credentialsEnvJSON, testCredentialsJSON(t))
+
+	var seenBody map[string]any
+	client := NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.String() == appleIDTokenURL:
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.String() == appleAdsAPIBase+"/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.Method == http.MethodPost && req.URL.Path == "/api/v5/campaigns":
+				seenBody = nil
+				_ = json.NewDecoder(req.Body).Decode(&seenBody)
+				return jsonResponse(http.StatusOK, `{"data":{"id":9,"name":"Launch","status":"ENABLED"}}`), nil
+			default:
+				return jsonResponse(http.StatusNotFound, `{"error":"unexpected request: `+req.Method+` `+req.URL.String()+`"}`), nil
+			}
+		}),
+	})
+
+	cases := map[string][3]string{
+		"":              {"SEARCH", "APPSTORE_SEARCH_RESULTS", "TAPS"},
+		"today-tab":     {"DISPLAY", "APPSTORE_TODAY_TAB", "IMPRESSIONS"},
+		"Search-Tab":    {"DISPLAY", "APPSTORE_SEARCH_TAB", "IMPRESSIONS"},
+		"product-pages": {"DISPLAY", "APPSTORE_PRODUCT_PAGES_BROWSE", "TAPS"},
+	}
+	for placement, want := range cases {
+		if _, err := client.CreateCampaign(context.Background(), "Launch", "ENABLED", 10, "USD", "DAILY", "1", []string{"US"}, "", "", placement); err != nil {
+			t.Fatalf("create %q failed: %v", placement, err)
+		}
+		sources, _ := seenBody["supplySources"].([]any)
+		if seenBody["adChannelType"] != want[0] || len(sources) != 1 || sources[0] != want[1] || seenBody["billingEvent"] != want[2] {
+			t.Fatalf("placement %q sent %v", placement, seenBody)
+		}
+	}
+	if _, err := client.CreateCampaign(context.Background(), "Launch", "ENABLED", 10, "USD", "DAILY", "1", nil, "", "", "watch-tab"); err == nil || !strings.Contains(err.Error(), "today-tab") {
+		t.Fatalf("expected an unknown placement to list the valid ones, got %v", err)
+	}
+}
+
+func TestBudgetOrdersUnwrapEnvelopeAndSendPartialUpdates(t *testing.T) {
+	t.Setenv(credentialsEnvJSON, testCredentialsJSON(t))
+
+	var seenBody string
+	client := NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.String() == appleIDTokenURL:
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.String() == appleAdsAPIBase+"/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.Method == http.MethodGet && req.URL.Path == "/api/v5/budgetorders":
+				return jsonResponse(http.StatusOK, `{"data":[{"bo":{"id":2,"name":"December","status":"ACTIVE","budget":{"amount":"5000","currency":"USD"},"startDate":"2026-12-01T00:00:00.000","orderNumber":"PO-2"}},{"bo":{"id":1,"name":"November","status":"COMPLETED"}}],"pagination":{"totalResults":2}}`), nil
+			case req.Method == http.MethodPut && req.URL.Path == "/api/v5/budgetorders/2":
+				body, _ := io.ReadAll(req.Body)
+				seenBody = string(body)
+				return jsonResponse(http.StatusOK, `{"data":{"bo":{"id":2,"name":"December","status":"ACTIVE","budget":{"amount":"6000","currency":"USD"}}}}`), nil
+			default:
+				return jsonResponse(http.StatusNotFound, `{"error":"unexpected request: `+req.Method+` `+req.URL.String()+`"}`), nil
+			}
+		}),
+	})
+
+	orders, err := client.FetchBudgetOrders(context.Background())
+	if err != nil {
+		t.Fatalf("fetch budget orders failed: %v", err)
+	}
+	if len(orders) != 2 || orders[0].ID != 1 || orders[1].OrderNumber != "PO-2" || orders[1].BudgetAmount == nil || *orders[1].BudgetAmount != 5000 || *orders[1].StartDate != "2026-12-01T00:00:00.000" {
+		t.Fatalf("unexpected budget orders: %+v", orders)
+	}
+
+	amount := 6000.0
+	updated, err := client.UpdateBudgetOrder(context.Background(), 2, BudgetOrderWrite{BudgetAmount: &amount, Currency: "usd"})
+	if err != nil {
+		t.Fatalf("update budget order failed: %v", err)
+	}
+	if *updated.BudgetAmount != 6000 {
+		t.Fatalf("unexpected updated order: %+v", updated)
+	}
+	if seenBody != `{"bo":{"budget":{"amount":"6000.0000","currency":"USD"}},"orgIds":[123]}` {
+		t.Fatalf("unexpected request body: %s", seenBody)
+	}
+	if _, err := client.UpdateBudgetOrder(context.Background(), 2, BudgetOrderWrite{}); err == nil {
+		t.Fatal("expected an empty update to fail")
+	}
+	if _, err := budgetOrderPayload("org-a", BudgetOrderWrite{}); err == nil || !strings.Contains(err.Error(), "numeric orgId") {
+		t.Fatalf("expected a non-numeric orgId to fail, got %v", err)
+	}
+}
+
+func TestFetchCampaignHourlySpendReadsHoursInOrgTimeZone(t *testing.T) {
+	t.Setenv(credentialsEnvJSON, testCredentialsJSON(t))
+
+	var seenBody map[string]any
+	client := NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.String() == appleIDTokenURL:
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.String() == appleAdsAPIBase+"/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.Method == http.MethodPost && req.URL.Path == "/api/v5/reports/campaigns":
+				_ = json.NewDecoder(req.Body).Decode(&seenBody)
+				return jsonResponse(http.StatusOK, `{"data":{"reportingDataResponse":{"row":[{"metadata":{"campaignId":7},"granularity":[{"date":"2026-10-18 09:00","localSpend":{"amount":"3.50","currency":"USD"}},{"date":"2026-10-18T08:00:00.000","localSpend":{"amount":"1.25","currency":"USD"}}]}]}}}`), nil
+			default:
+				return jsonResponse(http.StatusNotFound, `{"error":"unexpected request: `+req.Method+` `+req.URL.String()+`"}`), nil
+			}
+		}),
+	})
+
+	rows, err := client.FetchCampaignHourlySpend(context.Background(), time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("fetch hourly spend failed: %v", err)
+	}
+	if seenBody["granularity"] != "HOURLY" || seenBody["timeZone"] != "ORTZ" || seenBody["startTime"] != "2026-10-18" {
+		t.Fatalf("unexpected request: %v", seenBody)
+	}
+	if len(rows) != 2 || rows[0].Hour != 8 || rows[0].Spend != 1.25 || rows[1].Hour != 9 || rows[1].CampaignID != 7 {
+		t.Fatalf("unexpected rows: %+v", rows)
+	}
+}
+
+func TestDailyReportsShareTheDefaultTimeZone(t *testing.T) {
+	t.Setenv(credentialsEnvJSON, testCredentialsJSON(t))
+
+	seenZones := map[string]any{}
+	client := NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.String() == appleIDTokenURL:
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.String() == appleAdsAPIBase+"/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.Method == http.MethodPost && strings.HasPrefix(req.URL.Path, "/api/v5/reports/campaigns/7/"):
+				var body map[string]any
+				_ = json.NewDecoder(req.Body).Decode(&body)
+				seenZones[req.URL.Path] = body["timeZone"]
+				return jsonResponse(http.StatusOK, `{"data":{"reportingDataResponse":{"row":[]}}}`), nil
+			default:
+				return jsonResponse(http.StatusNotFound, `{"error":"unexpected request: `+req.Method+` `+req.URL.String()+`"}`), nil
+			}
+		}),
+	})
+
+	ctx := context.Background()
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := client.FetchAdGroupDailyMetrics(ctx, day, day, ReportOptions{}, 7, 8); err != nil {
+		t.Fatalf("ad group report failed: %v", err)
+	}
+	if _, err := client.FetchKeywordDailyMetrics(ctx, day, day, ReportOptions{}, 7, 8); err != nil {
+		t.Fatalf("keyword report failed: %v", err)
+	}
+	if _, err := client.FetchSearchTermDailyMetrics(ctx, day, day, ReportOptions{}, 7, 8); err != nil {
+		t.Fatalf("search term report failed: %v", err)
+	}
+	if len(seenZones) != 3 {
+		t.Fatalf("expected three report requests, got %v", seenZones)
+	}
+	for path, zone := range seenZones {
+		if zone != DefaultReportTimeZone {
+			t.Errorf("%s used timeZone %v, want %s", path, zone, DefaultReportTimeZone)
+		}
+	}
+
+	if _, err := client.FetchKeywordDailyMetrics(ctx, day, day, ReportOptions{TimeZone: ReportTimeZoneUTC}, 7, 8); err != nil {
+		t.Fatalf("keyword report failed: %v", err)
+	}
+	if zone := seenZones["/api/v5/reports/campaigns/7/adgroups/8/keywords"]; zone != "UTC" {
+		t.Fatalf("explicit time zone not sent: %v", seenZones)
+	}
+}
+
+func TestKeywordReportGroupsByDimensions(t *testing.T) {
+	t.Setenv(credentialsEnvJSON, testCredentialsJSON(t))
+
+	var seenBody map[string]any
+	client := NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.String() == appleIDTokenURL:
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.String() == appleAdsAPIBase+"/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.Method == http.MethodPost && req.URL.Path == "/api/v5/reports/campaigns/7/adgroups/8/keywords":
+				_ = json.NewDecoder(req.Body).Decode(&seenBody)
+				return jsonResponse(http.StatusOK, `{"data":{"reportingDataResponse":{"row":[`+
+					`{"metadata":{"keywordId":11,"keywordText":"maps","countryOrRegion":"US","deviceClass":"IPHONE"},"granularity":[{"date":"2026-10-01","localSpend":{"amount":"4.00","currency":"USD"},"taps":2}]},`+
+					`{"metadata":{"keywordId":11,"keywordText":"maps","countryOrRegion":"GB","deviceClass":null},"granularity":[{"date":"2026-10-01","localSpend":{"amount":"1.00","currency":"USD"},"taps":1}]}`+
+					`]}}}`), nil
+			default:
+				return jsonResponse(http.StatusNotFound, `{"error":"unexpected request: `+req.Method+` `+req.URL.String()+`"}`), nil
+			}
+		}),
+	})
+
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	options := ReportOptions{GroupBy: []string{"countryOrRegion", "deviceClass"}}
+	rows, err := client.FetchKeywordDailyMetrics(context.Background(), day, day, options, 7, 8)
+	if err != nil {
+		t.Fatalf("keyword report failed: %v", err)
+	}
+	groupBy, _ := seenBody["groupBy"].([]any)
+	if len(groupBy) != 2 || groupBy[0] != "countryOrRegion" || groupBy[1] != "deviceClass" {
+		t.Fatalf("groupBy not sent: %v", seenBody)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("unexpected rows: %+v", rows)
+	}
+	if rows[0].Dimensions["countryOrRegion"] != "US" || rows[0].Dimensions["deviceClass"] != "IPHONE" {
+		t.Fatalf("unexpected dimensions: %+v", rows[0].Dimensions)
+	}
+	if _, ok := rows[1].Dimensions["deviceClass"]; ok || rows[1].Dimensions["countryOrRegion"] != "GB" {
+		t.Fatalf("unexpected dimensions: %+v", rows[1].Dimensions)
+	}
+
+	if field, ok := LookupReportDimension("Device"); !ok || field != "deviceClass" {
+		t.Fatalf("LookupReportDimension(Device) = %q, %v", field, ok)
+	}
+	if field, ok := LookupReportDimension("ageRange"); !ok || field != "ageRange" {
+		t.Fatalf("LookupReportDimension(ageRange) = %q, %v", field, ok)
+	}
+}
+
+func TestReportPeriodKeysAndRanges(t *testing.T) {
+	keys := []struct {
+		raw, granularity, want, start string
+	}{
+		{"2026-10-18 09:00", ReportGranularityHourly, "2026-10-18T09:00", "2026-10-18"},
+		{"2026-10-18T09:30:00.000", ReportGranularityHourly, "2026-10-18T09:00", "2026-10-18"},
+		{"2026-10-18", "", "2026-10-18", "2026-10-18"},
+		{"2026-10-12", ReportGranularityWeekly, "2026-W42", "2026-10-12"},
+		{"2027-01-01", ReportGranularityWeekly, "2026-W53", "2026-12-28"},
+		{"2026-10-01", ReportGranularityMonthly, "2026-10", "2026-10-01"},
+		{"2026-10", ReportGranularityMonthly, "2026-10", "2026-10-01"},
+	}
+	for _, tc := range keys {
+		got := ReportPeriodKey(tc.raw, tc.granularity)
+		if got != tc.want {
+			t.Errorf("ReportPeriodKey(%q, %q) = %q, want %q", tc.raw, tc.granularity, got, tc.want)
+		}
+		if start := ReportPeriodStart(got); start != tc.start {
+			t.Errorf("ReportPeriodStart(%q) = %q, want %q", got, start, tc.start)
+		}
+	}
+
+	day := func(value string) time.Time {
+		parsed, _ := time.Parse("2006-01-02", value)
+		return parsed
+	}
+	today := day("2026-10-18")
+	ranges := []struct {
+		granularity, start, end string
+		ok                      bool
+	}{
+		{ReportGranularityHourly, "2026-10-01", "2026-10-18", true},
+		{ReportGranularityHourly, "2026-09-01", "2026-09-02", false},
+		{ReportGranularityDaily, "2026-07-21", "2026-10-18", true},
+		{ReportGranularityDaily, "2026-07-20", "2026-10-18", false},
+		{ReportGranularityWeekly, "2026-10-06", "2026-10-18", false},
+		{ReportGranularityWeekly, "2026-10-05", "2026-10-18", true},
+		{ReportGranularityMonthly, "2026-08-01", "2026-10-31", true},
+		{ReportGranularityMonthly, "2026-08-01", "2026-10-30", false},
+		{ReportGranularityMonthly, "2024-11-01", "2026-10-31", true},
+		{ReportGranularityMonthly, "2024-10-31", "2026-10-31", false},
+	}
+	for _, tc := range ranges {
+		err := ValidateReportRange(tc.granularity, day(tc.start), day(tc.end), today)
+		if (err == nil) != tc.ok {
+			t.Errorf("ValidateReportRange(%s, %s, %s) = %v, want ok=%v", tc.granularity, tc.start, tc.end, err, tc.ok)
+		}
+	}
+}
+
+func TestRawPaginatedMergesPagesAndRejectsForeignHosts(t *testing.T) {
+	t.Setenv(credentialsEnvJSON, testCredentialsJSON(t))
+
+	var offsets []string
+	client := NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.String() == appleIDTokenURL:
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.String() == appleAdsAPIBase+"/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.Method == http.MethodGet && req.URL.Path == "/api/v5/budgetorders":
+				if req.Header.Get("X-AP-Context") != "orgId=123" {
+					return jsonResponse(http.StatusForbidden, `{"error":"missing org context"}`), nil
+				}
+				offset := req.URL.Query().Get("offset")
+				offsets = append(offsets, offset)
+				if offset == "0" {
+					return jsonResponse(http.StatusOK, `{"data":[{"id":1},{"id":2}],"pagination":{"totalResults":3,"startIndex":0,"itemsPerPage":2}}`), nil
+				}
+				return jsonResponse(http.StatusOK, `{"data":[{"id":3}],"pagination":{"totalResults":3,"startIndex":2,"itemsPerPage":1}}`), nil
+			default:
+				return jsonResponse(http.StatusNotFound, `{"error":"unexpected request: `+req.Method+` `+req.URL.String()+`"}`), nil
+			}
+		}),
+	})
+
+	payload, err := client.RawPaginated(context.Background(), "get", "/budgetorders?limit=2", nil)
+	if err != nil {
+		t.Fatalf("paginated request failed: %v", err)
+	}
+	data, _ := payload["data"].([]any)
+	if len(data) != 3 || strings.Join(offsets, ",") != "0,2" {
+		t.Fatalf("expected 3 rows from offsets 0,2, got %d rows from %v", len(data), offsets)
+	}
+
+	for _, path := range []string{"https://example.com/api/v5/campaigns", "http://api.searchads.apple.com/api/v5/campaigns", "https://apple.com.example.net/x"} {
+		if _, err := client.Raw(context.Background(), http.MethodGet, path, nil); err == nil {
+			t.Fatalf("expected %s to be rejected", path)
+		}
+	}
+	if _, err := client.Raw(context.Background(), "TRACE", "campaigns", nil); err == nil {
+		t.Fatal("expected unsupported method to be rejected")
+	}
+}
+
+func TestSetRateLimitSpacesRequests(t *testing.T) {
+	client := NewClient(nil)
+	client.SetRateLimit(20, 1)
+
+	started := time.Now()
+	for range 3 {
+		if err := client.waitForRateLimit(context.Background()); err != nil {
+			t.Fatalf("wait failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(started); elapsed < 90*time.Millisecond {
+		t.Fatalf("expected three requests at 20/s to take at least 100ms, took %s", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := client.waitForRateLimit(ctx); err == nil {
+		t.Fatal("expected a cancelled context to stop waiting")
+	}
+	client.SetRateLimit(0, 0)
+	if err := client.waitForRateLimit(ctx); err != nil {
+		t.Fatalf("expected no wait once the limit is removed, got %v", err)
+	}
+}
+
+func TestReadOnlyClientRefusesChangesButAllowsReports(t *testing.T) {
+	t.Setenv(credentialsEnvJSON, testCredentialsJSON(t))
+
+	var sent []string
+	client := NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.String() == appleIDTokenURL:
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.String() == appleAdsAPIBase+"/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			default:
+				sent = append(sent, req.Method+" "+req.URL.Path)
+				return jsonResponse(http.StatusOK, `{"data":{"id":1}}`), nil
+			}
+		}),
+	})
+	client.SetReadOnly(true)
+
+	if _, err := client.UpdateCampaignStatus(context.Background(), 1, "PAUSED"); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected a read-only error, got %v", err)
+	}
+	if _, err := client.Raw(context.Background(), "DELETE", "campaigns/1/adgroups/2", nil); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected a read-only error for raw deletes, got %v", err)
+	}
+	if _, err := client.FindOrgAds(context.Background(), map[string]any{}); err != nil {
+		t.Fatalf("expected /find selectors to be allowed, got %v", err)
+	}
+	if _, err := client.Raw(context.Background(), "POST", "reports/campaigns", []byte(`{}`)); err != nil {
+		t.Fatalf("expected report requests to be allowed, got %v", err)
+	}
+	want := []string{"POST /api/v5/ads/find", "POST /api/v5/reports/campaigns"}
+	if strings.Join(sent, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected requests sent: %q", sent)
+	}
+}
+
+func TestCampaignProtectionBlocksPauseDeleteAndBudgetChanges(t *testing.T) {
+	t.Setenv(credentialsEnvJSON, testCredentialsJSON(t))
+
+	var changes []string
+	client := NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.String() == appleIDTokenURL:
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.String() == appleAdsAPIBase+"/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.Method == http.MethodGet && req.URL.Path == "/api/v5/campaigns/1":
+				return jsonResponse(http.StatusOK, `{"data":{"id":1,"name":"Brand UK"}}`), nil
+			case req.Method == http.MethodGet && req.URL.Path == "/api/v5/campaigns/2":
+				return jsonResponse(http.StatusOK, `{"data":{"id":2,"name":"Generic"}}`), nil
+			default:
+				changes = append(changes, req.Method+" "+req.URL.Path)
+				return jsonResponse(http.StatusOK, `{"data":{"id":2}}`), nil
+			}
+		}),
+	})
+	client.SetCampaignProtection(CampaignProtection{IDs: []int{3}, Names: []string{"brand *"}})
+
+	ctx := context.Background()
+	if _, err := client.UpdateCampaignStatus(ctx, 1, "PAUSED"); !errors.Is(err, ErrProtectedCampaign) {
+		t.Fatalf("expected pausing a protected name to fail, got %v", err)
+	}
+	if err := client.DeleteCampaign(ctx, 3); !errors.Is(err, ErrProtectedCampaign) {
+		t.Fatalf("expected deleting a protected ID to fail, got %v", err)
+	}
+	if _, err := client.UpdateCampaignDailyBudget(ctx, 1, 10, "USD"); !errors.Is(err, ErrProtectedCampaign) {
+		t.Fatalf("expected a budget change to fail, got %v", err)
+	}
+	if _, err := client.Raw(ctx, "PUT", "campaigns/3", []byte(`{}`)); !errors.Is(err, ErrProtectedCampaign) {
+		t.Fatalf("expected a raw campaign update to fail, got %v", err)
+	}
+	if _, err := client.UpdateCampaignStatus(ctx, 1, "ENABLED"); err != nil {
+		t.Fatalf("expected enabling a protected campaign to be allowed, got %v", err)
+	}
+	if err := client.DeleteCampaign(ctx, 2); err != nil {
+		t.Fatalf("expected an unprotected campaign to be deleted, got %v", err)
+	}
+	if strings.Join(changes, ",") != "PUT /api/v5/campaigns/1,DELETE /api/v5/campaigns/2" {
+		t.Fatalf("unexpected changes sent: %q", changes)
+	}
+}
+
+func TestAuditRecorderCapturesPriorStateAndEntities(t *testing.T) {
+	t.Setenv(credentialsEnvJSON, testCredentialsJSON(t))
+
+	client := NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.String() == appleIDTokenURL:
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.String() == appleAdsAPIBase+"/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.Method == http.MethodGet && req.URL.Path == "/api/v5/campaigns/7":
+				return jsonResponse(http.StatusOK, `{"data":{"id":7,"name":"Brand","status":"ENABLED"}}`), nil
+			case req.Method == http.MethodPut && req.URL.Path == "/api/v5/campaigns/7":
+				return jsonResponse(http.StatusOK, `{"data":{"id":7,"name":"Brand","status":"PAUSED"}}`), nil
+			case req.Method == http.MethodGet && req.URL.Path == "/api/v5/campaigns/7/adgroups/8/targetingkeywords":
+				return jsonResponse(http.StatusOK, `{"data":[{"id":1,"bidAmount":{"amount":"1.00"}},{"id":2,"bidAmount":{"amount":"2.00"}}]}`), nil
+			case req.Method == http.MethodPut && req.URL.Path == "/api/v5/campaigns/7/adgroups/8/targetingkeywords/bulk":
+				return jsonResponse(http.StatusOK, `{"data":[{"id":2}]}`), nil
+			default:
+				return jsonResponse(http.StatusNotFound, `{"error":"unexpected request: `+req.Method+` `+req.URL.String()+`"}`), nil
+			}
+		}),
+	})
+	var records []AuditRecord
+	client.SetAuditRecorder(func(record AuditRecord) { records = append(records, record) })
+
+	if _, err := client.UpdateCampaignStatus(context.Background(), 7, "PAUSED"); err != nil {
+		t.Fatalf("pause failed: %v", err)
+	}
+	bid := 2.5
+	if err := client.UpdateKeyword(context.Background(), 7, 8, 2, "EXACT", "ACTIVE", &bid, nil); err != nil {
+		t.Fatalf("rebid failed: %v", err)
+	}
+	if _, err := client.FetchCampaigns(context.Background()); err == nil {
+		t.Fatal("expected the unstubbed campaign list to fail")
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected two audit records, got %+v", records)
+	}
+	pause := records[0]
+	if pause.OrgID != "123" || pause.Method != http.MethodPut || strings.Join(pause.Entities, ",") != "campaign:7" ||
+		!strings.Contains(string(pause.Before), `"ENABLED"`) || !strings.Contains(string(pause.Request), `"PAUSED"`) || pause.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected pause record: %+v", pause)
+	}
+	rebid := records[1]
+	if strings.Join(rebid.Entities, ",") != "campaign:7,adgroup:8,keyword:2" || string(rebid.Before) != `[{"bidAmount":{"amount":"2.00"},"id":2}]` {
+		t.Fatalf("unexpected rebid record: entities=%q before=%s", rebid.Entities, rebid.Before)
+	}
+}
+
+func TestAuditPriorStatePagesThroughLargeAdGroups(t *testing.T) {
+	t.Setenv(credentialsEnvJSON, testCredentialsJSON(t))
+
+	var offsets []string
+	client := NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.String() == appleIDTokenURL:
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.String() == appleAdsAPIBase+"/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.Method == http.MethodGet && req.URL.Path == "/api/v5/campaigns/7/adgroups/8/targetingkeywords":
+				offsets = append(offsets, req.URL.Query().Get("offset"))
+				offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+				items := make([]string, 0, campaignsPerPage)
+				for id := offset + 1; id <= offset+campaignsPerPage && id <= 500; id++ {
+					items = append(items, fmt.Sprintf(`{"id":%d,"bidAmount":{"amount":"1.00"}}`, id))
+				}
+				return jsonResponse(http.StatusOK, `{"data":[`+strings.Join(items, ",")+`],"pagination":{"totalResults":500}}`), nil
+			case req.Method == http.MethodPut && req.URL.Path == "/api/v5/campaigns/7/adgroups/8/targetingkeywords/bulk":
+				return jsonResponse(http.StatusOK, `{"data":[{"id":450}]}`), nil
+			default:
+				return jsonResponse(http.StatusNotFound, `{"error":"unexpected request"}`), nil
+			}
+		}),
+	})
+	var records []AuditRecord
+	client.SetAuditRecorder(func(record AuditRecord) { records = append(records, record) })
+
+	bid := 2.5
+	if err := client.UpdateKeyword(context.Background(), 7, 8, 450, "EXACT", "ACTIVE", &bid, nil); err != nil {
+		t.Fatalf("rebid failed: %v", err)
+	}
+	if len(records) != 1 || string(records[0].Before) != `[{"bidAmount":{"amount":"1.00"},"id":450}]` {
+		t.Fatalf("expected the prior state from the third page, got %+v", records)
+	}
+	if strings.Join(offsets, ",") != "0,200,400" {
+		t.Fatalf("unexpected pages fetched: %q", offsets)
+	}
+}
+
 func jsonResponse(status int, body string) *http.Response {
 	return &http.Response{
 		StatusCode: status,
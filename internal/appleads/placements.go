@@ -0,0 +1,67 @@
+package appleads
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CampaignPlacement is the channel, supply source and billing combination a
+// campaign needs to serve in one App Store placement.
+type CampaignPlacement struct {
+	Name          string `json:"name"`
+	AdChannelType string `json:"adChannelType"`
+	SupplySource  string `json:"supplySource"`
+	BillingEvent  string `json:"billingEvent"`
+	// RequiresCustomProductPage is set where every ad must use a custom
+	// product page creative.
+	RequiresCustomProductPage bool `json:"requiresCustomProductPage,omitempty"`
+	// Keywords is set where ad groups bid on keywords and search match.
+	Keywords bool `json:"keywords,omitempty"`
+}
+
+// DefaultPlacement is the search results placement campaigns had before
+// placements could be chosen.
+const DefaultPlacement = "search-results"
+
+var campaignPlacements = []CampaignPlacement{
+	{Name: "search-results", AdChannelType: "SEARCH", SupplySource: "APPSTORE_SEARCH_RESULTS", BillingEvent: "TAPS", Keywords: true},
+	{Name: "search-tab", AdChannelType: "DISPLAY", SupplySource: "APPSTORE_SEARCH_TAB", BillingEvent: "IMPRESSIONS"},
+	{Name: "today-tab", AdChannelType: "DISPLAY", SupplySource: "APPSTORE_TODAY_TAB", BillingEvent: "IMPRESSIONS", RequiresCustomProductPage: true},
+	{Name: "product-pages", AdChannelType: "DISPLAY", SupplySource: "APPSTORE_PRODUCT_PAGES_BROWSE", BillingEvent: "TAPS"},
+}
+
+// PlacementNames lists the names LookupPlacement accepts.
+func PlacementNames() []string {
+	names := make([]string, 0, len(campaignPlacements))
+	for _, placement := range campaignPlacements {
+		names = append(names, placement.Name)
+	}
+	return names
+}
+
+// LookupPlacement resolves a placement name; empty means search results.
+func LookupPlacement(name string) (CampaignPlacement, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if normalized == "" {
+		normalized = DefaultPlacement
+	}
+	for _, placement := range campaignPlacements {
+		if placement.Name == normalized {
+			return placement, nil
+		}
+	}
+	return CampaignPlacement{}, fmt.Errorf("Unknown placement %q. Use: %s", name, strings.Join(PlacementNames(), ", "))
+}
+
+// PlacementOf reports the placement a campaign serves in, from its supply
+// sources.
+func PlacementOf(campaign CampaignSummary) (CampaignPlacement, bool) {
+	for _, source := range campaign.SupplySources {
+		for _, placement := range campaignPlacements {
+			if strings.EqualFold(placement.SupplySource, source) {
+				return placement, true
+			}
+		}
+	}
+	return CampaignPlacement{}, false
+}
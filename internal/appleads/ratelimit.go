@@ -0,0 +1,73 @@
+package appleads
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket shared by every request a Client makes.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+// SetRateLimit caps outgoing API requests at perSecond with bursts of up to
+// burst requests. A non-positive perSecond removes the limit.
+func (c *Client) SetRateLimit(perSecond float64, burst int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if perSecond <= 0 {
+		c.limiter = nil
+		return
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	c.limiter = &rateLimiter{
+		interval: time.Duration(float64(time.Second) / perSecond),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+func (c *Client) waitForRateLimit(ctx context.Context) error {
+	c.mu.Lock()
+	limiter := c.limiter
+	c.mu.Unlock()
+	if limiter == nil {
+		return nil
+	}
+	return limiter.wait(ctx)
+}
+
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - l.tokens) * float64(l.interval))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
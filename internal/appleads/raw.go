@@ -0,0 +1,186 @@
+package appleads
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+)
+
+const rawPageLimit = 1000
+
+// RawResponse is the undecoded result of a passthrough request.
+type RawResponse struct {
+	StatusCode int
+	Body       []byte
+}
+
+// Raw sends an authenticated request to an arbitrary Apple Ads endpoint. path
+// is relative to the API base (e.g. "campaigns/123") or a full https URL on an
+// Apple host. Non-2xx responses are returned as *APIError.
+func (c *Client) Raw(ctx context.Context, method, path string, body []byte) (*RawResponse, error) {
+	method, endpoint, err := resolveRawRequest(method, path)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := c.auth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkRawCampaignProtection(ctx, method, endpoint); err != nil {
+		return nil, err
+	}
+	return c.sendRaw(ctx, auth, method, endpoint.String(), body)
+}
+
+// RawPaginated follows offset/limit pagination and returns one response whose
+// data array holds every page. GET requests page with offset and limit query
+// parameters; other methods page through the body's pagination object.
+func (c *Client) RawPaginated(ctx context.Context, method, path string, body []byte) (map[string]any, error) {
+	method, endpoint, err := resolveRawRequest(method, path)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := c.auth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkRawCampaignProtection(ctx, method, endpoint); err != nil {
+		return nil, err
+	}
+
+	query := endpoint.Query()
+	limit := intFromAny(query.Get("limit"))
+	offset := intFromAny(query.Get("offset"))
+	var selector map[string]any
+	if method != http.MethodGet {
+		if len(bytes.TrimSpace(body)) > 0 {
+			if err := json.Unmarshal(body, &selector); err != nil {
+				return nil, fmt.Errorf("paginated request body must be a JSON object: %w", err)
+			}
+		}
+		if selector == nil {
+			selector = map[string]any{}
+		}
+		page := mapFromAny(selector["pagination"])
+		limit = intFromAny(page["limit"])
+		offset = intFromAny(page["offset"])
+	}
+	if limit <= 0 {
+		limit = rawPageLimit
+	}
+
+	var merged map[string]any
+	items := make([]any, 0, limit)
+	for {
+		pageBody := body
+		if method == http.MethodGet {
+			query.Set("offset", strconv.Itoa(offset))
+			query.Set("limit", strconv.Itoa(limit))
+			endpoint.RawQuery = query.Encode()
+		} else {
+			selector["pagination"] = map[string]any{"offset": offset, "limit": limit}
+			if pageBody, err = json.Marshal(selector); err != nil {
+				return nil, err
+			}
+		}
+		resp, err := c.sendRaw(ctx, auth, method, endpoint.String(), pageBody)
+		if err != nil {
+			return nil, err
+		}
+		var payload map[string]any
+		if err := json.Unmarshal(resp.Body, &payload); err != nil {
+			return nil, fmt.Errorf("invalid JSON response: %w", err)
+		}
+		if merged == nil {
+			merged = payload
+		}
+		data, isList := payload["data"].([]any)
+		if !isList {
+			// Not a paginated endpoint; return the single response as is.
+			return payload, nil
+		}
+		items = append(items, data...)
+
+		total := 0
+		if page, ok := payload["pagination"].(map[string]any); ok {
+			total = intFromAny(page["totalResults"])
+		}
+		if (total > 0 && offset+limit >= total) || len(data) < limit {
+			break
+		}
+		offset += limit
+	}
+	merged["data"] = items
+	merged["pagination"] = map[string]any{"totalResults": len(items), "startIndex": 0, "itemsPerPage": len(items)}
+	return merged, nil
+}
+
+func (c *Client) sendRaw(ctx context.Context, auth *authContext, method, endpoint string, body []byte) (*RawResponse, error) {
+	var reader io.Reader
+	if len(body) > 0 {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return nil, err
+	}
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+auth.accessToken)
+	req.Header.Set("X-AP-Context", "orgId="+auth.orgID)
+
+	respBody, statusCode, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode < 200 || statusCode > 299 {
+		return nil, httpStatusError(statusCode, respBody)
+	}
+	return &RawResponse{StatusCode: statusCode, Body: respBody}, nil
+}
+
+func resolveRawRequest(method, path string) (string, *neturl.URL, error) {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return "", nil, fmt.Errorf("unsupported method %q (use GET, POST, PUT, PATCH or DELETE)", method)
+	}
+
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", nil, errors.New("API path is required")
+	}
+	base, err := neturl.Parse(appleAdsAPIBase)
+	if err != nil {
+		return "", nil, err
+	}
+	var endpoint *neturl.URL
+	switch {
+	case strings.Contains(path, "://"):
+		endpoint, err = neturl.Parse(path)
+	case strings.HasPrefix(path, "/api/"):
+		endpoint, err = neturl.Parse(base.Scheme + "://" + base.Host + path)
+	default:
+		endpoint, err = neturl.Parse(appleAdsAPIBase + "/" + strings.TrimPrefix(path, "/"))
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid API path: %w", err)
+	}
+	if !strings.EqualFold(endpoint.Scheme, "https") {
+		return "", nil, errors.New("API URL must use https")
+	}
+	if !isTrustedAppleHost(strings.ToLower(endpoint.Hostname())) {
+		return "", nil, fmt.Errorf("API host %q is not an Apple host", endpoint.Hostname())
+	}
+	return method, endpoint, nil
+}
@@ -0,0 +1,191 @@
+package appleads
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Report time zones. ORTZ is the org's own time zone, the one daily budgets
+// reset in; UTC days start at midnight UTC instead. Reports in different zones
+// split spend across days differently, so every report uses the same default.
+const (
+	ReportTimeZoneORTZ    = "ORTZ"
+	ReportTimeZoneUTC     = "UTC"
+	DefaultReportTimeZone = ReportTimeZoneORTZ
+)
+
+// Report granularities, with the date ranges Apple Ads accepts for each.
+const (
+	ReportGranularityHourly  = "HOURLY"
+	ReportGranularityDaily   = "DAILY"
+	ReportGranularityWeekly  = "WEEKLY"
+	ReportGranularityMonthly = "MONTHLY"
+)
+
+// reportDimensions maps the short --groupBy names to the report API's groupBy
+// fields, in the order they are listed.
+var reportDimensions = []struct{ name, field string }{
+	{"country", "countryOrRegion"},
+	{"device", "deviceClass"},
+	{"age", "ageRange"},
+	{"gender", "gender"},
+	{"adminArea", "adminArea"},
+	{"locality", "locality"},
+}
+
+// ReportOptions shape a metrics report request. Zero values mean ORTZ days
+// with no breakdown. GroupBy holds report API fields such as
+// "countryOrRegion"; rows then carry each field's value in Dimensions.
+type ReportOptions struct {
+	TimeZone    string
+	Granularity string
+	GroupBy     []string
+}
+
+// ReportDimensionNames lists the short names LookupReportDimension accepts.
+func ReportDimensionNames() []string {
+	names := make([]string, 0, len(reportDimensions))
+	for _, dimension := range reportDimensions {
+		names = append(names, dimension.name)
+	}
+	return names
+}
+
+// LookupReportDimension resolves a short name ("country") or an API field
+// ("countryOrRegion"), in any case, to the API field.
+func LookupReportDimension(name string) (string, bool) {
+	trimmed := strings.TrimSpace(name)
+	for _, dimension := range reportDimensions {
+		if strings.EqualFold(trimmed, dimension.name) || strings.EqualFold(trimmed, dimension.field) {
+			return dimension.field, true
+		}
+	}
+	return "", false
+}
+
+// ReportTimeZones lists the time zones reports accept.
+func ReportTimeZones() []string {
+	return []string{ReportTimeZoneORTZ, ReportTimeZoneUTC}
+}
+
+// ReportGranularities lists the granularities reports accept.
+func ReportGranularities() []string {
+	return []string{ReportGranularityHourly, ReportGranularityDaily, ReportGranularityWeekly, ReportGranularityMonthly}
+}
+
+func (o ReportOptions) timeZone() string {
+	if o.TimeZone == "" {
+		return DefaultReportTimeZone
+	}
+	return o.TimeZone
+}
+
+func (o ReportOptions) granularity() string {
+	if o.Granularity == "" {
+		return ReportGranularityDaily
+	}
+	return o.Granularity
+}
+
+// ValidateReportRange checks a start and end date (both inclusive) against the
+// range Apple Ads allows for the granularity: up to 30 days hourly, 90 days
+// daily, 14 to 365 days weekly and 3 to 24 months monthly. Hourly data is also
+// kept for only 30 days, so the start can't be older than that.
+func ValidateReportRange(granularity string, startDate, endDate, today time.Time) error {
+	if endDate.Before(startDate) {
+		return fmt.Errorf("--endDate must not be before --startDate")
+	}
+	days := int(endDate.Sub(startDate).Hours()/24) + 1
+	afterEnd := endDate.AddDate(0, 0, 1)
+	switch granularity {
+	case ReportGranularityHourly:
+		if days > 30 {
+			return fmt.Errorf("HOURLY reports cover at most 30 days; this range has %d", days)
+		}
+		if startDate.Before(today.AddDate(0, 0, -30)) {
+			return fmt.Errorf("HOURLY data is kept for 30 days; start on or after %s", dateOnly(today.AddDate(0, 0, -30)))
+		}
+	case ReportGranularityWeekly:
+		if days < 14 || days > 365 {
+			return fmt.Errorf("WEEKLY reports cover 14 to 365 days; this range has %d", days)
+		}
+	case ReportGranularityMonthly:
+		if afterEnd.Before(startDate.AddDate(0, 3, 0)) || afterEnd.After(startDate.AddDate(0, 24, 0)) {
+			return fmt.Errorf("MONTHLY reports cover 3 to 24 months; %s to %s is outside that", dateOnly(startDate), dateOnly(endDate))
+		}
+	default:
+		if days > 90 {
+			return fmt.Errorf("DAILY reports cover at most 90 days; this range has %d. Use WEEKLY or MONTHLY for longer ranges", days)
+		}
+	}
+	return nil
+}
+
+// ReportPeriodKey normalizes the date of a report row to one key per period:
+// "2006-01-02T15:00" for hours, "2006-01-02" for days, an ISO week such as
+// "2006-W01" for weeks and "2006-01" for months. Dates it can't parse are cut
+// to their date part.
+func ReportPeriodKey(raw, granularity string) string {
+	trimmed := strings.TrimSpace(raw)
+	parsed, ok := parseReportTime(trimmed)
+	if !ok {
+		return normalizeDateKey(trimmed)
+	}
+	switch granularity {
+	case ReportGranularityHourly:
+		return parsed.Format("2006-01-02T15") + ":00"
+	case ReportGranularityWeekly:
+		year, week := parsed.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	case ReportGranularityMonthly:
+		return parsed.Format("2006-01")
+	default:
+		return parsed.Format("2006-01-02")
+	}
+}
+
+// ReportPeriodStart is the first day, as YYYY-MM-DD, of a ReportPeriodKey.
+func ReportPeriodStart(key string) string {
+	var year, week int
+	if _, err := fmt.Sscanf(key, "%4d-W%2d", &year, &week); err == nil {
+		// January 4th is always in ISO week 1.
+		jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+		monday := jan4.AddDate(0, 0, -((int(jan4.Weekday())+6)%7)+(week-1)*7)
+		return dateOnly(monday)
+	}
+	if parsed, err := time.Parse("2006-01", key); err == nil {
+		return dateOnly(parsed)
+	}
+	return normalizeDateKey(key)
+}
+
+func parseReportTime(raw string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04:05.000", "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15", "2006-01-02", "2006-01"} {
+		if parsed, err := time.Parse(layout, raw); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// apply adds the options that aren't always sent to a report request body.
+func (o ReportOptions) apply(body map[string]any) {
+	if len(o.GroupBy) > 0 {
+		body["groupBy"] = o.GroupBy
+	}
+}
+
+// dimensions reads the groupBy values from a report row's metadata.
+func (o ReportOptions) dimensions(meta map[string]any) map[string]string {
+	if len(o.GroupBy) == 0 {
+		return nil
+	}
+	values := make(map[string]string, len(o.GroupBy))
+	for _, field := range o.GroupBy {
+		if value := strings.TrimSpace(stringFromAny(meta[field])); value != "" {
+			values[field] = value
+		}
+	}
+	return values
+}
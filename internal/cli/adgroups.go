@@ -15,8 +15,20 @@ func RunAdGroups(ctx context.Context, client *appleads.Client, args []string, js
 		respondCommandError("adgroups", jsonOut, err)
 		return
 	}
+	args, err := resolveEntityFlags(ctx, client, args)
+	if err != nil {
+		respondCommandError("adgroups", jsonOut, err)
+		return
+	}
 
 	action := actionFromArgs(args, "list")
+	if isBulkInvocation(args) {
+		switch action {
+		case "pause", "activate", "delete":
+			runAdGroupsBulk(ctx, client, args, action, jsonOut)
+			return
+		}
+	}
 	switch action {
 	case "report":
 		runAdGroupsReport(ctx, client, args, jsonOut)
@@ -41,18 +53,22 @@ func runAdGroupsReport(ctx context.Context, client *appleads.Client, args []stri
 		respondCommandError("adgroups", jsonOut, err)
 		return
 	}
-	startRaw := valueForFlag(args, "--startDate")
-	endRaw := valueForFlag(args, "--endDate")
-	startDate, err := parseDate(startRaw)
+	startDate, endDate, err := reportDateRange(args)
 	if err != nil {
-		respondCommandError("adgroups", jsonOut, fmt.Errorf("Missing/invalid --startDate YYYY-MM-DD and --endDate YYYY-MM-DD"))
+		respondCommandError("adgroups", jsonOut, err)
+		return
+	}
+	options, err := reportOptions(args, startDate, endDate)
+	if err != nil {
+		respondCommandError("adgroups", jsonOut, err)
 		return
 	}
-	endDate, err := parseDate(endRaw)
+	compareStart, compareEnd, compare, err := reportCompareRange(args, startDate, endDate, options.Granularity)
 	if err != nil {
-		respondCommandError("adgroups", jsonOut, fmt.Errorf("Missing/invalid --startDate YYYY-MM-DD and --endDate YYYY-MM-DD"))
+		respondCommandError("adgroups", jsonOut, err)
 		return
 	}
+	startRaw, endRaw := startDate.Format("2006-01-02"), endDate.Format("2006-01-02")
 
 	specificAdGroupID := 0
 	if raw := strings.TrimSpace(valueForFlag(args, "--adGroupId")); raw != "" {
@@ -75,6 +91,70 @@ func runAdGroupsReport(ctx context.Context, client *appleads.Client, args []stri
 		targetGroups = append(targetGroups, group)
 	}
 
+	window, err := collectAdGroupReport(ctx, client, campaignID, targetGroups, startDate, endDate, options)
+	if err != nil {
+		respondCommandError("adgroups", jsonOut, err)
+		return
+	}
+	totals, breakdown := window.totals, window.breakdown
+	payload := map[string]any{
+		"ok":           true,
+		"campaignId":   campaignID,
+		"adGroupCount": len(targetGroups),
+		"startDate":    startRaw,
+		"endDate":      endRaw,
+		"timeZone":     options.TimeZone,
+		"granularity":  options.Granularity,
+		"totals":       totals,
+		"rows":         window.rows,
+	}
+	if len(options.GroupBy) > 0 {
+		payload["groupBy"] = options.GroupBy
+		payload["breakdown"] = breakdown.rows()
+	}
+	compareLabels := []string{"adGroupId", "adGroupName", "dimensions", "currency"}
+	if compare {
+		previous, err := collectAdGroupReport(ctx, client, campaignID, targetGroups, compareStart, compareEnd, options)
+		if err != nil {
+			respondCommandError("adgroups", jsonOut, err)
+			return
+		}
+		payload["compare"] = map[string]any{"startDate": compareStart.Format("2006-01-02"), "endDate": compareEnd.Format("2006-01-02")}
+		payload["comparison"] = compareReportRows(window.rows, previous.rows, []string{"adGroupId", "dimensions"}, compareLabels)
+	}
+	if jsonOut {
+		printJSON(payload)
+		return
+	}
+
+	fmt.Printf("campaignId=%d adGroupCount=%d range=%s...%s timeZone=%s granularity=%s\n", campaignID, len(targetGroups), startRaw, endRaw, options.TimeZone, options.Granularity)
+	for _, total := range totals {
+		day, _ := total["date"].(string)
+		spend, _ := total["spend"].(float64)
+		taps, _ := total["taps"].(int)
+		installs, _ := total["installs"].(int)
+		cpt, _ := total["cpt"].(float64)
+		ttr, _ := total["ttr"].(float64)
+		cr, _ := total["cr"].(float64)
+		currency, _ := total["currency"].(string)
+		fmt.Printf("%s\t%.2f\t%d\t%d\t%.4f\t%.4f\t%.4f\t%s\n", day, spend, taps, installs, cpt, ttr, cr, currency)
+	}
+	breakdown.print()
+	if compare {
+		printReportComparison(compareStart, compareEnd, payload["comparison"].([]map[string]any), compareLabels)
+	}
+}
+
+// adGroupReportWindow is one date range of an ad group report.
+type adGroupReportWindow struct {
+	rows      []map[string]any
+	totals    []map[string]any
+	breakdown *reportBreakdown
+}
+
+// collectAdGroupReport fetches one date range for the ad groups, so --compare
+// can run the same report over two windows.
+func collectAdGroupReport(ctx context.Context, client *appleads.Client, campaignID int, targetGroups []appleads.AdGroupSummary, startDate, endDate time.Time, options appleads.ReportOptions) (*adGroupReportWindow, error) {
 	rows := make([]map[string]any, 0, 128)
 	totalsByDate := map[string]struct {
 		spend       float64
@@ -83,12 +163,12 @@ func runAdGroupsReport(ctx context.Context, client *appleads.Client, args []stri
 		installs    int
 	}{}
 	var currencyCode *string
+	breakdown := newReportBreakdown(options.GroupBy)
 
 	for _, group := range targetGroups {
-		reports, err := client.FetchAdGroupDailyMetrics(ctx, startDate, endDate, campaignID, group.ID)
+		reports, err := client.FetchAdGroupDailyMetrics(ctx, startDate, endDate, options, campaignID, group.ID)
 		if err != nil {
-			respondCommandError("adgroups", jsonOut, err)
-			return
+			return nil, err
 		}
 		for _, report := range reports {
 			installs := 0
@@ -128,7 +208,11 @@ func runAdGroupsReport(ctx context.Context, client *appleads.Client, args []stri
 			} else {
 				row["currency"] = nil
 			}
+			if report.Dimensions != nil {
+				row["dimensions"] = report.Dimensions
+			}
 			rows = append(rows, row)
+			breakdown.add(report.Dimensions, derefString(report.CurrencyCode), report.Spend, report.Taps, report.Impressions, installs)
 
 			total := totalsByDate[report.Date]
 			total.spend += report.Spend
@@ -178,32 +262,7 @@ func runAdGroupsReport(ctx context.Context, client *appleads.Client, args []stri
 		totals = append(totals, total)
 	}
 
-	payload := map[string]any{
-		"ok":           true,
-		"campaignId":   campaignID,
-		"adGroupCount": len(targetGroups),
-		"startDate":    startRaw,
-		"endDate":      endRaw,
-		"totals":       totals,
-		"rows":         rows,
-	}
-	if jsonOut {
-		printJSON(payload)
-		return
-	}
-
-	fmt.Printf("campaignId=%d adGroupCount=%d range=%s...%s\n", campaignID, len(targetGroups), startRaw, endRaw)
-	for _, total := range totals {
-		day, _ := total["date"].(string)
-		spend, _ := total["spend"].(float64)
-		taps, _ := total["taps"].(int)
-		installs, _ := total["installs"].(int)
-		cpt, _ := total["cpt"].(float64)
-		ttr, _ := total["ttr"].(float64)
-		cr, _ := total["cr"].(float64)
-		currency, _ := total["currency"].(string)
-		fmt.Printf("%s\t%.2f\t%d\t%d\t%.4f\t%.4f\t%.4f\t%s\n", day, spend, taps, installs, cpt, ttr, cr, currency)
-	}
+	return &adGroupReportWindow{rows: rows, totals: totals, breakdown: breakdown}, nil
 }
 
 func runAdGroupsList(ctx context.Context, client *appleads.Client, args []string, jsonOut bool) {
@@ -309,9 +368,18 @@ func runAdGroupsCreate(ctx context.Context, client *appleads.Client, args []stri
 		return
 	}
 	status := firstNonEmptyString(valueForFlag(args, "--status"), "ENABLED")
-	currency := firstNonEmptyString(valueForFlag(args, "--currency"), "GBP")
+	currency := firstNonEmptyString(valueForFlag(args, "--currency"), configDefault("currency"), "GBP")
 	var automatedKeywordsOptIn *bool
 	if hasFlag(args, "--automatedKeywordsOptIn") {
+		campaign, err := client.FetchCampaign(ctx, campaignID)
+		if err != nil {
+			respondCommandError("adgroups", jsonOut, err)
+			return
+		}
+		if placement, ok := appleads.PlacementOf(*campaign); ok && !placement.Keywords {
+			respondCommandError("adgroups", jsonOut, fmt.Errorf("--automatedKeywordsOptIn needs a search-results campaign; campaign %d is %s", campaignID, placement.Name))
+			return
+		}
 		v := true
 		automatedKeywordsOptIn = &v
 	}
@@ -391,6 +459,31 @@ func runAdGroupsDelete(ctx context.Context, client *appleads.Client, args []stri
 	fmt.Printf("ok action=delete campaignId=%d adGroupId=%d\n", campaignID, adGroupID)
 }
 
+func runAdGroupsBulk(ctx context.Context, client *appleads.Client, args []string, action string, jsonOut bool) {
+	targets, err := readBulkInput(args, "adGroupId")
+	if err == nil {
+		targets, err = scopeBulkTargets(targets, args, true, false)
+	}
+	if err != nil {
+		respondCommandError("adgroups", jsonOut, err)
+		return
+	}
+	runBulkTargets("adgroups", action, args, jsonOut, targets, func(target bulkTarget) (bulkItemResult, error) {
+		if action == "delete" {
+			return bulkItemResult{}, client.DeleteAdGroup(ctx, target.CampaignID, target.ID)
+		}
+		status := "ENABLED"
+		if action == "pause" {
+			status = "PAUSED"
+		}
+		updated, err := client.UpdateAdGroupStatus(ctx, target.CampaignID, target.ID, status)
+		if err != nil {
+			return bulkItemResult{}, err
+		}
+		return bulkItemResult{Name: updated.Name, Status: updated.Status}, nil
+	})
+}
+
 func fetchAdGroupsWithTimeout(ctx context.Context, client *appleads.Client, campaignID int, timeout time.Duration) ([]appleads.AdGroupSummary, error) {
 	deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
@@ -14,8 +14,20 @@ func RunAds(ctx context.Context, client *appleads.Client, args []string, jsonOut
 		respondCommandError("ads", jsonOut, err)
 		return
 	}
+	args, err := resolveEntityFlags(ctx, client, args)
+	if err != nil {
+		respondCommandError("ads", jsonOut, err)
+		return
+	}
 
 	action := actionFromArgs(args, "list")
+	if isBulkInvocation(args) {
+		switch action {
+		case "update", "pause", "activate", "delete", "remove":
+			runAdsBulk(ctx, client, args, action, jsonOut)
+			return
+		}
+	}
 	switch action {
 	case "list":
 		runAdsList(ctx, client, args, jsonOut)
@@ -180,6 +192,10 @@ func runAdsCreate(ctx context.Context, client *appleads.Client, args []string, j
 	}
 	name := strings.TrimSpace(valueForFlag(args, "--name"))
 	status := firstNonEmptyString(valueForFlag(args, "--status"), "ENABLED")
+	if err := checkAdPlacement(ctx, client, campaignID, creativeID); err != nil {
+		respondCommandError("ads", jsonOut, err)
+		return
+	}
 
 	ad, err := client.CreateAd(ctx, campaignID, adGroupID, creativeID, name, status)
 	if err != nil {
@@ -193,6 +209,27 @@ func runAdsCreate(ctx context.Context, client *appleads.Client, args []string, j
 	fmt.Printf("ok action=create id=%d status=%s name=%s\n", ad.ID, ad.Status, ad.Name)
 }
 
+// checkAdPlacement refuses creatives the campaign's placement can't serve,
+// such as a default product page ad in a Today tab campaign.
+func checkAdPlacement(ctx context.Context, client *appleads.Client, campaignID, creativeID int) error {
+	campaign, err := client.FetchCampaign(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+	placement, ok := appleads.PlacementOf(*campaign)
+	if !ok || !placement.RequiresCustomProductPage {
+		return nil
+	}
+	creative, err := client.FetchCreative(ctx, creativeID)
+	if err != nil {
+		return err
+	}
+	if creative.Type != "CUSTOM_PRODUCT_PAGE" {
+		return fmt.Errorf("%s campaigns need a custom product page creative; creative %d is %s", placement.Name, creativeID, firstNonEmptyString(creative.Type, "untyped"))
+	}
+	return nil
+}
+
 func runAdsUpdate(ctx context.Context, client *appleads.Client, args []string, jsonOut bool) {
 	campaignID, err := requiredIntFlag(args, "--campaignId")
 	if err != nil {
@@ -291,6 +328,42 @@ func runAdsDelete(ctx context.Context, client *appleads.Client, args []string, j
 	fmt.Printf("ok action=delete campaignId=%d adGroupId=%d adId=%d\n", campaignID, adGroupID, adID)
 }
 
+func runAdsBulk(ctx context.Context, client *appleads.Client, args []string, action string, jsonOut bool) {
+	targets, err := readBulkInput(args, "adId")
+	if err == nil {
+		targets, err = scopeBulkTargets(targets, args, true, true)
+	}
+	if err != nil {
+		respondCommandError("ads", jsonOut, err)
+		return
+	}
+	name := ""
+	status := ""
+	switch action {
+	case "update":
+		name = strings.TrimSpace(valueForFlag(args, "--name"))
+		status = strings.TrimSpace(valueForFlag(args, "--status"))
+		if name == "" && status == "" {
+			respondCommandError("ads", jsonOut, fmt.Errorf("Provide at least one of --name or --status"))
+			return
+		}
+	case "pause":
+		status = "PAUSED"
+	case "activate":
+		status = "ENABLED"
+	}
+	runBulkTargets("ads", action, args, jsonOut, targets, func(target bulkTarget) (bulkItemResult, error) {
+		if action == "delete" || action == "remove" {
+			return bulkItemResult{}, client.DeleteAd(ctx, target.CampaignID, target.AdGroupID, target.ID)
+		}
+		ad, err := client.UpdateAd(ctx, target.CampaignID, target.AdGroupID, target.ID, name, status)
+		if err != nil {
+			return bulkItemResult{}, err
+		}
+		return bulkItemResult{Name: ad.Name, Status: ad.Status}, nil
+	})
+}
+
 func respondAdsList(jsonOut bool, ads []appleads.AdSummary) {
 	if jsonOut {
 		printJSON(ads)
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"searchads-cli/internal/appleads"
+)
+
+// RunAPI sends an authenticated request to any Apple Ads endpoint, for
+// endpoints the other commands don't wrap yet.
+func RunAPI(ctx context.Context, client *appleads.Client, args []string, jsonOut bool) {
+	if len(args) < 2 || strings.HasPrefix(args[0], "-") || strings.HasPrefix(args[1], "-") {
+		respondCommandError("api", jsonOut, errors.New("Usage: searchads api <METHOD> <path> [--data @file.json] [--paginate]"))
+		return
+	}
+	if err := ensureCredentialsPresent(); err != nil {
+		respondCommandError("api", jsonOut, err)
+		return
+	}
+	method, path := args[0], args[1]
+
+	body, err := readAPIData(valueForFlag(args, "--data"))
+	if err != nil {
+		respondCommandError("api", jsonOut, err)
+		return
+	}
+
+	if hasFlag(args, "--paginate") {
+		payload, err := client.RawPaginated(ctx, method, path, body)
+		if err != nil {
+			respondCommandError("api", jsonOut, err)
+			return
+		}
+		printJSON(payload)
+		return
+	}
+
+	resp, err := client.Raw(ctx, method, path, body)
+	if err != nil {
+		respondCommandError("api", jsonOut, err)
+		return
+	}
+	if len(bytes.TrimSpace(resp.Body)) == 0 {
+		if jsonOut {
+			printJSON(map[string]any{"ok": true, "status": resp.StatusCode})
+		}
+		return
+	}
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, resp.Body, "", "  "); err != nil {
+		fmt.Println(string(resp.Body))
+		return
+	}
+	fmt.Println(pretty.String())
+}
+
+// readAPIData returns the request body for --data: inline JSON, @file or @-
+// for stdin.
+func readAPIData(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	data := []byte(value)
+	if strings.HasPrefix(value, "@") {
+		source := strings.TrimPrefix(value, "@")
+		var err error
+		if source == "-" {
+			data, err = io.ReadAll(stdinSource)
+		} else {
+			data, err = os.ReadFile(source)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read --data: %w", err)
+		}
+	}
+	if !json.Valid(data) {
+		return nil, errors.New("--data must be valid JSON")
+	}
+	return data, nil
+}
@@ -0,0 +1,287 @@
+package cli
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"searchads-cli/internal/appleads"
+)
+
+const auditFileName = "audit.jsonl"
+
+// auditEntry is one line of the audit log: an API change plus who made it
+// from which command line. Every change one command makes shares its ID.
+// Changes are appended as they happen; when the command ends one more line
+// with End set carries its exit status, which readAuditEntries copies onto
+// the changes. A command that never got that far has no exit status.
+type auditEntry struct {
+	ID string `json:"id"`
+	appleads.AuditRecord
+	User       string `json:"user,omitempty"`
+	Profile    string `json:"profile,omitempty"`
+	ClientID   string `json:"clientId,omitempty"`
+	Command    string `json:"command"`
+	ExitStatus *int   `json:"exitStatus,omitempty"`
+	End        bool   `json:"end,omitempty"`
+}
+
+// auditEnd is the line that closes a command's changes.
+type auditEnd struct {
+	ID         string    `json:"id"`
+	Time       time.Time `json:"time"`
+	Command    string    `json:"command"`
+	ExitStatus int       `json:"exitStatus"`
+	End        bool      `json:"end"`
+}
+
+// commandAudit appends the changes one Dispatch call makes as the client
+// reports them, so a command that is interrupted still leaves its trail.
+type commandAudit struct {
+	client  *appleads.Client
+	path    string
+	entry   auditEntry
+	mu      sync.Mutex
+	written bool
+}
+
+func startCommandAudit(client *appleads.Client, args []string) *commandAudit {
+	words := make([]string, 0, len(args)+1)
+	words = append(words, "searchads")
+	for _, arg := range args {
+		words = append(words, quoteShellWord(arg))
+	}
+	audit := &commandAudit{client: client, path: auditFilePath(), entry: auditEntry{ID: newAuditID(), Command: strings.Join(words, " ")}}
+	client.SetAuditRecorder(audit.record)
+	return audit
+}
+
+func (a *commandAudit) record(record appleads.AuditRecord) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.written {
+		a.entry.Profile = configProfile()
+		if creds, err := appleads.LoadCredentials(); err == nil && creds != nil {
+			a.entry.ClientID = creds.ClientID
+		}
+		a.entry.User = os.Getenv("USER")
+		if current, err := user.Current(); err == nil {
+			a.entry.User = current.Username
+		}
+	}
+	entry := a.entry
+	entry.AuditRecord = record
+	a.written = true
+	if err := appendAuditLines(a.path, entry); err != nil {
+		failText("Could not write the audit log: %s", err.Error())
+	}
+}
+
+func (a *commandAudit) finish() {
+	a.client.SetAuditRecorder(nil)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.written {
+		return
+	}
+	exitStatus := 0
+	if CommandFailed() {
+		exitStatus = 1
+	}
+	end := auditEnd{ID: a.entry.ID, Time: time.Now().UTC(), Command: a.entry.Command, ExitStatus: exitStatus, End: true}
+	if err := appendAuditLines(a.path, end); err != nil {
+		failText("Could not write the audit log: %s", err.Error())
+	}
+}
+
+func newAuditID() string {
+	random := make([]byte, 4)
+	_, _ = rand.Read(random)
+	return time.Now().UTC().Format("20060102T150405") + "-" + hex.EncodeToString(random)
+}
+
+// auditFilePath is the auditFile setting, or audit.jsonl next to the user
+// config.
+func auditFilePath() string {
+	if path := configDefault("auditFile"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, userConfigDir, auditFileName)
+}
+
+func appendAuditEntries(path string, entries []auditEntry) error {
+	lines := make([]any, 0, len(entries))
+	for _, entry := range entries {
+		lines = append(lines, entry)
+	}
+	return appendAuditLines(path, lines...)
+}
+
+func appendAuditLines(path string, lines ...any) error {
+	if path == "" {
+		return errors.New("no user config directory for the audit log; set auditFile")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	var encoded []byte
+	for _, line := range lines {
+		raw, err := json.Marshal(line)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		encoded = append(append(encoded, raw...), '\n')
+	}
+	if _, err := file.Write(encoded); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// RunAudit queries the audit log.
+func RunAudit(args []string, jsonOut bool) {
+	action := actionFromArgs(args, "log")
+	if action != "log" {
+		respondCommandError("audit", jsonOut, fmt.Errorf("Unknown audit action: %s. Use: log", action))
+		return
+	}
+	var since time.Time
+	if raw := valueForFlag(args, "--since"); raw != "" {
+		parsed, err := parseAuditSince(raw, time.Now())
+		if err != nil {
+			respondCommandError("audit", jsonOut, err)
+			return
+		}
+		since = parsed
+	}
+	entity := strings.ToLower(strings.TrimSpace(valueForFlag(args, "--entity")))
+
+	path := auditFilePath()
+	entries, err := readAuditEntries(path)
+	if err != nil {
+		respondCommandError("audit", jsonOut, err)
+		return
+	}
+	matched := make([]auditEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Time.Before(since) || (entity != "" && !auditEntryMentions(entry, entity)) {
+			continue
+		}
+		matched = append(matched, entry)
+	}
+
+	if jsonOut {
+		printJSON(map[string]any{"ok": true, "file": path, "count": len(matched), "records": matched})
+		return
+	}
+	if len(matched) == 0 {
+		fmt.Println("No audit records.")
+		return
+	}
+	for _, entry := range matched {
+		exit := "-"
+		if entry.ExitStatus != nil {
+			exit = strconv.Itoa(*entry.ExitStatus)
+		}
+		fmt.Printf("%s\t%s\t%s %s\tstatus=%d\texit=%s\t%s\t%s\n",
+			firstNonEmptyString(entry.ID, "-"), entry.Time.Local().Format(time.RFC3339), entry.Method, entry.Path, entry.StatusCode, exit,
+			firstNonEmptyString(strings.Join(entry.Entities, ","), "-"), entry.Command)
+	}
+}
+
+func readAuditEntries(path string) ([]auditEntry, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries := make([]auditEntry, 0, 64)
+	exitStatuses := map[string]int{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry auditEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", path, number, err)
+		}
+		if entry.End {
+			if entry.ExitStatus != nil {
+				exitStatuses[entry.ID] = *entry.ExitStatus
+			}
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	for idx := range entries {
+		if status, ok := exitStatuses[entries[idx].ID]; ok && entries[idx].ExitStatus == nil {
+			entries[idx].ExitStatus = &status
+		}
+	}
+	return entries, nil
+}
+
+// auditEntryMentions matches "campaign:123" exactly, or a bare "123" against
+// an entity of any kind.
+func auditEntryMentions(entry auditEntry, entity string) bool {
+	for _, candidate := range entry.Entities {
+		if candidate == entity {
+			return true
+		}
+		if _, id, found := strings.Cut(candidate, ":"); found && id == entity {
+			return true
+		}
+	}
+	return false
+}
+
+// parseAuditSince accepts a date, an RFC 3339 time, or an age such as 7d or
+// 12h.
+func parseAuditSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	if parsed, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return parsed, nil
+	}
+	if days, ok := strings.CutSuffix(strings.ToLower(value), "d"); ok {
+		if count, err := strconv.Atoi(days); err == nil && count > 0 {
+			return now.AddDate(0, 0, -count), nil
+		}
+	}
+	if age, err := time.ParseDuration(value); err == nil && age > 0 {
+		return now.Add(-age), nil
+	}
+	return time.Time{}, fmt.Errorf("Invalid --since %q. Use YYYY-MM-DD, an RFC 3339 time, or an age like 7d or 12h", value)
+}
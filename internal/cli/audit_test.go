@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"searchads-cli/internal/appleads"
+)
+
+func TestAuditLogFiltersBySinceAndEntity(t *testing.T) {
+	previous := activeConfig
+	t.Cleanup(func() { activeConfig = previous })
+	path := filepath.Join(t.TempDir(), "audit", auditFileName)
+	activeConfig = newEffectiveConfig()
+	activeConfig.Values["auditFile"] = configValue{Value: path, Source: configSourceEnv}
+
+	now := time.Now().UTC()
+	entries := []auditEntry{
+		{AuditRecord: appleads.AuditRecord{Time: now.AddDate(0, 0, -10), Method: "DELETE", Path: "/api/v5/campaigns/1", Entities: []string{"campaign:1"}}, Command: "searchads campaigns delete --campaignId 1"},
+		{AuditRecord: appleads.AuditRecord{Time: now.Add(-time.Hour), Method: "PUT", Path: "/api/v5/campaigns/1", Entities: []string{"campaign:1"}}, Command: "searchads campaigns pause --campaignId 1"},
+		{AuditRecord: appleads.AuditRecord{Time: now, Method: "PUT", Path: "/api/v5/campaigns/2/adgroups/1/targetingkeywords/bulk", Entities: []string{"campaign:2", "adgroup:1", "keyword:9"}}, Command: "searchads keywords rebid"},
+	}
+	if err := appendAuditEntries(path, entries[:2]); err != nil {
+		t.Fatalf("append failed: %v", err)
+	}
+	if err := appendAuditEntries(path, entries[2:]); err != nil {
+		t.Fatalf("append failed: %v", err)
+	}
+
+	cases := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"log"}, []string{"searchads campaigns delete --campaignId 1", "searchads campaigns pause --campaignId 1", "searchads keywords rebid"}},
+		{[]string{"log", "--since", "7d"}, []string{"searchads campaigns pause --campaignId 1", "searchads keywords rebid"}},
+		{[]string{"log", "--entity", "campaign:1", "--since", "2h"}, []string{"searchads campaigns pause --campaignId 1"}},
+		{[]string{"log", "--entity", "1"}, []string{"searchads campaigns delete --campaignId 1", "searchads campaigns pause --campaignId 1", "searchads keywords rebid"}},
+		{[]string{"log", "--entity", "Keyword:9"}, []string{"searchads keywords rebid"}},
+	}
+	for _, tc := range cases {
+		stdout, _, failed, err := captureCommandOutput(func() { RunAudit(append(tc.args, "--json"), true) })
+		if err != nil || failed {
+			t.Fatalf("audit %q failed: %v %s", tc.args, err, stdout)
+		}
+		var payload struct {
+			Records []auditEntry `json:"records"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &payload); err != nil {
+			t.Fatalf("invalid JSON for %q: %v", tc.args, err)
+		}
+		commands := make([]string, 0, len(payload.Records))
+		for _, record := range payload.Records {
+			commands = append(commands, record.Command)
+		}
+		if strings.Join(commands, "|") != strings.Join(tc.want, "|") {
+			t.Fatalf("audit %q = %q, want %q", tc.args, commands, tc.want)
+		}
+	}
+
+	if _, _, failed, _ := captureCommandOutput(func() { RunAudit([]string{"log", "--since", "soon"}, false) }); !failed {
+		t.Fatal("expected an invalid --since to fail")
+	}
+}
+
+func TestCommandAuditAppendsChangesBeforeTheCommandEnds(t *testing.T) {
+	previous := activeConfig
+	t.Cleanup(func() { activeConfig = previous })
+	path := filepath.Join(t.TempDir(), auditFileName)
+	activeConfig = newEffectiveConfig()
+	activeConfig.Values["auditFile"] = configValue{Value: path, Source: configSourceEnv}
+	activeConfig.Values["profile"] = configValue{Value: "brand-team", Source: configSourceEnv}
+
+	audit := startCommandAudit(appleads.NewClient(nil), []string{"campaigns", "pause", "--stdin"})
+	audit.record(appleads.AuditRecord{Method: "PUT", Path: "/api/v5/campaigns/1", Entities: []string{"campaign:1"}, StatusCode: 200})
+	entries, err := readAuditEntries(path)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected the change on disk before the command ends, got %v %v", entries, err)
+	}
+	if entries[0].Profile != "brand-team" || entries[0].ExitStatus != nil || entries[0].Command != "searchads campaigns pause --stdin" {
+		t.Fatalf("unexpected pending entry: %+v", entries[0])
+	}
+
+	audit.record(appleads.AuditRecord{Method: "PUT", Path: "/api/v5/campaigns/2", Entities: []string{"campaign:2"}, StatusCode: 200})
+	if _, _, _, err := captureCommandOutput(audit.finish); err != nil {
+		t.Fatalf("finish failed: %v", err)
+	}
+	entries, err = readAuditEntries(path)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("expected two changes, got %v %v", entries, err)
+	}
+	for _, entry := range entries {
+		if entry.ID != entries[0].ID || entry.ExitStatus == nil || *entry.ExitStatus != 0 {
+			t.Fatalf("expected both changes to share an ID and exit status 0, got %+v", entry)
+		}
+	}
+}
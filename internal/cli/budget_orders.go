@@ -0,0 +1,223 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"searchads-cli/internal/appleads"
+)
+
+func RunBudgetOrders(ctx context.Context, client *appleads.Client, args []string, jsonOut bool) {
+	if err := ensureCredentialsPresent(); err != nil {
+		respondCommandError("budget-orders", jsonOut, err)
+		return
+	}
+
+	action := actionFromArgs(args, "list")
+	switch action {
+	case "list":
+		runBudgetOrdersList(ctx, client, args, jsonOut)
+	case "get":
+		runBudgetOrdersGet(ctx, client, args, jsonOut)
+	case "create":
+		runBudgetOrdersWrite(ctx, client, args, 0, jsonOut)
+	case "update":
+		budgetOrderID, err := requiredIntFlag(args, "--budgetOrderId")
+		if err != nil {
+			respondCommandError("budget-orders", jsonOut, err)
+			return
+		}
+		runBudgetOrdersWrite(ctx, client, args, budgetOrderID, jsonOut)
+	default:
+		respondCommandError("budget-orders", jsonOut, fmt.Errorf("Unknown budget-orders action: %s. Use: list|get|create|update", action))
+	}
+}
+
+func runBudgetOrdersList(ctx context.Context, client *appleads.Client, args []string, jsonOut bool) {
+	orders, err := client.FetchBudgetOrders(ctx)
+	if err != nil {
+		respondCommandError("budget-orders", jsonOut, err)
+		return
+	}
+	statuses := parseStringSet(splitCSVValues(valuesForFlag(args, "--status")), true)
+	filtered := make([]appleads.BudgetOrder, 0, len(orders))
+	for _, order := range orders {
+		if _, ok := statuses[order.Status]; len(statuses) > 0 && !ok {
+			continue
+		}
+		filtered = append(filtered, order)
+	}
+	if jsonOut {
+		printJSON(filtered)
+		return
+	}
+	fmt.Printf("budgetOrderCount=%d\n", len(filtered))
+	for _, order := range filtered {
+		fmt.Printf("%d\t%s\t%s\t%s\t%s\t%s\t%s\n", order.ID, order.Status, firstNonEmptyString(order.OrderNumber, "-"),
+			formatCampaignMoney(order.BudgetAmount, order.Currency), stringPtrOrDash(order.StartDate), stringPtrOrDash(order.EndDate), order.Name)
+	}
+}
+
+func runBudgetOrdersGet(ctx context.Context, client *appleads.Client, args []string, jsonOut bool) {
+	budgetOrderID, err := requiredIntFlag(args, "--budgetOrderId")
+	if err != nil {
+		respondCommandError("budget-orders", jsonOut, err)
+		return
+	}
+	order, err := client.FetchBudgetOrder(ctx, budgetOrderID)
+	if err != nil {
+		respondCommandError("budget-orders", jsonOut, err)
+		return
+	}
+	if jsonOut {
+		printJSON(order)
+		return
+	}
+	printBudgetOrder(order)
+}
+
+func printBudgetOrder(order *appleads.BudgetOrder) {
+	fmt.Printf("budgetOrderId=%d status=%s orderNumber=%s\n", order.ID, order.Status, firstNonEmptyString(order.OrderNumber, "-"))
+	fmt.Printf("name=%s\n", order.Name)
+	fmt.Printf("budget=%s startDate=%s endDate=%s\n", formatCampaignMoney(order.BudgetAmount, order.Currency), stringPtrOrDash(order.StartDate), stringPtrOrDash(order.EndDate))
+	fmt.Printf("clientName=%s primaryBuyer=%s <%s> billingEmail=%s\n", firstNonEmptyString(order.ClientName, "-"), firstNonEmptyString(order.PrimaryBuyerName, "-"),
+		firstNonEmptyString(order.PrimaryBuyerEmail, "-"), firstNonEmptyString(order.BillingEmail, "-"))
+	fmt.Printf("supplySources=%s\n", joinOrDash(order.SupplySources))
+}
+
+// runBudgetOrdersWrite creates an order when budgetOrderID is zero and
+// otherwise sends only the flags given.
+func runBudgetOrdersWrite(ctx context.Context, client *appleads.Client, args []string, budgetOrderID int, jsonOut bool) {
+	write, err := budgetOrderWriteFromFlags(args, budgetOrderID == 0)
+	if err != nil {
+		respondCommandError("budget-orders", jsonOut, err)
+		return
+	}
+	if budgetOrderID != 0 && write.BudgetAmount != nil && write.Currency == "" {
+		// A new amount is in the order's own currency unless --budgetCurrency
+		// says otherwise.
+		current, err := client.FetchBudgetOrder(ctx, budgetOrderID)
+		if err != nil {
+			respondCommandError("budget-orders", jsonOut, err)
+			return
+		}
+		if current.Currency == nil || strings.TrimSpace(*current.Currency) == "" {
+			respondCommandError("budget-orders", jsonOut, fmt.Errorf("Budget order %d has no currency; pass --budgetCurrency", budgetOrderID))
+			return
+		}
+		write.Currency = *current.Currency
+	}
+	action := "create"
+	var order *appleads.BudgetOrder
+	if budgetOrderID == 0 {
+		order, err = client.CreateBudgetOrder(ctx, write)
+	} else {
+		action = "update"
+		order, err = client.UpdateBudgetOrder(ctx, budgetOrderID, write)
+	}
+	if err != nil {
+		respondCommandError("budget-orders", jsonOut, err)
+		return
+	}
+	if jsonOut {
+		printJSON(map[string]any{"ok": true, "action": action, "budgetOrder": order})
+		return
+	}
+	fmt.Printf("ok action=%s\n", action)
+	printBudgetOrder(order)
+}
+
+// budgetOrderFields records which order fields were given and which were
+// left out.
+type budgetOrderFields struct {
+	missing []string
+	given   bool
+}
+
+func (f *budgetOrderFields) note(flag string, present bool) {
+	if present {
+		f.given = true
+	} else {
+		f.missing = append(f.missing, flag)
+	}
+}
+
+// budgetOrderWriteFromFlags reads the order fields. Dates are YYYY-MM-DD;
+// a create needs every field except --supplySources, which defaults to search
+// results. An update without --budgetCurrency leaves Currency empty so the
+// caller can keep the order's own.
+func budgetOrderWriteFromFlags(args []string, create bool) (appleads.BudgetOrderWrite, error) {
+	var fields budgetOrderFields
+	text := func(flag string) *string {
+		value := strings.TrimSpace(valueForFlag(args, flag))
+		fields.note(flag, value != "")
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+	write := appleads.BudgetOrderWrite{
+		Name:              text("--name"),
+		OrderNumber:       text("--orderNumber"),
+		ClientName:        text("--clientName"),
+		PrimaryBuyerName:  text("--primaryBuyerName"),
+		PrimaryBuyerEmail: text("--primaryBuyerEmail"),
+		BillingEmail:      text("--billingEmail"),
+	}
+	var err error
+	if write.StartDate, err = budgetOrderDate(args, "--startDate", &fields); err != nil {
+		return write, err
+	}
+	if write.EndDate, err = budgetOrderDate(args, "--endDate", &fields); err != nil {
+		return write, err
+	}
+	if write.StartDate != nil && write.EndDate != nil && *write.EndDate < *write.StartDate {
+		return write, fmt.Errorf("--endDate must not be before --startDate")
+	}
+	raw := strings.TrimSpace(valueForFlag(args, "--budgetAmount"))
+	fields.note("--budgetAmount", raw != "")
+	if raw != "" {
+		amount, err := strconv.ParseFloat(raw, 64)
+		if err != nil || !(amount > 0) || math.IsInf(amount, 0) {
+			return write, fmt.Errorf("Invalid --budgetAmount %q", raw)
+		}
+		write.BudgetAmount = &amount
+		write.Currency = strings.TrimSpace(valueForFlag(args, "--budgetCurrency"))
+		if create {
+			write.Currency = firstNonEmptyString(write.Currency, configDefault("currency"), "GBP")
+		}
+	}
+	write.SupplySources = normalizeUpperValues(splitCSVValues(valuesForFlag(args, "--supplySources")))
+	fields.given = fields.given || len(write.SupplySources) > 0
+
+	if create {
+		if len(fields.missing) > 0 {
+			return write, fmt.Errorf("Missing required %s", strings.Join(fields.missing, ", "))
+		}
+		if len(write.SupplySources) == 0 {
+			write.SupplySources = []string{"APPSTORE_SEARCH_RESULTS"}
+		}
+	} else if !fields.given {
+		return write, fmt.Errorf("Provide at least one field to update")
+	}
+	return write, nil
+}
+
+// budgetOrderDate turns a YYYY-MM-DD flag into the API's local date-time.
+func budgetOrderDate(args []string, flag string, fields *budgetOrderFields) (*string, error) {
+	raw := strings.TrimSpace(valueForFlag(args, flag))
+	fields.note(flag, raw != "")
+	if raw == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s %q. Use YYYY-MM-DD", flag, raw)
+	}
+	formatted := parsed.Format("2006-01-02T15:04:05.000")
+	return &formatted, nil
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"searchads-cli/internal/appleads"
+)
+
+func TestBudgetOrderWriteFromFlags(t *testing.T) {
+	t.Parallel()
+
+	create := []string{"create", "--name", "December", "--orderNumber", "PO-2", "--startDate", "2026-12-01", "--endDate", "2026-12-31",
+		"--budgetAmount", "5000", "--budgetCurrency", "USD", "--clientName", "Acme", "--primaryBuyerName", "Sam", "--primaryBuyerEmail", "sam@example.com",
+		"--billingEmail", "ap@example.com"}
+	write, err := budgetOrderWriteFromFlags(create, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *write.StartDate != "2026-12-01T00:00:00.000" || *write.BudgetAmount != 5000 || write.Currency != "USD" || strings.Join(write.SupplySources, ",") != "APPSTORE_SEARCH_RESULTS" {
+		t.Fatalf("unexpected create: %+v", write)
+	}
+
+	if _, err := budgetOrderWriteFromFlags([]string{"create", "--name", "December"}, true); err == nil || !strings.Contains(err.Error(), "--billingEmail") {
+		t.Fatalf("expected missing fields to be listed, got %v", err)
+	}
+	if _, err := budgetOrderWriteFromFlags([]string{"update", "--startDate", "2026-12-31", "--endDate", "2026-12-01"}, false); err == nil {
+		t.Fatal("expected an end date before the start date to fail")
+	}
+	if _, err := budgetOrderWriteFromFlags([]string{"update", "--startDate", "12/01/2026"}, false); err == nil {
+		t.Fatal("expected a non-ISO date to fail")
+	}
+	if _, err := budgetOrderWriteFromFlags([]string{"update"}, false); err == nil {
+		t.Fatal("expected an update without fields to fail")
+	}
+	update, err := budgetOrderWriteFromFlags([]string{"update", "--billingEmail", "finance@example.com"}, false)
+	if err != nil || update.BillingEmail == nil || update.Name != nil || update.BudgetAmount != nil || len(update.SupplySources) != 0 {
+		t.Fatalf("expected only the billing email, got %+v %v", update, err)
+	}
+	if update, err := budgetOrderWriteFromFlags([]string{"update", "--supplySources", "appstore_search_tab"}, false); err != nil || len(update.SupplySources) != 1 {
+		t.Fatalf("expected supply sources alone to be an update, got %+v %v", update, err)
+	}
+	for _, amount := range []string{"500abc", "-1", "Inf"} {
+		if _, err := budgetOrderWriteFromFlags([]string{"update", "--budgetAmount", amount}, false); err == nil {
+			t.Fatalf("expected --budgetAmount %s to be rejected", amount)
+		}
+	}
+}
+
+func TestBudgetOrderAmountUpdateKeepsOrderCurrency(t *testing.T) {
+	t.Setenv("OE_ADS_CREDENTIALS_JSON", testCredentialsJSON(t))
+	var sent map[string]any
+	client := appleads.NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.Host == "appleid.apple.com":
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.Path == "/api/v5/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.URL.Path == "/api/v5/budgetorders/9" && req.Method == http.MethodGet:
+				return jsonResponse(http.StatusOK, `{"data":{"bo":{"id":9,"name":"December","status":"ACTIVE","budget":{"amount":"5000","currency":"USD"}}}}`), nil
+			case req.URL.Path == "/api/v5/budgetorders/9" && req.Method == http.MethodPut:
+				body, _ := io.ReadAll(req.Body)
+				if err := json.Unmarshal(body, &sent); err != nil {
+					t.Errorf("decode update body: %v", err)
+				}
+				return jsonResponse(http.StatusOK, `{"data":{"bo":{"id":9,"name":"December","status":"ACTIVE","budget":{"amount":"7500","currency":"USD"}}}}`), nil
+			}
+			return jsonResponse(http.StatusNotFound, `{"error":"unexpected request"}`), nil
+		}),
+	})
+
+	_, _, failed, err := captureCommandOutput(func() {
+		RunBudgetOrders(context.Background(), client, []string{"update", "--budgetOrderId", "9", "--budgetAmount", "7500"}, true)
+	})
+	if err != nil || failed {
+		t.Fatalf("expected the update to succeed, failed=%v err=%v", failed, err)
+	}
+	order, _ := sent["bo"].(map[string]any)
+	budget, _ := order["budget"].(map[string]any)
+	if budget["amount"] != "7500.0000" || budget["currency"] != "USD" || len(order) != 1 {
+		t.Fatalf("expected only the amount in the order's currency, got %v", sent)
+	}
+}
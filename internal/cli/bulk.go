@@ -0,0 +1,398 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var stdinSource io.Reader = os.Stdin
+
+const stdinFlag = "--stdin"
+
+type bulkTarget struct {
+	ID         int
+	CampaignID int
+	AdGroupID  int
+}
+
+type bulkItemResult struct {
+	ID         int      `json:"id,omitempty"`
+	CampaignID int      `json:"campaignId,omitempty"`
+	AdGroupID  int      `json:"adGroupId,omitempty"`
+	Outcome    string   `json:"outcome"`
+	Name       string   `json:"name,omitempty"`
+	Text       string   `json:"text,omitempty"`
+	MatchType  string   `json:"matchType,omitempty"`
+	Status     string   `json:"status,omitempty"`
+	BidAmount  *float64 `json:"bidAmount,omitempty"`
+	Currency   string   `json:"currency,omitempty"`
+	Reason     string   `json:"reason,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+type bulkOptions struct {
+	continueOnError bool
+	resultFile      string
+}
+
+func parseBulkOptions(args []string) bulkOptions {
+	return bulkOptions{
+		continueOnError: hasFlag(args, "--continue-on-error"),
+		resultFile:      strings.TrimSpace(valueForFlag(args, "--resultFile")),
+	}
+}
+
+// isBulkInvocation reports whether targets come from stdin or a previous
+// result file rather than from ID flags.
+func isBulkInvocation(args []string) bool {
+	return hasFlag(args, stdinFlag) || strings.TrimSpace(valueForFlag(args, "--retry")) != ""
+}
+
+func readBulkInput(args []string, idField string) ([]bulkTarget, error) {
+	if path := strings.TrimSpace(valueForFlag(args, "--retry")); path != "" {
+		rows, err := readRetryRows(path)
+		if err != nil {
+			return nil, err
+		}
+		targets := make([]bulkTarget, 0, len(rows))
+		for _, row := range rows {
+			if bulkObjectID(row, idField) <= 0 {
+				// Items skipped before they resolved to an ID (e.g. an unknown
+				// --text) cannot be retried by ID.
+				continue
+			}
+			parsed, err := bulkTargetsFromJSON(row, idField)
+			if err != nil {
+				return nil, err
+			}
+			targets = append(targets, parsed...)
+		}
+		if len(targets) == 0 {
+			return nil, fmt.Errorf("Nothing to retry: every item in %s succeeded", path)
+		}
+		return targets, nil
+	}
+	return readBulkTargets(stdinSource, idField)
+}
+
+// readRetryRows loads a --resultFile written by an earlier run and keeps the
+// items that did not succeed.
+func readRetryRows(path string) ([]map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var rows []map[string]any
+	if err := decoder.Decode(&rows); err != nil {
+		return nil, fmt.Errorf("Invalid result file %s; expected a JSON array of results", path)
+	}
+	pending := make([]map[string]any, 0, len(rows))
+	for _, row := range rows {
+		if outcome, _ := row["outcome"].(string); outcome == "ok" {
+			continue
+		}
+		pending = append(pending, row)
+	}
+	return pending, nil
+}
+
+// readBulkTargets accepts plain IDs (one per line, extra columns ignored),
+// JSON lines, or any JSON document such as the array printed by `find --json`.
+// idField names the entity-specific key an object's ID is read from; "id" is
+// used only when that key is absent.
+func readBulkTargets(r io.Reader, idField string) ([]bulkTarget, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("No IDs received on stdin")
+	}
+
+	targets := make([]bulkTarget, 0, 16)
+	if trimmed[0] == '[' || trimmed[0] == '{' {
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.UseNumber()
+		for {
+			var value any
+			if err := decoder.Decode(&value); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("Invalid JSON on stdin: %w", err)
+			}
+			parsed, err := bulkTargetsFromJSON(value, idField)
+			if err != nil {
+				return nil, err
+			}
+			targets = append(targets, parsed...)
+		}
+		return targets, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		id, err := strconv.Atoi(fields[0])
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("stdin line %d: expected an ID, got %q", lineNumber, fields[0])
+		}
+		targets = append(targets, bulkTarget{ID: id})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+func bulkTargetsFromJSON(value any, idField string) ([]bulkTarget, error) {
+	switch typed := value.(type) {
+	case []any:
+		out := make([]bulkTarget, 0, len(typed))
+		for _, item := range typed {
+			parsed, err := bulkTargetsFromJSON(item, idField)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, parsed...)
+		}
+		return out, nil
+	case map[string]any:
+		target := bulkTarget{
+			ID:         bulkObjectID(typed, idField),
+			CampaignID: jsonIntValue(typed["campaignId"]),
+			AdGroupID:  jsonIntValue(typed["adGroupId"]),
+		}
+		if target.ID <= 0 {
+			return nil, fmt.Errorf("stdin object has no usable \"id\" or %q field", idField)
+		}
+		return []bulkTarget{target}, nil
+	default:
+		id := jsonIntValue(typed)
+		if id <= 0 {
+			return nil, fmt.Errorf("stdin value %v is not an ID", typed)
+		}
+		return []bulkTarget{{ID: id}}, nil
+	}
+}
+
+// bulkObjectID reads an object's idField, falling back to "id" when it is
+// absent. The entity-specific key comes first because other entities' output
+// carries it too: an ad group's "id" is its ad group ID, while its
+// "campaignId" is the campaign to pause.
+func bulkObjectID(object map[string]any, idField string) int {
+	if idField != "" {
+		if id := jsonIntValue(object[idField]); id > 0 {
+			return id
+		}
+	}
+	return jsonIntValue(object["id"])
+}
+
+func jsonIntValue(v any) int {
+	switch typed := v.(type) {
+	case json.Number:
+		id, _ := strconv.Atoi(typed.String())
+		return id
+	case float64:
+		return int(typed)
+	case string:
+		id, _ := strconv.Atoi(strings.TrimSpace(typed))
+		return id
+	default:
+		return 0
+	}
+}
+
+// scopeBulkTargets fills campaign/ad group IDs from flags for targets that did
+// not carry their own, and rejects targets that still lack a required scope.
+func scopeBulkTargets(targets []bulkTarget, args []string, needCampaign, needAdGroup bool) ([]bulkTarget, error) {
+	flagCampaignID, _ := requiredIntFlag(args, "--campaignId")
+	flagAdGroupID, _ := requiredIntFlag(args, "--adGroupId")
+	scoped := make([]bulkTarget, 0, len(targets))
+	for _, target := range targets {
+		if target.CampaignID <= 0 {
+			target.CampaignID = flagCampaignID
+		}
+		if target.AdGroupID <= 0 {
+			target.AdGroupID = flagAdGroupID
+		}
+		if needCampaign && target.CampaignID <= 0 {
+			return nil, fmt.Errorf("stdin item %d has no campaignId; pass --campaignId <id> or include it in the input", target.ID)
+		}
+		if needAdGroup && target.AdGroupID <= 0 {
+			return nil, fmt.Errorf("stdin item %d has no adGroupId; pass --adGroupId <id> or include it in the input", target.ID)
+		}
+		scoped = append(scoped, target)
+	}
+	return scoped, nil
+}
+
+// runBulkTargets applies fn to each target in order. Unless
+// --continue-on-error is set, the first failure stops the run and the
+// remaining targets are reported as skipped.
+func runBulkTargets(command, action string, args []string, jsonOut bool, targets []bulkTarget, fn func(bulkTarget) (bulkItemResult, error)) {
+	results := collectBulkResults(
+		len(targets),
+		parseBulkOptions(args).continueOnError,
+		func(idx int) bulkItemResult {
+			target := targets[idx]
+			return bulkItemResult{ID: target.ID, CampaignID: target.CampaignID, AdGroupID: target.AdGroupID}
+		},
+		func(idx int) (bulkItemResult, error) { return fn(targets[idx]) },
+	)
+	respondBulkResults(command, action, args, jsonOut, results)
+}
+
+func collectBulkResults(count int, continueOnError bool, describe func(int) bulkItemResult, apply func(int) (bulkItemResult, error)) []bulkItemResult {
+	results := make([]bulkItemResult, 0, count)
+	stopped := false
+	for idx := 0; idx < count; idx++ {
+		base := describe(idx)
+		if stopped {
+			base.Outcome = "skipped"
+			base.Reason = "not attempted after an earlier failure"
+			results = append(results, base)
+			continue
+		}
+		result, err := apply(idx)
+		merged := mergeBulkResult(base, result)
+		switch {
+		case err != nil:
+			merged.Outcome = "error"
+			merged.Error = err.Error()
+			stopped = !continueOnError
+		case merged.Outcome == "":
+			merged.Outcome = "ok"
+		}
+		results = append(results, merged)
+	}
+	return results
+}
+
+func mergeBulkResult(base, result bulkItemResult) bulkItemResult {
+	if result.ID == 0 {
+		result.ID = base.ID
+	}
+	if result.CampaignID == 0 {
+		result.CampaignID = base.CampaignID
+	}
+	if result.AdGroupID == 0 {
+		result.AdGroupID = base.AdGroupID
+	}
+	result.Name = firstNonEmptyString(result.Name, base.Name)
+	result.Text = firstNonEmptyString(result.Text, base.Text)
+	result.MatchType = firstNonEmptyString(result.MatchType, base.MatchType)
+	result.Status = firstNonEmptyString(result.Status, base.Status)
+	result.Currency = firstNonEmptyString(result.Currency, base.Currency)
+	if result.BidAmount == nil {
+		result.BidAmount = base.BidAmount
+	}
+	return result
+}
+
+func respondBulkResults(command, action string, args []string, jsonOut bool, results []bulkItemResult) {
+	counts := map[string]int{}
+	for _, result := range results {
+		counts[result.Outcome]++
+	}
+	if counts["error"] > 0 {
+		markCommandFailed()
+	}
+	options := parseBulkOptions(args)
+	if options.resultFile != "" {
+		if err := writeBulkResultFile(options.resultFile, results); err != nil {
+			markCommandFailed()
+			failText("%s failed to write --resultFile: %s", command, err.Error())
+		}
+	}
+
+	if jsonOut {
+		payload := map[string]any{
+			"ok":        counts["error"] == 0,
+			"command":   command,
+			"action":    action,
+			"requested": len(results),
+			"succeeded": counts["ok"],
+			"skipped":   counts["skipped"],
+			"failed":    counts["error"],
+			"results":   results,
+		}
+		if options.resultFile != "" {
+			payload["resultFile"] = options.resultFile
+		}
+		printJSON(payload)
+		return
+	}
+	for _, result := range results {
+		fmt.Println(formatBulkResultLine(result))
+	}
+	summary := fmt.Sprintf("%s action=%s requested=%d succeeded=%d skipped=%d failed=%d", command, action, len(results), counts["ok"], counts["skipped"], counts["error"])
+	if options.resultFile != "" {
+		summary += " resultFile=" + options.resultFile
+	}
+	fmt.Println(summary)
+}
+
+func formatBulkResultLine(result bulkItemResult) string {
+	line := result.Outcome
+	if result.ID > 0 {
+		line += fmt.Sprintf(" id=%d", result.ID)
+	}
+	if result.CampaignID > 0 {
+		line += fmt.Sprintf(" campaignId=%d", result.CampaignID)
+	}
+	if result.AdGroupID > 0 {
+		line += fmt.Sprintf(" adGroupId=%d", result.AdGroupID)
+	}
+	if result.Status != "" {
+		line += " status=" + result.Status
+	}
+	if result.BidAmount != nil {
+		line += fmt.Sprintf(" bidAmount=%.4f", *result.BidAmount)
+	}
+	if result.MatchType != "" {
+		line += " matchType=" + result.MatchType
+	}
+	if result.Text != "" {
+		line += fmt.Sprintf(" text=%q", result.Text)
+	}
+	if result.Name != "" {
+		line += " name=" + result.Name
+	}
+	if result.Reason != "" {
+		line += " reason=" + result.Reason
+	}
+	if result.Error != "" {
+		line += " error=" + result.Error
+	}
+	return line
+}
+
+func writeBulkResultFile(path string, results []bulkItemResult) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
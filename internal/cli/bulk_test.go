@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadBulkTargetsAcceptsFindJSONOutput(t *testing.T) {
+	t.Parallel()
+
+	input := `[
+  {
+    "id": 11,
+    "name": "Brand UK",
+    "status": "ENABLED"
+  },
+  {
+    "id": 12,
+    "name": "Brand US",
+    "status": "ENABLED"
+  }
+]`
+	targets, err := readBulkTargets(strings.NewReader(input), "campaignId")
+	if err != nil {
+		t.Fatalf("read targets failed: %v", err)
+	}
+	if len(targets) != 2 || targets[0].ID != 11 || targets[1].ID != 12 {
+		t.Fatalf("expected ids 11 and 12, got %+v", targets)
+	}
+}
+
+func TestReadBulkTargetsAcceptsJSONLinesWithScope(t *testing.T) {
+	t.Parallel()
+
+	input := "{\"keywordId\": 5, \"campaignId\": 1, \"adGroupId\": 2}\n{\"id\": 6}\n"
+	targets, err := readBulkTargets(strings.NewReader(input), "keywordId")
+	if err != nil {
+		t.Fatalf("read targets failed: %v", err)
+	}
+	want := []bulkTarget{{ID: 5, CampaignID: 1, AdGroupID: 2}, {ID: 6}}
+	if len(targets) != len(want) || targets[0] != want[0] || targets[1] != want[1] {
+		t.Fatalf("expected %+v, got %+v", want, targets)
+	}
+
+	scoped, err := scopeBulkTargets(targets, []string{"pause", "--campaignId", "9", "--adGroupId", "8"}, true, true)
+	if err != nil {
+		t.Fatalf("scope targets failed: %v", err)
+	}
+	if scoped[0].CampaignID != 1 || scoped[0].AdGroupID != 2 {
+		t.Fatalf("expected explicit scope to win over flags, got %+v", scoped[0])
+	}
+	if scoped[1].CampaignID != 9 || scoped[1].AdGroupID != 8 {
+		t.Fatalf("expected flag scope to fill missing ids, got %+v", scoped[1])
+	}
+}
+
+func TestReadBulkTargetsPrefersTheEntityIDField(t *testing.T) {
+	t.Parallel()
+
+	// adgroups list --json piped into campaigns pause --stdin.
+	input := `[{"id": 201, "campaignId": 11, "name": "Exact"}, {"id": 202, "campaignId": 12, "name": "Broad"}]`
+	targets, err := readBulkTargets(strings.NewReader(input), "campaignId")
+	if err != nil {
+		t.Fatalf("read targets failed: %v", err)
+	}
+	if len(targets) != 2 || targets[0].ID != 11 || targets[1].ID != 12 {
+		t.Fatalf("expected campaign ids 11 and 12, got %+v", targets)
+	}
+
+	// keywords list --json piped into adgroups pause --stdin.
+	targets, err = readBulkTargets(strings.NewReader(`{"id": 5, "campaignId": 1, "adGroupId": 2}`), "adGroupId")
+	if err != nil {
+		t.Fatalf("read targets failed: %v", err)
+	}
+	if len(targets) != 1 || targets[0].ID != 2 || targets[0].CampaignID != 1 {
+		t.Fatalf("expected ad group id 2 in campaign 1, got %+v", targets)
+	}
+}
+
+func TestReadBulkTargetsAcceptsPlainIDLines(t *testing.T) {
+	t.Parallel()
+
+	targets, err := readBulkTargets(strings.NewReader("101\tENABLED\tBrand\n\n102\n"), "campaignId")
+	if err != nil {
+		t.Fatalf("read targets failed: %v", err)
+	}
+	if len(targets) != 2 || targets[0].ID != 101 || targets[1].ID != 102 {
+		t.Fatalf("expected ids 101 and 102, got %+v", targets)
+	}
+
+	if _, err := readBulkTargets(strings.NewReader("campaignCount=2\n"), "campaignId"); err == nil {
+		t.Fatal("expected error for non-ID line")
+	}
+}
+
+func TestCollectBulkResultsSkipsRemainingAfterFailure(t *testing.T) {
+	t.Parallel()
+
+	describe := func(idx int) bulkItemResult { return bulkItemResult{ID: idx + 1} }
+	apply := func(idx int) (bulkItemResult, error) {
+		if idx == 1 {
+			return bulkItemResult{}, errors.New("boom")
+		}
+		return bulkItemResult{}, nil
+	}
+
+	stopped := collectBulkResults(4, false, describe, apply)
+	outcomes := make([]string, 0, len(stopped))
+	for _, result := range stopped {
+		outcomes = append(outcomes, result.Outcome)
+	}
+	if got := strings.Join(outcomes, ","); got != "ok,error,skipped,skipped" {
+		t.Fatalf("unexpected outcomes without --continue-on-error: %s", got)
+	}
+	if stopped[1].Error != "boom" || stopped[1].ID != 2 {
+		t.Fatalf("expected error result for id 2, got %+v", stopped[1])
+	}
+
+	continued := collectBulkResults(4, true, describe, apply)
+	outcomes = outcomes[:0]
+	for _, result := range continued {
+		outcomes = append(outcomes, result.Outcome)
+	}
+	if got := strings.Join(outcomes, ","); got != "ok,error,ok,ok" {
+		t.Fatalf("unexpected outcomes with --continue-on-error: %s", got)
+	}
+}
+
+func TestResultFileRoundTripsFailuresForRetry(t *testing.T) {
+	t.Parallel()
+
+	bid := 1.25
+	path := filepath.Join(t.TempDir(), "results.json")
+	results := []bulkItemResult{
+		{CampaignID: 1, AdGroupID: 2, Outcome: "ok", Text: "done", MatchType: "EXACT", Status: "ACTIVE"},
+		{CampaignID: 1, AdGroupID: 2, Outcome: "error", Text: "retry me", MatchType: "EXACT", Status: "ACTIVE", BidAmount: &bid, Currency: "GBP", Error: "boom"},
+		{ID: 9, CampaignID: 1, AdGroupID: 2, Outcome: "skipped", Reason: "not attempted after an earlier failure"},
+	}
+	if err := writeBulkResultFile(path, results); err != nil {
+		t.Fatalf("write result file failed: %v", err)
+	}
+
+	rows, err := readRetryRows(path)
+	if err != nil {
+		t.Fatalf("read retry rows failed: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 pending rows, got %d", len(rows))
+	}
+	inputs := keywordInputsFromRows(rows, keywordDefaults{matchType: "BROAD", status: "ACTIVE"})
+	if len(inputs) != 1 || inputs[0].text != "retry me" || inputs[0].matchType != "EXACT" {
+		t.Fatalf("unexpected keyword inputs: %+v", inputs)
+	}
+	if inputs[0].bidAmount == nil || *inputs[0].bidAmount != 1.25 || inputs[0].currency == nil || *inputs[0].currency != "GBP" {
+		t.Fatalf("expected bid 1.25 GBP, got %+v", inputs[0])
+	}
+
+	targets, err := readBulkInput([]string{"pause", "--retry", path}, "keywordId")
+	if err != nil {
+		t.Fatalf("read retry targets failed: %v", err)
+	}
+	if len(targets) != 1 || targets[0] != (bulkTarget{ID: 9, CampaignID: 1, AdGroupID: 2}) {
+		t.Fatalf("unexpected retry targets: %+v", targets)
+	}
+}
@@ -0,0 +1,286 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"searchads-cli/internal/appleads"
+)
+
+const defaultCloneNameTemplate = "{name} - {countries}"
+
+// cloneIDPair maps one copied entity to the one created from it. ID is zero
+// on a dry run or when the create failed.
+type cloneIDPair struct {
+	Kind     string `json:"kind"`
+	SourceID int    `json:"sourceId"`
+	ID       int    `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+}
+
+type cloneAdGroupPlan struct {
+	Source     appleads.AdGroupSummary
+	DefaultBid float64
+	Keywords   []appleads.KeywordWrite
+	KeywordIDs []int
+	Negatives  []appleads.NegativeKeywordSummary
+	Ads        []appleads.AdSummary
+}
+
+type campaignClonePlan struct {
+	Source      appleads.CampaignSummary
+	Name        string
+	Countries   []string
+	Status      string
+	Placement   appleads.CampaignPlacement
+	DailyBudget float64
+	Currency    string
+	Negatives   []appleads.NegativeKeywordSummary
+	AdGroups    []cloneAdGroupPlan
+}
+
+// runCampaignsClone copies a campaign with its ad groups, keywords, negatives
+// and ads into a new campaign for other countries or regions.
+func runCampaignsClone(ctx context.Context, client *appleads.Client, args []string, jsonOut bool) {
+	campaignID, err := requiredIntFlag(args, "--campaignId")
+	if err != nil {
+		respondCommandError("campaigns", jsonOut, err)
+		return
+	}
+	countries := normalizeUpperValues(splitCSVValues(valuesForFlag(args, "--countries")))
+	if len(countries) == 0 {
+		respondCommandError("campaigns", jsonOut, fmt.Errorf("Missing required --countries <codes>"))
+		return
+	}
+	multiplier := 1.0
+	if raw := strings.TrimSpace(valueForFlag(args, "--bidMultiplier")); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed <= 0 {
+			respondCommandError("campaigns", jsonOut, fmt.Errorf("Invalid --bidMultiplier %q", raw))
+			return
+		}
+		multiplier = parsed
+	}
+	if err := validateCampaignCountries(ctx, client, countries); err != nil {
+		respondCommandError("campaigns", jsonOut, err)
+		return
+	}
+
+	plan, err := planCampaignClone(ctx, client, campaignID, countries, multiplier,
+		firstNonEmptyString(valueForFlag(args, "--nameTemplate"), defaultCloneNameTemplate),
+		firstNonEmptyString(valueForFlag(args, "--status"), "PAUSED"))
+	if err != nil {
+		respondCommandError("campaigns", jsonOut, err)
+		return
+	}
+	dryRun := hasFlag(args, "--dryRun")
+	var mapping []cloneIDPair
+	var failures []string
+	if dryRun {
+		mapping = cloneDryRunMapping(plan)
+	} else {
+		mapping, failures, err = executeCampaignClone(ctx, client, plan)
+		if err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+
+	if len(failures) > 0 {
+		markCommandFailed()
+	}
+	if jsonOut {
+		printJSON(map[string]any{
+			"ok":               len(failures) == 0,
+			"dryRun":           dryRun,
+			"sourceCampaignId": campaignID,
+			"name":             plan.Name,
+			"countries":        plan.Countries,
+			"placement":        plan.Placement.Name,
+			"bidMultiplier":    multiplier,
+			"mapping":          mapping,
+			"errors":           failures,
+		})
+		return
+	}
+	prefix := "ok"
+	if dryRun {
+		prefix = "dryRun"
+	}
+	fmt.Printf("%s clone campaignId=%d name=%s countries=%s placement=%s bidMultiplier=%g\n",
+		prefix, campaignID, plan.Name, strings.Join(plan.Countries, ","), plan.Placement.Name, multiplier)
+	for _, pair := range mapping {
+		newID := "-"
+		if pair.ID > 0 {
+			newID = strconv.Itoa(pair.ID)
+		}
+		fmt.Printf("%s\t%d\t%s\t%s\n", pair.Kind, pair.SourceID, newID, pair.Name)
+	}
+	for _, failure := range failures {
+		failText("clone: %s", failure)
+	}
+}
+
+// planCampaignClone reads everything the clone copies. Deleted keywords and
+// ads are left behind; bids are scaled by multiplier and rounded to cents.
+func planCampaignClone(ctx context.Context, client *appleads.Client, campaignID int, countries []string, multiplier float64, nameTemplate, status string) (*campaignClonePlan, error) {
+	source, err := client.FetchCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if source.DailyBudgetAmount == nil {
+		return nil, fmt.Errorf("campaign %d has no daily budget to copy", campaignID)
+	}
+	placement, ok := appleads.PlacementOf(*source)
+	if !ok {
+		placement, _ = appleads.LookupPlacement(appleads.DefaultPlacement)
+	}
+	currency := firstNonEmptyString(configDefault("currency"), "GBP")
+	if source.Currency != nil {
+		currency = *source.Currency
+	}
+	name := strings.NewReplacer("{name}", source.Name, "{countries}", strings.Join(countries, ",")).Replace(nameTemplate)
+	plan := &campaignClonePlan{
+		Source:      *source,
+		Name:        strings.TrimSpace(name),
+		Countries:   countries,
+		Status:      strings.ToUpper(strings.TrimSpace(status)),
+		Placement:   placement,
+		DailyBudget: *source.DailyBudgetAmount,
+		Currency:    currency,
+	}
+	if plan.Negatives, err = client.FetchCampaignNegativeKeywords(ctx, campaignID); err != nil {
+		return nil, err
+	}
+	adGroups, err := client.FetchAdGroups(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	for _, adGroup := range adGroups {
+		if adGroup.Status == "DELETED" {
+			continue
+		}
+		if adGroup.DefaultBid == nil {
+			return nil, fmt.Errorf("ad group %d has no default bid to copy", adGroup.ID)
+		}
+		item := cloneAdGroupPlan{Source: adGroup, DefaultBid: scaleCloneBid(*adGroup.DefaultBid, multiplier)}
+		keywords, err := client.FetchKeywords(ctx, campaignID, adGroup.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, keyword := range keywords {
+			write := appleads.KeywordWrite{Text: keyword.Text, MatchType: keyword.MatchType, Status: keyword.Status, Currency: keyword.Currency}
+			if keyword.BidAmount != nil {
+				bid := scaleCloneBid(*keyword.BidAmount, multiplier)
+				write.BidAmount = &bid
+			}
+			item.Keywords = append(item.Keywords, write)
+			item.KeywordIDs = append(item.KeywordIDs, keyword.ID)
+		}
+		if item.Negatives, err = client.FetchNegativeKeywords(ctx, campaignID, adGroup.ID); err != nil {
+			return nil, err
+		}
+		ads, err := client.FetchAds(ctx, campaignID, adGroup.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, ad := range ads {
+			if !ad.Deleted && ad.Status != "DELETED" {
+				item.Ads = append(item.Ads, ad)
+			}
+		}
+		plan.AdGroups = append(plan.AdGroups, item)
+	}
+	return plan, nil
+}
+
+func scaleCloneBid(bid, multiplier float64) float64 {
+	return math.Round(bid*multiplier*100) / 100
+}
+
+func cloneDryRunMapping(plan *campaignClonePlan) []cloneIDPair {
+	mapping := []cloneIDPair{{Kind: "campaign", SourceID: plan.Source.ID, Name: plan.Name}}
+	for _, adGroup := range plan.AdGroups {
+		mapping = append(mapping, cloneIDPair{Kind: "adgroup", SourceID: adGroup.Source.ID, Name: adGroup.Source.Name})
+		for idx, keyword := range adGroup.Keywords {
+			mapping = append(mapping, cloneIDPair{Kind: "keyword", SourceID: adGroup.KeywordIDs[idx], Name: keyword.Text})
+		}
+		for _, ad := range adGroup.Ads {
+			mapping = append(mapping, cloneIDPair{Kind: "ad", SourceID: ad.ID, Name: ad.Name})
+		}
+	}
+	return mapping
+}
+
+// executeCampaignClone creates the campaign, then each ad group with its
+// keywords, negatives and ads. Failures below the campaign are collected so
+// one bad keyword doesn't strand the rest; a failed campaign or ad group
+// create stops what depends on it.
+func executeCampaignClone(ctx context.Context, client *appleads.Client, plan *campaignClonePlan) ([]cloneIDPair, []string, error) {
+	adamID := ""
+	if plan.Source.AdamID > 0 {
+		adamID = strconv.Itoa(plan.Source.AdamID)
+	}
+	created, err := client.CreateCampaign(ctx, plan.Name, plan.Status, plan.DailyBudget, plan.Currency, "DAILY",
+		adamID, plan.Countries, "", "", plan.Placement.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	mapping := []cloneIDPair{{Kind: "campaign", SourceID: plan.Source.ID, ID: created.ID, Name: created.Name}}
+	var failures []string
+	if err := client.AddCampaignNegativeKeywords(ctx, created.ID, plan.Negatives); err != nil {
+		failures = append(failures, fmt.Sprintf("campaign negatives: %s", err.Error()))
+	}
+	for _, adGroup := range plan.AdGroups {
+		newGroup, err := client.CreateAdGroup(ctx, created.ID, adGroup.Source.Name, adGroup.Source.Status, adGroup.DefaultBid,
+			firstNonEmptyString(derefString(adGroup.Source.Currency), plan.Currency), adGroup.Source.AutomatedKeywordsOptIn)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("ad group %d: %s", adGroup.Source.ID, err.Error()))
+			mapping = append(mapping, cloneIDPair{Kind: "adgroup", SourceID: adGroup.Source.ID, Name: adGroup.Source.Name})
+			continue
+		}
+		mapping = append(mapping, cloneIDPair{Kind: "adgroup", SourceID: adGroup.Source.ID, ID: newGroup.ID, Name: newGroup.Name})
+
+		if len(adGroup.Keywords) > 0 {
+			results, err := client.AddKeywords(ctx, created.ID, newGroup.ID, adGroup.Keywords)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("ad group %d keywords: %s", adGroup.Source.ID, err.Error()))
+			}
+			for idx, keyword := range adGroup.Keywords {
+				pair := cloneIDPair{Kind: "keyword", SourceID: adGroup.KeywordIDs[idx], Name: keyword.Text}
+				if idx < len(results) {
+					if results[idx].Err != nil {
+						failures = append(failures, fmt.Sprintf("keyword %d %q: %s", pair.SourceID, keyword.Text, results[idx].Err.Error()))
+					}
+					pair.ID = results[idx].ID
+				}
+				mapping = append(mapping, pair)
+			}
+		}
+		if len(adGroup.Negatives) > 0 {
+			if err := client.AddNegativeKeywords(ctx, created.ID, newGroup.ID, adGroup.Negatives); err != nil {
+				failures = append(failures, fmt.Sprintf("ad group %d negatives: %s", adGroup.Source.ID, err.Error()))
+			}
+		}
+		for _, ad := range adGroup.Ads {
+			pair := cloneIDPair{Kind: "ad", SourceID: ad.ID, Name: ad.Name}
+			newAd, err := client.CreateAd(ctx, created.ID, newGroup.ID, ad.CreativeID, ad.Name, ad.Status)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("ad %d: %s", ad.ID, err.Error()))
+			} else {
+				pair.ID = newAd.ID
+			}
+			mapping = append(mapping, pair)
+		}
+	}
+	return mapping, failures, nil
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"searchads-cli/internal/appleads"
+)
+
+const (
+	pacingCapped     = "CAPPED"
+	pacingWillCap    = "WILL_CAP"
+	pacingUnderspend = "UNDERSPEND"
+	pacingOnPace     = "ON_PACE"
+	pacingNoBudget   = "NO_DAILY_BUDGET"
+	pacingTooEarly   = "TOO_EARLY"
+
+	// pacingRateHours is how many recent complete hours set the run rate.
+	pacingRateHours = 3
+)
+
+// campaignPacing is one campaign's spend so far today against its daily
+// budget, and where the current run rate takes it by midnight.
+type campaignPacing struct {
+	CampaignID     int      `json:"campaignId"`
+	Name           string   `json:"name"`
+	Status         string   `json:"status"`
+	DailyBudget    *float64 `json:"dailyBudget,omitempty"`
+	Currency       string   `json:"currency,omitempty"`
+	Spend          float64  `json:"spend"`
+	ExpectedSpend  float64  `json:"expectedSpend"`
+	Pace           float64  `json:"pace"`
+	HourlyRate     float64  `json:"hourlyRate"`
+	ProjectedSpend *float64 `json:"projectedSpend"`
+	CapsAt         string   `json:"capsAt,omitempty"`
+	Flag           string   `json:"flag"`
+}
+
+// runCampaignsPacing compares today's hourly spend with each campaign's daily
+// budget and flags campaigns that will cap out or badly underspend.
+func runCampaignsPacing(ctx context.Context, client *appleads.Client, args []string, jsonOut bool) {
+	underspendBelow := 0.5
+	if raw := strings.TrimSpace(valueForFlag(args, "--underspendBelow")); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed <= 0 || parsed >= 1 {
+			respondCommandError("campaigns", jsonOut, fmt.Errorf("Invalid --underspendBelow %q. Use a fraction of the daily budget between 0 and 1", raw))
+			return
+		}
+		underspendBelow = parsed
+	}
+	campaignFilter := parseIntFlagSet(args, "--campaignId")
+	includePaused := hasFlag(args, "--includePaused")
+	flaggedOnly := hasFlag(args, "--flaggedOnly")
+
+	campaigns, err := client.FetchCampaigns(ctx)
+	if err != nil {
+		respondCommandError("campaigns", jsonOut, err)
+		return
+	}
+	location, err := orgLocation(ctx, client)
+	if err != nil {
+		respondCommandError("campaigns", jsonOut, err)
+		return
+	}
+	now := time.Now().In(location)
+	hourly, err := client.FetchCampaignHourlySpend(ctx, time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		respondCommandError("campaigns", jsonOut, err)
+		return
+	}
+	spendByCampaign := map[int][]float64{}
+	currencyByCampaign := map[int]string{}
+	for _, row := range hourly {
+		hours, ok := spendByCampaign[row.CampaignID]
+		if !ok {
+			hours = make([]float64, 24)
+			spendByCampaign[row.CampaignID] = hours
+		}
+		if row.Hour >= 0 && row.Hour < 24 {
+			hours[row.Hour] += row.Spend
+		}
+		if row.CurrencyCode != nil {
+			currencyByCampaign[row.CampaignID] = *row.CurrencyCode
+		}
+	}
+
+	rows := make([]campaignPacing, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		if _, ok := campaignFilter[campaign.ID]; len(campaignFilter) > 0 && !ok {
+			continue
+		}
+		if campaign.Status != "ENABLED" && !includePaused {
+			continue
+		}
+		hours := spendByCampaign[campaign.ID]
+		if hours == nil {
+			hours = make([]float64, 24)
+		}
+		row := paceCampaign(campaign.DailyBudgetAmount, hours, now, underspendBelow)
+		row.CampaignID, row.Name, row.Status = campaign.ID, campaign.Name, campaign.Status
+		row.Currency = currencyByCampaign[campaign.ID]
+		if campaign.Currency != nil {
+			row.Currency = *campaign.Currency
+		}
+		if flaggedOnly && (row.Flag == pacingOnPace || row.Flag == pacingNoBudget || row.Flag == pacingTooEarly) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	elapsed := dayElapsed(now)
+	if jsonOut {
+		printJSON(map[string]any{
+			"ok":              true,
+			"asOf":            now.Format(time.RFC3339),
+			"dayElapsed":      elapsed,
+			"underspendBelow": underspendBelow,
+			"campaigns":       rows,
+		})
+		return
+	}
+	fmt.Printf("asOf=%s dayElapsed=%.0f%% campaignCount=%d\n", now.Format(time.RFC3339), elapsed*100, len(rows))
+	for _, row := range rows {
+		budget := "-"
+		if row.DailyBudget != nil {
+			budget = fmt.Sprintf("%.2f", *row.DailyBudget)
+		}
+		projected := "-"
+		if row.ProjectedSpend != nil {
+			projected = fmt.Sprintf("%.2f", *row.ProjectedSpend)
+		}
+		fmt.Printf("%d\t%s\tspend=%.2f/%s %s\tpace=%.0f%%\tprojected=%s\tcapsAt=%s\t%s\n",
+			row.CampaignID, row.Flag, row.Spend, budget, firstNonEmptyString(row.Currency, "-"), row.Pace*100, projected, firstNonEmptyString(row.CapsAt, "-"), row.Name)
+	}
+}
+
+// orgLocation is the orgTimeZone setting, or else the org's zone from the
+// API, so "now" is in the same zone as the hourly report.
+func orgLocation(ctx context.Context, client *appleads.Client) (*time.Location, error) {
+	name := configDefault("orgTimeZone")
+	if name == "" {
+		zone, err := client.FetchOrgTimeZone(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("Could not look up the org's time zone (%w); set orgTimeZone", err)
+		}
+		name = zone
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("Unknown org time zone %q; set orgTimeZone", name)
+	}
+	return location, nil
+}
+
+// paceCampaign projects end-of-day spend from the run rate of the last few
+// complete hours. Before the first hour completes there is no rate to
+// project from, so the campaign is TOO_EARLY unless already CAPPED. A
+// campaign is CAPPED once it has spent 98% of its budget.
+func paceCampaign(budget *float64, hours []float64, now time.Time, underspendBelow float64) campaignPacing {
+	row := campaignPacing{DailyBudget: budget}
+	for _, spend := range hours {
+		row.Spend += spend
+	}
+	elapsedHours := dayElapsed(now) * 24
+	currentHour := now.Hour()
+	if currentHour > 0 {
+		first := max(0, currentHour-pacingRateHours)
+		recent := 0.0
+		for hour := first; hour < currentHour; hour++ {
+			recent += hours[hour]
+		}
+		row.HourlyRate = recent / float64(currentHour-first)
+		projected := row.Spend + row.HourlyRate*(24-elapsedHours)
+		row.ProjectedSpend = &projected
+	}
+
+	if budget == nil || *budget <= 0 {
+		row.Flag = pacingNoBudget
+		return row
+	}
+	row.ExpectedSpend = *budget * elapsedHours / 24
+	if row.ExpectedSpend > 0 {
+		row.Pace = row.Spend / row.ExpectedSpend
+	}
+	switch {
+	case row.Spend >= *budget*0.98:
+		row.Flag = pacingCapped
+	case row.ProjectedSpend == nil:
+		row.Flag = pacingTooEarly
+	case *row.ProjectedSpend >= *budget:
+		row.Flag = pacingWillCap
+		if row.HourlyRate > 0 {
+			capsAt := now.Add(time.Duration((*budget - row.Spend) / row.HourlyRate * float64(time.Hour)))
+			row.CapsAt = capsAt.Format("15:04")
+		}
+	case *row.ProjectedSpend < *budget*underspendBelow:
+		row.Flag = pacingUnderspend
+	default:
+		row.Flag = pacingOnPace
+	}
+	return row
+}
+
+func dayElapsed(now time.Time) float64 {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return now.Sub(midnight).Hours() / 24
+}
@@ -3,8 +3,11 @@ package cli
 import (
 	"context"
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"searchads-cli/internal/appleads"
 )
@@ -14,13 +17,27 @@ func RunCampaigns(ctx context.Context, client *appleads.Client, args []string, j
 		respondCommandError("campaigns", jsonOut, err)
 		return
 	}
+	args, err := resolveEntityFlags(ctx, client, args)
+	if err != nil {
+		respondCommandError("campaigns", jsonOut, err)
+		return
+	}
 
 	action := actionFromArgs(args, "list")
+	if isBulkInvocation(args) {
+		switch action {
+		case "pause", "activate", "delete", "update-budget", "set-budget":
+			runCampaignsBulk(ctx, client, args, action, jsonOut)
+			return
+		}
+	}
 	switch action {
 	case "report":
 		runCampaignsReport(ctx, client, args, jsonOut)
 	case "list":
-		runCampaignsList(ctx, client, jsonOut)
+		runCampaignsList(ctx, client, args, jsonOut)
+	case "get":
+		runCampaignsGet(ctx, client, args, jsonOut)
 	case "find":
 		runCampaignsFind(ctx, client, args, jsonOut)
 	case "pause", "activate":
@@ -29,6 +46,12 @@ func RunCampaigns(ctx context.Context, client *appleads.Client, args []string, j
 		runCampaignsDelete(ctx, client, args, jsonOut)
 	case "update-budget", "set-budget":
 		runCampaignsUpdateBudget(ctx, client, args, action, jsonOut)
+	case "update":
+		runCampaignsUpdate(ctx, client, args, jsonOut)
+	case "clone":
+		runCampaignsClone(ctx, client, args, jsonOut)
+	case "pacing":
+		runCampaignsPacing(ctx, client, args, jsonOut)
 	case "create":
 		runCampaignsCreate(ctx, client, args, jsonOut)
 	default:
@@ -37,18 +60,22 @@ func RunCampaigns(ctx context.Context, client *appleads.Client, args []string, j
 }
 
 func runCampaignsReport(ctx context.Context, client *appleads.Client, args []string, jsonOut bool) {
-	startRaw := valueForFlag(args, "--startDate")
-	endRaw := valueForFlag(args, "--endDate")
-	startDate, err := parseDate(startRaw)
+	startDate, endDate, err := reportDateRange(args)
 	if err != nil {
-		respondCommandError("campaigns", jsonOut, fmt.Errorf("Missing/invalid --startDate YYYY-MM-DD and --endDate YYYY-MM-DD"))
+		respondCommandError("campaigns", jsonOut, err)
 		return
 	}
-	endDate, err := parseDate(endRaw)
+	options, err := reportOptions(args, startDate, endDate)
 	if err != nil {
-		respondCommandError("campaigns", jsonOut, fmt.Errorf("Missing/invalid --startDate YYYY-MM-DD and --endDate YYYY-MM-DD"))
+		respondCommandError("campaigns", jsonOut, err)
 		return
 	}
+	compareStart, compareEnd, compare, err := reportCompareRange(args, startDate, endDate, options.Granularity)
+	if err != nil {
+		respondCommandError("campaigns", jsonOut, err)
+		return
+	}
+	startRaw, endRaw := startDate.Format("2006-01-02"), endDate.Format("2006-01-02")
 
 	includeFilter := strings.ToLower(strings.TrimSpace(valueForFlag(args, "--nameIncludes")))
 	excludeFilter := strings.ToLower(strings.TrimSpace(valueForFlag(args, "--nameExcludes")))
@@ -76,147 +103,230 @@ func runCampaignsReport(ctx context.Context, client *appleads.Client, args []str
 		filtered = append(filtered, campaign)
 	}
 
-	totalsByDate := map[string]struct {
+	var fx *fxRates
+	reportCurrency := strings.ToUpper(strings.TrimSpace(valueForFlag(args, "--reportCurrency")))
+	fxPath := strings.TrimSpace(valueForFlag(args, "--fxRates"))
+	if (reportCurrency == "") != (fxPath == "") {
+		respondCommandError("campaigns", jsonOut, fmt.Errorf("--reportCurrency and --fxRates go together"))
+		return
+	}
+	if fxPath != "" {
+		fx, err = loadFXRates(fxPath, reportCurrency)
+		if err != nil {
+			respondCommandError("campaigns", jsonOut, err)
+			return
+		}
+		// A week or month has one spend figure, so there is no single day
+		// whose rate fits it; its first day can even fall before the range.
+		if len(fx.byDay) > 0 && (options.Granularity == appleads.ReportGranularityWeekly || options.Granularity == appleads.ReportGranularityMonthly) {
+			respondCommandError("campaigns", jsonOut, fmt.Errorf("%s has dated rates, which need --granularity DAILY or HOURLY; use currency,rate rows for %s reports", fxPath, options.Granularity))
+			return
+		}
+	}
+
+	window, err := collectCampaignReport(ctx, client, filtered, startDate, endDate, options, fx)
+	if err != nil {
+		respondCommandError("campaigns", jsonOut, err)
+		return
+	}
+	totals, breakdown := window.totals, window.breakdown
+	payload := map[string]any{
+		"ok":            true,
+		"startDate":     startRaw,
+		"endDate":       endRaw,
+		"timeZone":      options.TimeZone,
+		"granularity":   options.Granularity,
+		"campaignCount": len(filtered),
+		"totals":        totals,
+		"campaigns":     window.campaigns,
+	}
+	if fx != nil {
+		payload["reportCurrency"] = fx.target
+	}
+	if len(options.GroupBy) > 0 {
+		payload["groupBy"] = options.GroupBy
+		payload["breakdown"] = breakdown.rows()
+	}
+	compareLabels := []string{"campaignId", "campaignName", "currency"}
+	if compare {
+		previous, err := collectCampaignReport(ctx, client, filtered, compareStart, compareEnd, options, fx)
+		if err != nil {
+			respondCommandError("campaigns", jsonOut, err)
+			return
+		}
+		payload["compare"] = map[string]any{"startDate": compareStart.Format("2006-01-02"), "endDate": compareEnd.Format("2006-01-02")}
+		payload["comparison"] = compareReportRows(window.campaigns, previous.campaigns, []string{"campaignId"}, compareLabels)
+	}
+	if jsonOut {
+		printJSON(payload)
+		return
+	}
+
+	fmt.Printf("campaignCount=%d range=%s...%s timeZone=%s granularity=%s\n", len(filtered), startRaw, endRaw, options.TimeZone, options.Granularity)
+	for _, total := range totals {
+		day, _ := total["date"].(string)
+		spend, _ := total["spend"].(float64)
+		taps, _ := total["taps"].(int)
+		installs, _ := total["installs"].(int)
+		cpt, _ := total["cpt"].(float64)
+		ttr, _ := total["ttr"].(float64)
+		cr, _ := total["cr"].(float64)
+		currency, _ := total["currency"].(string)
+		fmt.Printf("%s\t%.2f\t%d\t%d\t%.4f\t%.4f\t%.4f\t%s\n", day, spend, taps, installs, cpt, ttr, cr, currency)
+	}
+	breakdown.print()
+	if compare {
+		printReportComparison(compareStart, compareEnd, payload["comparison"].([]map[string]any), compareLabels)
+	}
+}
+
+// campaignReportWindow is one date range of a campaigns report.
+type campaignReportWindow struct {
+	totals    []map[string]any
+	campaigns []map[string]any
+	breakdown *reportBreakdown
+}
+
+// collectCampaignReport fetches one date range for the campaigns, converting
+// spend when fx is set, so --compare can run the same report over two
+// windows.
+func collectCampaignReport(ctx context.Context, client *appleads.Client, filtered []appleads.CampaignSummary, startDate, endDate time.Time, options appleads.ReportOptions, fx *fxRates) (*campaignReportWindow, error) {
+	// Totals are kept per date and currency; with --fxRates every row is
+	// converted first, so there is one currency.
+	type reportKey struct{ date, currency string }
+	type reportTotal struct {
 		spend       float64
 		taps        int
 		impressions int
 		installs    int
-	}{}
+	}
+	totalsByKey := map[reportKey]reportTotal{}
 	campaignRows := make([]map[string]any, 0, len(filtered))
-	var currencyCode *string
+	missingRates := map[string]struct{}{}
+	breakdown := newReportBreakdown(options.GroupBy)
 
 	for _, campaign := range filtered {
 		adGroups, err := client.FetchAdGroups(ctx, campaign.ID)
 		if err != nil {
-			respondCommandError("campaigns", jsonOut, err)
-			return
+			return nil, err
 		}
 
-		campaignSpend := 0.0
-		campaignTaps := 0
-		campaignImpressions := 0
-		campaignInstalls := 0
+		campaignCurrency := ""
+		if campaign.Currency != nil {
+			campaignCurrency = strings.ToUpper(*campaign.Currency)
+		}
+		var campaignTotal reportTotal
 		for _, group := range adGroups {
-			dailyRows, err := client.FetchAdGroupDailyMetrics(ctx, startDate, endDate, campaign.ID, group.ID)
+			dailyRows, err := client.FetchAdGroupDailyMetrics(ctx, startDate, endDate, options, campaign.ID, group.ID)
 			if err != nil {
-				respondCommandError("campaigns", jsonOut, err)
-				return
+				return nil, err
 			}
 			for _, daily := range dailyRows {
-				total := totalsByDate[daily.Date]
-				total.spend += daily.Spend
-				total.taps += daily.Taps
-				total.impressions += daily.Impressions
-				if daily.Installs != nil {
-					total.installs += *daily.Installs
+				currency := campaignCurrency
+				if daily.CurrencyCode != nil {
+					currency = strings.ToUpper(*daily.CurrencyCode)
+					campaignCurrency = firstNonEmptyString(campaignCurrency, currency)
 				}
-				totalsByDate[daily.Date] = total
-
-				campaignSpend += daily.Spend
-				campaignTaps += daily.Taps
-				campaignImpressions += daily.Impressions
-				if daily.Installs != nil {
-					campaignInstalls += *daily.Installs
+				spend := daily.Spend
+				if fx != nil {
+					rate, ok := fx.rate(appleads.ReportPeriodStart(daily.Date), currency)
+					if !ok && spend != 0 {
+						missingRates[firstNonEmptyString(currency, "unknown currency")+" on "+daily.Date] = struct{}{}
+					}
+					spend *= rate
+					currency = fx.target
 				}
-				if currencyCode == nil && daily.CurrencyCode != nil {
-					currencyCode = daily.CurrencyCode
+				installs := 0
+				if daily.Installs != nil {
+					installs = *daily.Installs
 				}
-			}
-		}
+				key := reportKey{daily.Date, currency}
+				total := totalsByKey[key]
+				total.spend += spend
+				total.taps += daily.Taps
+				total.impressions += daily.Impressions
+				total.installs += installs
+				totalsByKey[key] = total
 
-		cpt := 0.0
-		if campaignTaps > 0 {
-			cpt = campaignSpend / float64(campaignTaps)
-		}
-		ttr := 0.0
-		if campaignImpressions > 0 {
-			ttr = float64(campaignTaps) / float64(campaignImpressions)
+				breakdown.add(daily.Dimensions, currency, spend, daily.Taps, daily.Impressions, installs)
+
+				campaignTotal.spend += spend
+				campaignTotal.taps += daily.Taps
+				campaignTotal.impressions += daily.Impressions
+				campaignTotal.installs += installs
+			}
 		}
-		cr := 0.0
-		if campaignTaps > 0 {
-			cr = float64(campaignInstalls) / float64(campaignTaps)
+		if fx != nil {
+			campaignCurrency = fx.target
 		}
 
-		campaignRows = append(campaignRows, map[string]any{
+		row := map[string]any{
 			"campaignId":   campaign.ID,
 			"campaignName": campaign.Name,
 			"status":       campaign.Status,
-			"spend":        campaignSpend,
-			"taps":         campaignTaps,
-			"installs":     campaignInstalls,
-			"impressions":  campaignImpressions,
-			"cpt":          cpt,
-			"ttr":          ttr,
-			"cr":           cr,
-		})
+			"spend":        campaignTotal.spend,
+			"currency":     nullableString(campaignCurrency),
+			"taps":         campaignTotal.taps,
+			"installs":     campaignTotal.installs,
+			"impressions":  campaignTotal.impressions,
+		}
+		addReportRatios(row, campaignTotal.spend, campaignTotal.taps, campaignTotal.impressions, campaignTotal.installs)
+		campaignRows = append(campaignRows, row)
+	}
+	if len(missingRates) > 0 {
+		return nil, missingFXRates(missingRates)
 	}
 
-	days := make([]string, 0, len(totalsByDate))
-	for day := range totalsByDate {
-		days = append(days, day)
+	keys := make([]reportKey, 0, len(totalsByKey))
+	for key := range totalsByKey {
+		keys = append(keys, key)
 	}
-	sort.Strings(days)
-	totals := make([]map[string]any, 0, len(days))
-	for _, day := range days {
-		t := totalsByDate[day]
-		cpt := 0.0
-		if t.taps > 0 {
-			cpt = t.spend / float64(t.taps)
-		}
-		ttr := 0.0
-		if t.impressions > 0 {
-			ttr = float64(t.taps) / float64(t.impressions)
-		}
-		cr := 0.0
-		if t.taps > 0 {
-			cr = float64(t.installs) / float64(t.taps)
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].date != keys[j].date {
+			return keys[i].date < keys[j].date
 		}
+		return keys[i].currency < keys[j].currency
+	})
+	totals := make([]map[string]any, 0, len(keys))
+	for _, key := range keys {
+		t := totalsByKey[key]
 		total := map[string]any{
-			"date":        day,
+			"date":        key.date,
+			"currency":    nullableString(key.currency),
 			"spend":       t.spend,
 			"taps":        t.taps,
 			"installs":    t.installs,
 			"impressions": t.impressions,
-			"cpt":         cpt,
-			"ttr":         ttr,
-			"cr":          cr,
-		}
-		if currencyCode != nil {
-			total["currency"] = *currencyCode
-		} else {
-			total["currency"] = nil
 		}
+		addReportRatios(total, t.spend, t.taps, t.impressions, t.installs)
 		totals = append(totals, total)
 	}
 
-	payload := map[string]any{
-		"ok":            true,
-		"startDate":     startRaw,
-		"endDate":       endRaw,
-		"campaignCount": len(filtered),
-		"totals":        totals,
-		"campaigns":     campaignRows,
+	return &campaignReportWindow{totals: totals, campaigns: campaignRows, breakdown: breakdown}, nil
+}
+
+// addReportRatios sets cpt, ttr and cr on a report row; cpt is in the row's
+// currency.
+func addReportRatios(row map[string]any, spend float64, taps, impressions, installs int) {
+	cpt, ttr, cr := 0.0, 0.0, 0.0
+	if taps > 0 {
+		cpt = spend / float64(taps)
+		cr = float64(installs) / float64(taps)
 	}
-	if jsonOut {
-		printJSON(payload)
-		return
+	if impressions > 0 {
+		ttr = float64(taps) / float64(impressions)
 	}
+	row["cpt"], row["ttr"], row["cr"] = cpt, ttr, cr
+}
 
-	fmt.Printf("campaignCount=%d range=%s...%s\n", len(filtered), startRaw, endRaw)
-	for _, total := range totals {
-		day, _ := total["date"].(string)
-		spend, _ := total["spend"].(float64)
-		taps, _ := total["taps"].(int)
-		installs, _ := total["installs"].(int)
-		cpt, _ := total["cpt"].(float64)
-		ttr, _ := total["ttr"].(float64)
-		cr, _ := total["cr"].(float64)
-		currency, _ := total["currency"].(string)
-		fmt.Printf("%s\t%.2f\t%d\t%d\t%.4f\t%.4f\t%.4f\t%s\n", day, spend, taps, installs, cpt, ttr, cr, currency)
+func nullableString(value string) any {
+	if value == "" {
+		return nil
 	}
+	return value
 }
 
-func runCampaignsList(ctx context.Context, client *appleads.Client, jsonOut bool) {
+func runCampaignsList(ctx context.Context, client *appleads.Client, args []string, jsonOut bool) {
 	campaigns, err := client.FetchCampaigns(ctx)
 	if err != nil {
 		respondCommandError("campaigns", jsonOut, err)
@@ -229,11 +339,93 @@ func runCampaignsList(ctx context.Context, client *appleads.Client, jsonOut bool
 		return
 	}
 	fmt.Printf("campaignCount=%d\n", len(campaigns))
+	wide := hasFlag(args, "--wide")
 	for _, campaign := range campaigns {
+		if wide {
+			fmt.Println(formatCampaignWide(campaign))
+			continue
+		}
 		fmt.Printf("%d\t%s\t%s\n", campaign.ID, campaign.Status, campaign.Name)
 	}
 }
 
+func runCampaignsGet(ctx context.Context, client *appleads.Client, args []string, jsonOut bool) {
+	campaignID, err := requiredIntFlag(args, "--campaignId")
+	if err != nil {
+		respondCommandError("campaigns", jsonOut, err)
+		return
+	}
+	campaign, err := client.FetchCampaign(ctx, campaignID)
+	if err != nil {
+		respondCommandError("campaigns", jsonOut, err)
+		return
+	}
+	if jsonOut {
+		printJSON(campaign)
+		return
+	}
+	fmt.Printf("campaignId=%d adamId=%d\n", campaign.ID, campaign.AdamID)
+	fmt.Printf("name=%s\n", campaign.Name)
+	fmt.Printf("status=%s servingStatus=%s displayStatus=%s\n", campaign.Status, firstNonEmptyString(campaign.ServingStatus, "-"), firstNonEmptyString(campaign.DisplayStatus, "-"))
+	fmt.Printf("dailyBudget=%s budget=%s\n", formatCampaignMoney(campaign.DailyBudgetAmount, campaign.Currency), formatCampaignMoney(campaign.BudgetAmount, campaign.Currency))
+	placement := "-"
+	if known, ok := appleads.PlacementOf(*campaign); ok {
+		placement = known.Name
+	}
+	fmt.Printf("placement=%s countriesOrRegions=%s supplySources=%s adChannelType=%s billingEvent=%s\n",
+		placement, joinOrDash(campaign.CountriesOrRegions), joinOrDash(campaign.SupplySources), firstNonEmptyString(campaign.AdChannelType, "-"), firstNonEmptyString(campaign.BillingEvent, "-"))
+	fmt.Printf("startTime=%s endTime=%s\n", stringPtrOrDash(campaign.StartTime), stringPtrOrDash(campaign.EndTime))
+	fmt.Printf("servingStateReasons=%s\n", joinOrDash(campaign.ServingStateReasons))
+	for _, country := range sortedKeys(campaign.CountryOrRegionServingStateReasons) {
+		fmt.Printf("servingStateReasons[%s]=%s\n", country, joinOrDash(campaign.CountryOrRegionServingStateReasons[country]))
+	}
+}
+
+// formatCampaignWide is the --wide line for list and find: everything that
+// explains whether and where a campaign serves.
+func formatCampaignWide(campaign appleads.CampaignSummary) string {
+	reasons := append([]string{}, campaign.ServingStateReasons...)
+	for _, country := range sortedKeys(campaign.CountryOrRegionServingStateReasons) {
+		for _, reason := range campaign.CountryOrRegionServingStateReasons[country] {
+			reasons = append(reasons, country+":"+reason)
+		}
+	}
+	return strings.Join([]string{
+		fmt.Sprint(campaign.ID),
+		campaign.Status,
+		firstNonEmptyString(campaign.ServingStatus, "-"),
+		fmt.Sprint(campaign.AdamID),
+		formatCampaignMoney(campaign.DailyBudgetAmount, campaign.Currency),
+		joinOrDash(campaign.CountriesOrRegions),
+		joinOrDash(campaign.SupplySources),
+		stringPtrOrDash(campaign.StartTime),
+		stringPtrOrDash(campaign.EndTime),
+		joinOrDash(reasons),
+		campaign.Name,
+	}, "\t")
+}
+
+func formatCampaignMoney(amount *float64, currency *string) string {
+	if amount == nil {
+		return "-"
+	}
+	if currency == nil {
+		return fmt.Sprintf("%.2f", *amount)
+	}
+	return fmt.Sprintf("%.2f %s", *amount, *currency)
+}
+
+func joinOrDash(values []string) string {
+	return firstNonEmptyString(strings.Join(values, ","), "-")
+}
+
+func stringPtrOrDash(value *string) string {
+	if value == nil {
+		return "-"
+	}
+	return firstNonEmptyString(*value, "-")
+}
+
 func runCampaignsFind(ctx context.Context, client *appleads.Client, args []string, jsonOut bool) {
 	campaigns, err := client.FetchCampaigns(ctx)
 	if err != nil {
@@ -275,7 +467,12 @@ func runCampaignsFind(ctx context.Context, client *appleads.Client, args []strin
 		return
 	}
 	fmt.Printf("campaignCount=%d\n", len(filtered))
+	wide := hasFlag(args, "--wide")
 	for _, campaign := range filtered {
+		if wide {
+			fmt.Println(formatCampaignWide(campaign))
+			continue
+		}
 		fmt.Printf("%d\t%s\t%d\t%s\n", campaign.ID, campaign.Status, campaign.AdamID, campaign.Name)
 	}
 }
@@ -332,17 +529,11 @@ func runCampaignsUpdateBudget(ctx context.Context, client *appleads.Client, args
 		respondCommandError("campaigns", jsonOut, err)
 		return
 	}
-	budgetRaw := strings.TrimSpace(valueForFlag(args, "--budgetAmount"))
-	if budgetRaw == "" {
-		respondCommandError("campaigns", jsonOut, fmt.Errorf("Missing required --budgetAmount <number>"))
-		return
-	}
-	var budgetAmount float64
-	if _, scanErr := fmt.Sscanf(budgetRaw, "%f", &budgetAmount); scanErr != nil || budgetAmount <= 0 {
-		respondCommandError("campaigns", jsonOut, fmt.Errorf("Missing required --budgetAmount <number>"))
+	budgetAmount, budgetCurrency, err := parseDailyBudgetFlags(args)
+	if err != nil {
+		respondCommandError("campaigns", jsonOut, err)
 		return
 	}
-	budgetCurrency := firstNonEmptyString(strings.TrimSpace(valueForFlag(args, "--budgetCurrency")), "GBP")
 
 	updated, err := client.UpdateCampaignDailyBudget(ctx, campaignID, budgetAmount, budgetCurrency)
 	if err != nil {
@@ -364,6 +555,203 @@ func runCampaignsUpdateBudget(ctx context.Context, client *appleads.Client, args
 	fmt.Printf("ok id=%d status=%s name=%s dailyBudget=%.4f %s\n", updated.ID, updated.Status, updated.Name, budgetAmount, strings.ToUpper(budgetCurrency))
 }
 
+func parseDailyBudgetFlags(args []string) (float64, string, error) {
+	budgetRaw := strings.TrimSpace(valueForFlag(args, "--budgetAmount"))
+	var budgetAmount float64
+	if _, scanErr := fmt.Sscanf(budgetRaw, "%f", &budgetAmount); budgetRaw == "" || scanErr != nil || budgetAmount <= 0 {
+		return 0, "", fmt.Errorf("Missing required --budgetAmount <number>")
+	}
+	budgetCurrency := firstNonEmptyString(strings.TrimSpace(valueForFlag(args, "--budgetCurrency")), "GBP")
+	return budgetAmount, budgetCurrency, nil
+}
+
+func runCampaignsBulk(ctx context.Context, client *appleads.Client, args []string, action string, jsonOut bool) {
+	targets, err := readBulkInput(args, "campaignId")
+	if err != nil {
+		respondCommandError("campaigns", jsonOut, err)
+		return
+	}
+	budgetAmount, budgetCurrency := 0.0, ""
+	if action == "update-budget" || action == "set-budget" {
+		budgetAmount, budgetCurrency, err = parseDailyBudgetFlags(args)
+		if err != nil {
+			respondCommandError("campaigns", jsonOut, err)
+			return
+		}
+	}
+	runBulkTargets("campaigns", action, args, jsonOut, targets, func(target bulkTarget) (bulkItemResult, error) {
+		switch action {
+		case "delete":
+			return bulkItemResult{}, client.DeleteCampaign(ctx, target.ID)
+		case "update-budget", "set-budget":
+			updated, err := client.UpdateCampaignDailyBudget(ctx, target.ID, budgetAmount, budgetCurrency)
+			if err != nil {
+				return bulkItemResult{}, err
+			}
+			return bulkItemResult{Name: updated.Name, Status: updated.Status}, nil
+		default:
+			status := "ENABLED"
+			if action == "pause" {
+				status = "PAUSED"
+			}
+			updated, err := client.UpdateCampaignStatus(ctx, target.ID, status)
+			if err != nil {
+				return bulkItemResult{}, err
+			}
+			return bulkItemResult{Name: updated.Name, Status: updated.Status}, nil
+		}
+	})
+}
+
+// campaignFieldChange is one line of the before/after diff campaigns update
+// prints.
+type campaignFieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+func runCampaignsUpdate(ctx context.Context, client *appleads.Client, args []string, jsonOut bool) {
+	campaignID, err := requiredIntFlag(args, "--campaignId")
+	if err != nil {
+		respondCommandError("campaigns", jsonOut, err)
+		return
+	}
+	before, err := client.FetchCampaign(ctx, campaignID)
+	if err != nil {
+		respondCommandError("campaigns", jsonOut, err)
+		return
+	}
+	update, err := campaignUpdateFromFlags(ctx, client, args, before)
+	if err != nil {
+		respondCommandError("campaigns", jsonOut, err)
+		return
+	}
+	after, err := client.UpdateCampaign(ctx, campaignID, update)
+	if err != nil {
+		respondCommandError("campaigns", jsonOut, err)
+		return
+	}
+	changes := campaignChanges(*before, *after)
+	if jsonOut {
+		printJSON(map[string]any{"ok": true, "action": "update", "campaign": after, "changes": changes})
+		return
+	}
+	fmt.Printf("ok action=update id=%d status=%s name=%s\n", after.ID, after.Status, after.Name)
+	for _, change := range changes {
+		fmt.Printf("%s: %s -> %s\n", change.Field, change.Before, change.After)
+	}
+}
+
+// campaignUpdateFromFlags builds the partial update. --addCountries extends
+// the campaign's current list; --countries replaces it. Every code must be a
+// storefront Apple supports.
+func campaignUpdateFromFlags(ctx context.Context, client *appleads.Client, args []string, current *appleads.CampaignSummary) (appleads.CampaignUpdate, error) {
+	update := appleads.CampaignUpdate{ClearGeoTargeting: hasFlag(args, "--clearGeoTargeting")}
+	if hasFlag(args, "--name") {
+		name := strings.TrimSpace(valueForFlag(args, "--name"))
+		if name == "" {
+			return update, fmt.Errorf("--name cannot be empty")
+		}
+		update.Name = &name
+	}
+
+	replace := normalizeUpperValues(splitCSVValues(valuesForFlag(args, "--countries")))
+	add := normalizeUpperValues(splitCSVValues(valuesForFlag(args, "--addCountries")))
+	if len(replace) > 0 && len(add) > 0 {
+		return update, fmt.Errorf("Use either --countries or --addCountries, not both")
+	}
+	if len(replace) > 0 || len(add) > 0 {
+		countries := []string{}
+		if len(add) > 0 {
+			replace = append(append([]string{}, current.CountriesOrRegions...), add...)
+		}
+		for _, country := range replace {
+			if !contains(countries, country) {
+				countries = append(countries, country)
+			}
+		}
+		if err := validateCampaignCountries(ctx, client, countries); err != nil {
+			return update, err
+		}
+		update.CountriesOrRegions = countries
+	}
+
+	endTime := strings.TrimSpace(valueForFlag(args, "--endTime"))
+	switch {
+	case endTime != "" && hasFlag(args, "--clearEndTime"):
+		return update, fmt.Errorf("Use either --endTime or --clearEndTime, not both")
+	case endTime != "":
+		parsed, err := time.Parse(time.RFC3339, endTime)
+		if err != nil {
+			return update, fmt.Errorf("Invalid --endTime %q. Use RFC 3339, e.g. 2026-12-31T23:59:59Z", endTime)
+		}
+		if !parsed.After(time.Now()) {
+			return update, fmt.Errorf("--endTime %s is not in the future", endTime)
+		}
+		update.EndTime = &endTime
+	case hasFlag(args, "--clearEndTime"):
+		cleared := ""
+		update.EndTime = &cleared
+	}
+
+	if hasFlag(args, "--budgetAmount") {
+		budgetRaw := strings.TrimSpace(valueForFlag(args, "--budgetAmount"))
+		budgetAmount, err := strconv.ParseFloat(budgetRaw, 64)
+		if err != nil || !(budgetAmount > 0) || math.IsInf(budgetAmount, 0) {
+			return update, fmt.Errorf("Invalid --budgetAmount %q", budgetRaw)
+		}
+		update.BudgetAmount = &budgetAmount
+		currentCurrency := ""
+		if current.Currency != nil {
+			currentCurrency = *current.Currency
+		}
+		update.BudgetCurrency = firstNonEmptyString(strings.TrimSpace(valueForFlag(args, "--budgetCurrency")), currentCurrency, configDefault("currency"), "GBP")
+	}
+
+	if update.Name == nil && update.CountriesOrRegions == nil && update.EndTime == nil && update.BudgetAmount == nil {
+		return update, fmt.Errorf("Provide at least one of --name, --countries, --addCountries, --endTime, --clearEndTime or --budgetAmount")
+	}
+	return update, nil
+}
+
+func validateCampaignCountries(ctx context.Context, client *appleads.Client, countries []string) error {
+	supported, err := client.FetchSupportedCountriesOrRegions(ctx)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]struct{}, len(supported))
+	for _, item := range supported {
+		known[item.Code] = struct{}{}
+	}
+	var unknown []string
+	for _, country := range countries {
+		if _, ok := known[country]; !ok {
+			unknown = append(unknown, country)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("Unsupported countries or regions: %s. See searchads product-pages countries", strings.Join(unknown, ","))
+	}
+	return nil
+}
+
+func campaignChanges(before, after appleads.CampaignSummary) []campaignFieldChange {
+	fields := []campaignFieldChange{
+		{Field: "name", Before: before.Name, After: after.Name},
+		{Field: "countriesOrRegions", Before: joinOrDash(before.CountriesOrRegions), After: joinOrDash(after.CountriesOrRegions)},
+		{Field: "endTime", Before: stringPtrOrDash(before.EndTime), After: stringPtrOrDash(after.EndTime)},
+		{Field: "budgetAmount", Before: formatCampaignMoney(before.BudgetAmount, before.Currency), After: formatCampaignMoney(after.BudgetAmount, after.Currency)},
+	}
+	changes := make([]campaignFieldChange, 0, len(fields))
+	for _, field := range fields {
+		if field.Before != field.After {
+			changes = append(changes, field)
+		}
+	}
+	return changes
+}
+
 func runCampaignsCreate(ctx context.Context, client *appleads.Client, args []string, jsonOut bool) {
 	name := strings.TrimSpace(valueForFlag(args, "--name"))
 	if name == "" {
@@ -376,12 +764,12 @@ func runCampaignsCreate(ctx context.Context, client *appleads.Client, args []str
 		respondCommandError("campaigns", jsonOut, fmt.Errorf("Missing required --budgetAmount <number>"))
 		return
 	}
-	budgetCurrency := firstNonEmptyString(valueForFlag(args, "--budgetCurrency"), "GBP")
+	budgetCurrency := firstNonEmptyString(valueForFlag(args, "--budgetCurrency"), configDefault("currency"), "GBP")
 	budgetType := firstNonEmptyString(valueForFlag(args, "--budgetType"), "DAILY")
 	status := firstNonEmptyString(valueForFlag(args, "--status"), "ENABLED")
 	adamID := valueForFlag(args, "--adamId")
 
-	countriesValue := firstNonEmptyString(valueForFlag(args, "--countries"), "GB")
+	countriesValue := firstNonEmptyString(valueForFlag(args, "--countries"), configDefault("countries"), "GB")
 	countries := []string{}
 	for _, raw := range strings.Split(countriesValue, ",") {
 		country := strings.ToUpper(strings.TrimSpace(raw))
@@ -390,6 +778,12 @@ func runCampaignsCreate(ctx context.Context, client *appleads.Client, args []str
 		}
 	}
 
+	placement, err := appleads.LookupPlacement(valueForFlag(args, "--placement"))
+	if err != nil {
+		respondCommandError("campaigns", jsonOut, err)
+		return
+	}
+
 	created, err := client.CreateCampaign(
 		ctx,
 		name,
@@ -401,16 +795,20 @@ func runCampaignsCreate(ctx context.Context, client *appleads.Client, args []str
 		countries,
 		valueForFlag(args, "--startTime"),
 		valueForFlag(args, "--endTime"),
+		placement.Name,
 	)
 	if err != nil {
 		respondCommandError("campaigns", jsonOut, err)
 		return
 	}
 	if jsonOut {
-		printJSON(map[string]any{"ok": true, "id": created.ID, "name": created.Name, "status": created.Status})
+		printJSON(map[string]any{"ok": true, "id": created.ID, "name": created.Name, "status": created.Status, "placement": placement.Name})
 		return
 	}
-	fmt.Printf("ok createdCampaign id=%d status=%s name=%s\n", created.ID, created.Status, created.Name)
+	fmt.Printf("ok createdCampaign id=%d status=%s placement=%s name=%s\n", created.ID, created.Status, placement.Name, created.Name)
+	if placement.RequiresCustomProductPage {
+		fmt.Println("note: every ad in this campaign must use a custom product page creative")
+	}
 }
 
 func respondCommandError(command string, jsonOut bool, err error) {
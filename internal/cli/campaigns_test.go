@@ -0,0 +1,250 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"searchads-cli/internal/appleads"
+)
+
+func TestCampaignUpdateFromFlagsValidatesCountriesAndDiffs(t *testing.T) {
+	t.Setenv("OE_ADS_CREDENTIALS_JSON", testCredentialsJSON(t))
+	client := appleads.NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.Host == "appleid.apple.com":
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.Path == "/api/v5/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.URL.Path == "/api/v5/countries-or-regions":
+				return jsonResponse(http.StatusOK, `{"data":[{"code":"GB"},{"code":"US"},{"code":"IE"}]}`), nil
+			}
+			return jsonResponse(http.StatusNotFound, `{"error":"unexpected request"}`), nil
+		}),
+	})
+	ctx := context.Background()
+	currency := "GBP"
+	current := &appleads.CampaignSummary{ID: 7, Name: "Brand", CountriesOrRegions: []string{"GB"}, Currency: &currency}
+
+	update, err := campaignUpdateFromFlags(ctx, client, []string{"update", "--addCountries", "ie,gb", "--budgetAmount", "500", "--clearEndTime"}, current)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(update.CountriesOrRegions, ",") != "GB,IE" || update.BudgetCurrency != "GBP" || update.EndTime == nil || *update.EndTime != "" || update.Name != nil {
+		t.Fatalf("unexpected update: %+v", update)
+	}
+	if _, err := campaignUpdateFromFlags(ctx, client, []string{"update", "--countries", "GB,XX"}, current); err == nil || !strings.Contains(err.Error(), "XX") {
+		t.Fatalf("expected XX to be rejected, got %v", err)
+	}
+	if _, err := campaignUpdateFromFlags(ctx, client, []string{"update"}, current); err == nil {
+		t.Fatal("expected an update without fields to fail")
+	}
+	for _, args := range [][]string{
+		{"update", "--budgetAmount", "500abc"},
+		{"update", "--budgetAmount", "NaN"},
+		{"update", "--endTime", "next friday"},
+		{"update", "--endTime", "2001-01-01T00:00:00Z"},
+	} {
+		if _, err := campaignUpdateFromFlags(ctx, client, args, current); err == nil {
+			t.Fatalf("expected %q to be rejected", args[1:])
+		}
+	}
+	endTime := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	if update, err := campaignUpdateFromFlags(ctx, client, []string{"update", "--endTime", endTime}, current); err != nil || update.EndTime == nil || *update.EndTime != endTime {
+		t.Fatalf("expected a future RFC 3339 end time to pass, got %+v %v", update, err)
+	}
+
+	budget := 500.0
+	after := *current
+	after.CountriesOrRegions = []string{"GB", "IE"}
+	after.BudgetAmount = &budget
+	changes := campaignChanges(*current, after)
+	if len(changes) != 2 || changes[0].Field != "countriesOrRegions" || changes[0].After != "GB,IE" || changes[1].Before != "-" || changes[1].After != "500.00 GBP" {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+}
+
+func TestCheckAdPlacementRequiresCustomProductPageForTodayTab(t *testing.T) {
+	t.Setenv("OE_ADS_CREDENTIALS_JSON", testCredentialsJSON(t))
+	client := appleads.NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch req.URL.Path {
+			case "/api/v5/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case "/api/v5/campaigns/1":
+				return jsonResponse(http.StatusOK, `{"data":{"id":1,"supplySources":["APPSTORE_TODAY_TAB"]}}`), nil
+			case "/api/v5/campaigns/2":
+				return jsonResponse(http.StatusOK, `{"data":{"id":2,"supplySources":["APPSTORE_SEARCH_RESULTS"]}}`), nil
+			case "/api/v5/creatives/10":
+				return jsonResponse(http.StatusOK, `{"data":{"id":10,"type":"DEFAULT_PRODUCT_PAGE"}}`), nil
+			case "/api/v5/creatives/11":
+				return jsonResponse(http.StatusOK, `{"data":{"id":11,"type":"CUSTOM_PRODUCT_PAGE"}}`), nil
+			}
+			if req.URL.Host == "appleid.apple.com" {
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			}
+			return jsonResponse(http.StatusNotFound, `{"error":"unexpected request"}`), nil
+		}),
+	})
+	ctx := context.Background()
+
+	if err := checkAdPlacement(ctx, client, 1, 10); err == nil || !strings.Contains(err.Error(), "today-tab") {
+		t.Fatalf("expected a default product page ad to be refused for the Today tab, got %v", err)
+	}
+	if err := checkAdPlacement(ctx, client, 1, 11); err != nil {
+		t.Fatalf("expected a custom product page ad to be allowed, got %v", err)
+	}
+	if err := checkAdPlacement(ctx, client, 2, 10); err != nil {
+		t.Fatalf("expected search results to accept any creative, got %v", err)
+	}
+}
+
+func TestCampaignCloneCopiesTreeWithScaledBids(t *testing.T) {
+	t.Setenv("OE_ADS_CREDENTIALS_JSON", testCredentialsJSON(t))
+	reads := map[string]string{
+		"/api/v5/campaigns/1":                                    `{"data":{"id":1,"adamId":99,"name":"Brand","status":"ENABLED","dailyBudgetAmount":{"amount":"40","currency":"EUR"},"supplySources":["APPSTORE_SEARCH_RESULTS"]}}`,
+		"/api/v5/campaigns/1/negativekeywords":                   `{"data":[{"id":5,"text":"free","matchType":"BROAD","status":"ACTIVE"}]}`,
+		"/api/v5/campaigns/1/adgroups":                           `{"data":[{"id":10,"name":"Exact","status":"ENABLED","defaultBidAmount":{"amount":"1.00","currency":"EUR"},"automatedKeywordsOptIn":false}]}`,
+		"/api/v5/campaigns/1/adgroups/10/targetingkeywords":      `{"data":[{"id":100,"text":"brand app","matchType":"EXACT","status":"ACTIVE","bidAmount":{"amount":"2.50","currency":"EUR"}}]}`,
+		"/api/v5/campaigns/1/adgroups/10/negativekeywords":       `{"data":[]}`,
+		"/api/v5/campaigns/1/adgroups/10/ads":                    `{"data":[{"id":30,"creativeId":7,"name":"Default","status":"ENABLED"},{"id":31,"creativeId":8,"name":"Old","status":"ENABLED","deleted":true}]}`,
+		"/api/v5/campaigns/2/negativekeywords/bulk":              `{"data":[]}`,
+		"/api/v5/campaigns/2/adgroups":                           `{"data":{"id":20,"name":"Exact","status":"ENABLED"}}`,
+		"/api/v5/campaigns/2/adgroups/20/targetingkeywords/bulk": `{"data":[{"id":200}]}`,
+		"/api/v5/campaigns/2/adgroups/20/ads":                    `{"data":{"id":300,"creativeId":7,"name":"Default","status":"ENABLED"}}`,
+	}
+	var posted []string
+	client := appleads.NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.Host == "appleid.apple.com":
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.Path == "/api/v5/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.Method == http.MethodPost:
+				body, _ := io.ReadAll(req.Body)
+				posted = append(posted, req.URL.Path+" "+string(body))
+				if req.URL.Path == "/api/v5/campaigns" {
+					return jsonResponse(http.StatusOK, `{"data":{"id":2,"name":"Brand - DE","status":"PAUSED"}}`), nil
+				}
+			}
+			if body, ok := reads[req.URL.Path]; ok {
+				return jsonResponse(http.StatusOK, body), nil
+			}
+			return jsonResponse(http.StatusNotFound, `{"error":"unexpected request"}`), nil
+		}),
+	})
+	ctx := context.Background()
+
+	plan, err := planCampaignClone(ctx, client, 1, []string{"DE"}, 0.8, defaultCloneNameTemplate, "PAUSED")
+	if err != nil {
+		t.Fatalf("plan failed: %v", err)
+	}
+	if plan.Name != "Brand - DE" || plan.AdGroups[0].DefaultBid != 0.8 || *plan.AdGroups[0].Keywords[0].BidAmount != 2 || len(plan.AdGroups[0].Ads) != 1 {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+	if len(posted) != 0 {
+		t.Fatalf("planning must not write, got %q", posted)
+	}
+
+	mapping, failures, err := executeCampaignClone(ctx, client, plan)
+	if err != nil || len(failures) > 0 {
+		t.Fatalf("clone failed: %v %q", err, failures)
+	}
+	var pairs []string
+	for _, pair := range mapping {
+		pairs = append(pairs, fmt.Sprintf("%s:%d->%d", pair.Kind, pair.SourceID, pair.ID))
+	}
+	if strings.Join(pairs, ",") != "campaign:1->2,adgroup:10->20,keyword:100->200,ad:30->300" {
+		t.Fatalf("unexpected mapping: %v", pairs)
+	}
+	if len(posted) != 5 || !strings.Contains(posted[0], `"countriesOrRegions":["DE"]`) || !strings.Contains(posted[0], `"adamId":99`) ||
+		!strings.Contains(posted[2], `"amount":"0.8000"`) || !strings.Contains(posted[2], `"automatedKeywordsOptIn":false`) {
+		t.Fatalf("unexpected writes: %q", posted)
+	}
+}
+
+func TestPaceCampaignFlagsCapAndUnderspend(t *testing.T) {
+	t.Parallel()
+
+	noon := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	budget := 100.0
+	hours := func(perHour float64) []float64 {
+		spend := make([]float64, 24)
+		for hour := 0; hour < 12; hour++ {
+			spend[hour] = perHour
+		}
+		return spend
+	}
+
+	onPace := paceCampaign(&budget, hours(4), noon, 0.5)
+	if onPace.Flag != pacingOnPace || onPace.Spend != 48 || onPace.Pace != 0.96 || onPace.ProjectedSpend == nil || *onPace.ProjectedSpend != 96 {
+		t.Fatalf("unexpected on-pace row: %+v", onPace)
+	}
+	hot := hours(4)
+	hot[9], hot[10], hot[11] = 10, 10, 10
+	willCap := paceCampaign(&budget, hot, noon, 0.5)
+	if willCap.Flag != pacingWillCap || willCap.CapsAt != "15:24" {
+		t.Fatalf("expected a cap at 15:24, got %+v", willCap)
+	}
+	if row := paceCampaign(&budget, hours(1), noon, 0.5); row.Flag != pacingUnderspend {
+		t.Fatalf("expected underspend, got %+v", row)
+	}
+	if row := paceCampaign(&budget, hours(8.2), noon, 0.5); row.Flag != pacingCapped {
+		t.Fatalf("expected capped, got %+v", row)
+	}
+	if row := paceCampaign(nil, hours(1), noon, 0.5); row.Flag != pacingNoBudget {
+		t.Fatalf("expected no budget, got %+v", row)
+	}
+
+	justAfterMidnight := time.Date(2026, 10, 18, 0, 5, 0, 0, time.UTC)
+	early := make([]float64, 24)
+	early[0] = 5
+	if row := paceCampaign(&budget, early, justAfterMidnight, 0.5); row.Flag != pacingTooEarly || row.ProjectedSpend != nil || row.HourlyRate != 0 {
+		t.Fatalf("expected no projection in the first hour, got %+v", row)
+	}
+	early[0] = 99
+	if row := paceCampaign(&budget, early, justAfterMidnight, 0.5); row.Flag != pacingCapped {
+		t.Fatalf("expected capped even in the first hour, got %+v", row)
+	}
+}
+
+func TestOrgLocationComesFromConfigOrACLs(t *testing.T) {
+	t.Setenv("OE_ADS_CREDENTIALS_JSON", testCredentialsJSON(t))
+	previous := activeConfig
+	t.Cleanup(func() { activeConfig = previous })
+	activeConfig = newEffectiveConfig()
+	acls := `{"data":[{"orgId":99,"timeZone":"Europe/London"},{"orgId":123,"timeZone":"America/New_York"}]}`
+	client := appleads.NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.Host == "appleid.apple.com":
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.Path == "/api/v5/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.URL.Path == "/api/v5/acls":
+				return jsonResponse(http.StatusOK, acls), nil
+			}
+			return jsonResponse(http.StatusNotFound, `{"error":"unexpected request"}`), nil
+		}),
+	})
+
+	location, err := orgLocation(context.Background(), client)
+	if err != nil || location.String() != "America/New_York" {
+		t.Fatalf("expected the org's zone from /acls, got %v %v", location, err)
+	}
+	activeConfig.Values["orgTimeZone"] = configValue{Value: "Asia/Tokyo", Source: configSourceUser}
+	if location, err := orgLocation(context.Background(), client); err != nil || location.String() != "Asia/Tokyo" {
+		t.Fatalf("expected orgTimeZone to win, got %v %v", location, err)
+	}
+	activeConfig = newEffectiveConfig()
+	acls = `{"data":[{"orgId":99,"timeZone":"Europe/London"}]}`
+	if _, err := orgLocation(context.Background(), client); err == nil || !strings.Contains(err.Error(), "set orgTimeZone") {
+		t.Fatalf("expected a missing org to fail rather than use local time, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sync"
+)
+
+var commandOutputMu sync.Mutex
+
+// captureCommandOutput runs fn with stdout and stderr redirected so servers
+// embedding the CLI can return a command's output. Commands share the
+// process-wide streams and failure flag, so calls are serialized.
+func captureCommandOutput(fn func()) (stdout string, stderr string, failed bool, err error) {
+	commandOutputMu.Lock()
+	defer commandOutputMu.Unlock()
+
+	outReader, outWriter, err := os.Pipe()
+	if err != nil {
+		return "", "", false, err
+	}
+	errReader, errWriter, err := os.Pipe()
+	if err != nil {
+		_ = outReader.Close()
+		_ = outWriter.Close()
+		return "", "", false, err
+	}
+
+	var outBuf, errBuf bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(&outBuf, outReader)
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(&errBuf, errReader)
+	}()
+
+	originalStdout, originalStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = outWriter, errWriter
+	func() {
+		defer func() {
+			os.Stdout, os.Stderr = originalStdout, originalStderr
+			_ = outWriter.Close()
+			_ = errWriter.Close()
+		}()
+		ResetCommandFailure()
+		fn()
+	}()
+	wg.Wait()
+	_ = outReader.Close()
+	_ = errReader.Close()
+	return outBuf.String(), errBuf.String(), CommandFailed(), nil
+}
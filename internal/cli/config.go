@@ -0,0 +1,640 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"searchads-cli/internal/appleads"
+)
+
+const (
+	projectConfigName = ".searchads.yaml"
+	projectPolicyName = ".searchads-policy.yaml"
+	userConfigDir     = "searchads"
+	userConfigName    = "config.yaml"
+	userPolicyName    = "policy.yaml"
+
+	configSourceEnv     = "env"
+	configSourceProject = "project"
+	configSourceUser    = "user"
+)
+
+// configSetting is one default that .searchads.yaml, the user config or an
+// environment variable can set. Flags always win over all three. A sticky
+// setting that one layer turns on cannot be turned off by a later layer.
+type configSetting struct {
+	key         string
+	env         string
+	description string
+	validate    func(string) (string, error)
+	sticky      bool
+}
+
+var configSettings = []configSetting{
+	{key: "profile", env: "SEARCHADS_PROFILE", description: "Name for the account these settings are for; passed to plugins and recorded in the audit log", validate: validateConfigString},
+	{key: "currency", env: "SEARCHADS_CURRENCY", description: "Currency for new campaign budgets, ad group default bids and keyword bids", validate: validateConfigCurrency},
+	{key: "matchType", env: "SEARCHADS_MATCH_TYPE", description: "Match type for keywords add", validate: validateConfigMatchType},
+	{key: "countries", env: "SEARCHADS_COUNTRIES", description: "Comma-separated countries for campaigns create and sov-report", validate: validateConfigCountries},
+	{key: "outputRoot", env: "SEARCHADS_OUTPUT_ROOT", description: "Directory for sov-report and reports download output", validate: validateConfigString},
+	{key: "reportTimeZone", env: "SEARCHADS_REPORT_TIME_ZONE", description: "Default report --timeZone, UTC or ORTZ", validate: validateConfigReportTimeZone},
+	{key: "orgTimeZone", env: "SEARCHADS_ORG_TIME_ZONE", description: "IANA time zone of the org, that --last counts days in", validate: validateConfigTimeZone},
+	{key: "dateWindow", env: "SEARCHADS_DATE_WINDOW", description: "Report window when no dates are given, e.g. 14d", validate: validateConfigDateWindow},
+	{key: "auditFile", env: "SEARCHADS_AUDIT_FILE", description: "JSONL file every change is appended to", validate: validateConfigString},
+	{key: "readOnly", env: "SEARCHADS_READ_ONLY", description: "Refuse every change except report creation", validate: validateConfigBool, sticky: true},
+}
+
+// renamedConfigSettings points old setting names at their replacements.
+var renamedConfigSettings = map[string]string{
+	"timeZone": "orgTimeZone (an IANA zone) or reportTimeZone (UTC or ORTZ)",
+}
+
+type configValue struct {
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+type configAdGroupAlias struct {
+	CampaignID int    `json:"campaignId"`
+	AdGroupID  int    `json:"adGroupId"`
+	Source     string `json:"source"`
+}
+
+type configCampaignAlias struct {
+	CampaignID int    `json:"campaignId"`
+	Source     string `json:"source"`
+}
+
+type effectiveConfig struct {
+	ProjectFile        string                         `json:"projectFile,omitempty"`
+	UserFile           string                         `json:"userFile,omitempty"`
+	Values             map[string]configValue         `json:"values"`
+	CampaignAliases    map[string]configCampaignAlias `json:"campaignAliases"`
+	AdGroupAliases     map[string]configAdGroupAlias  `json:"adGroupAliases"`
+	PolicyFiles        []string                       `json:"policyFiles"`
+	ProtectedCampaigns []string                       `json:"protectedCampaigns"`
+}
+
+// activeConfig is loaded by Dispatch before each command runs.
+var activeConfig = newEffectiveConfig()
+
+func newEffectiveConfig() *effectiveConfig {
+	return &effectiveConfig{
+		Values:             map[string]configValue{},
+		CampaignAliases:    map[string]configCampaignAlias{},
+		AdGroupAliases:     map[string]configAdGroupAlias{},
+		PolicyFiles:        []string{},
+		ProtectedCampaigns: []string{},
+	}
+}
+
+// configDefault returns the configured default for key, or "".
+func configDefault(key string) string {
+	return activeConfig.Values[key].Value
+}
+
+// configReadOnly reports whether read-only mode is on.
+func configReadOnly() bool {
+	return configDefault("readOnly") == "true"
+}
+
+// configProfile names the account the current settings are for. It is a
+// label only: credentials still come from the OE_ADS_* environment.
+func configProfile() string {
+	return firstNonEmptyString(configDefault("profile"), "default")
+}
+
+// configOutputRoot is the directory report files are written under.
+func configOutputRoot() string {
+	return firstNonEmptyString(configDefault("outputRoot"), "reports")
+}
+
+// loadEffectiveConfig layers the user config, the nearest .searchads.yaml and
+// SEARCHADS_* environment variables, each overriding the one before.
+func loadEffectiveConfig() (*effectiveConfig, error) {
+	config := newEffectiveConfig()
+	if dir, err := os.UserConfigDir(); err == nil {
+		path := filepath.Join(dir, userConfigDir, userConfigName)
+		loaded, err := applyConfigFile(config, path, configSourceUser)
+		if err != nil {
+			return nil, err
+		}
+		if loaded {
+			config.UserFile = path
+		}
+		if err := applyPolicyFile(config, filepath.Join(dir, userConfigDir, userPolicyName)); err != nil {
+			return nil, err
+		}
+	}
+	if path := findUpward(projectConfigName); path != "" {
+		if _, err := applyConfigFile(config, path, configSourceProject); err != nil {
+			return nil, err
+		}
+		config.ProjectFile = path
+	}
+	if path := findUpward(projectPolicyName); path != "" {
+		if err := applyPolicyFile(config, path); err != nil {
+			return nil, err
+		}
+	}
+	for _, setting := range configSettings {
+		raw := strings.TrimSpace(os.Getenv(setting.env))
+		if raw == "" {
+			continue
+		}
+		value, err := setting.validate(raw)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %s: %w", setting.env, err)
+		}
+		config.set(setting, value, configSourceEnv)
+	}
+	return config, nil
+}
+
+func (config *effectiveConfig) set(setting configSetting, value, source string) {
+	if setting.sticky && config.Values[setting.key].Value == "true" {
+		return
+	}
+	config.Values[setting.key] = configValue{Value: value, Source: source}
+}
+
+// findUpward returns the nearest file called name in the working directory
+// or one of its parents.
+func findUpward(name string) string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func applyConfigFile(config *effectiveConfig, path, source string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	document, err := parseConfigYAML(data)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := applyConfigDocument(config, document, source); err != nil {
+		return false, fmt.Errorf("%s: %w", path, err)
+	}
+	return true, nil
+}
+
+func applyConfigDocument(config *effectiveConfig, document map[string]any, source string) error {
+	settings := make(map[string]configSetting, len(configSettings))
+	for _, setting := range configSettings {
+		settings[setting.key] = setting
+	}
+	for key, raw := range document {
+		if key == "aliases" {
+			if err := applyConfigAliases(config, raw, source); err != nil {
+				return err
+			}
+			continue
+		}
+		setting, ok := settings[key]
+		if !ok {
+			if renamed, found := renamedConfigSettings[key]; found {
+				return fmt.Errorf("unknown setting %q; it is now %s", key, renamed)
+			}
+			return fmt.Errorf("unknown setting %q", key)
+		}
+		var text string
+		switch typed := raw.(type) {
+		case string:
+			text = typed
+		case []any:
+			parts := make([]string, 0, len(typed))
+			for _, item := range typed {
+				item, ok := item.(string)
+				if !ok {
+					return fmt.Errorf("%s must be a list of values", key)
+				}
+				parts = append(parts, item)
+			}
+			text = strings.Join(parts, ",")
+		default:
+			return fmt.Errorf("%s must be a value, not a map", key)
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		value, err := setting.validate(text)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", key, err)
+		}
+		config.set(setting, value, source)
+	}
+	return nil
+}
+
+// applyConfigAliases reads
+//
+//	aliases:
+//	  campaigns:
+//	    brand-uk: 123456
+//	  adGroups:
+//	    brand-exact: 123456/789012
+func applyConfigAliases(config *effectiveConfig, raw any, source string) error {
+	sections, ok := raw.(map[string]any)
+	if !ok {
+		return errors.New("aliases must be a map with campaigns and adGroups")
+	}
+	for section, entries := range sections {
+		names, ok := entries.(map[string]any)
+		if !ok {
+			return fmt.Errorf("aliases.%s must be a map of name: id", section)
+		}
+		for name, value := range names {
+			text, _ := value.(string)
+			key := strings.ToLower(strings.TrimSpace(name))
+			switch section {
+			case "campaigns":
+				id, err := strconv.Atoi(strings.TrimSpace(text))
+				if err != nil || id <= 0 {
+					return fmt.Errorf("aliases.campaigns.%s must be a campaign ID", name)
+				}
+				config.CampaignAliases[key] = configCampaignAlias{CampaignID: id, Source: source}
+			case "adGroups":
+				campaignPart, adGroupPart, found := strings.Cut(strings.TrimSpace(text), "/")
+				campaignID, campaignErr := strconv.Atoi(strings.TrimSpace(campaignPart))
+				adGroupID, adGroupErr := strconv.Atoi(strings.TrimSpace(adGroupPart))
+				if !found || campaignErr != nil || adGroupErr != nil || campaignID <= 0 || adGroupID <= 0 {
+					return fmt.Errorf("aliases.adGroups.%s must be <campaignId>/<adGroupId>", name)
+				}
+				config.AdGroupAliases[key] = configAdGroupAlias{CampaignID: campaignID, AdGroupID: adGroupID, Source: source}
+			default:
+				return fmt.Errorf("unknown alias section %q (use campaigns or adGroups)", section)
+			}
+		}
+	}
+	return nil
+}
+
+// applyPolicyFile adds the campaigns a policy file protects:
+//
+//	protectedCampaigns:
+//	  - 123456
+//	  - Brand *
+//
+// Numbers are campaign IDs; anything else is a name where * matches any run
+// of characters. Protection from every policy file found adds up.
+func applyPolicyFile(config *effectiveConfig, path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	document, err := parseConfigYAML(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for key, raw := range document {
+		if key != "protectedCampaigns" {
+			return fmt.Errorf("%s: unknown policy %q", path, key)
+		}
+		entries, ok := raw.([]any)
+		if !ok {
+			return fmt.Errorf("%s: protectedCampaigns must be a list of campaign IDs or names", path)
+		}
+		for _, entry := range entries {
+			text, _ := entry.(string)
+			if text = strings.TrimSpace(text); text != "" {
+				config.ProtectedCampaigns = append(config.ProtectedCampaigns, text)
+			}
+		}
+	}
+	config.PolicyFiles = append(config.PolicyFiles, path)
+	return nil
+}
+
+// campaignProtection splits the protected entries into IDs and names.
+func (config *effectiveConfig) campaignProtection() appleads.CampaignProtection {
+	var protection appleads.CampaignProtection
+	for _, entry := range config.ProtectedCampaigns {
+		if id, err := strconv.Atoi(entry); err == nil && id > 0 {
+			protection.IDs = append(protection.IDs, id)
+			continue
+		}
+		protection.Names = append(protection.Names, entry)
+	}
+	return protection
+}
+
+func validateConfigString(value string) (string, error) {
+	return strings.TrimSpace(value), nil
+}
+
+func validateConfigBool(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "1", "true", "yes", "on":
+		return "true", nil
+	case "0", "false", "no", "off":
+		return "false", nil
+	}
+	return "", fmt.Errorf("%q is not true or false", value)
+}
+
+func validateConfigCurrency(value string) (string, error) {
+	currency := strings.ToUpper(strings.TrimSpace(value))
+	if len(currency) != 3 {
+		return "", fmt.Errorf("%q is not a 3-letter currency code", value)
+	}
+	return currency, nil
+}
+
+func validateConfigMatchType(value string) (string, error) {
+	matchType := strings.ToUpper(strings.TrimSpace(value))
+	if matchType != "EXACT" && matchType != "BROAD" {
+		return "", fmt.Errorf("%q is not EXACT or BROAD", value)
+	}
+	return matchType, nil
+}
+
+func validateConfigCountries(value string) (string, error) {
+	countries := make([]string, 0, 4)
+	for _, raw := range strings.Split(value, ",") {
+		country := strings.ToUpper(strings.TrimSpace(raw))
+		if country == "" {
+			continue
+		}
+		if len(country) != 2 {
+			return "", fmt.Errorf("%q is not a 2-letter country code", raw)
+		}
+		countries = append(countries, country)
+	}
+	return strings.Join(countries, ","), nil
+}
+
+func validateConfigTimeZone(value string) (string, error) {
+	name := strings.TrimSpace(value)
+	if _, err := time.LoadLocation(name); err != nil {
+		return "", fmt.Errorf("unknown time zone %q", value)
+	}
+	return name, nil
+}
+
+func validateConfigReportTimeZone(value string) (string, error) {
+	name := strings.ToUpper(strings.TrimSpace(value))
+	if !contains(appleads.ReportTimeZones(), name) {
+		return "", fmt.Errorf("report time zone %q must be one of %s", value, strings.Join(appleads.ReportTimeZones(), ", "))
+	}
+	return name, nil
+}
+
+func validateConfigDateWindow(value string) (string, error) {
+	if _, err := parseDateWindow(value); err != nil {
+		return "", err
+	}
+	return strings.ToLower(strings.TrimSpace(value)), nil
+}
+
+// RunConfig prints the effective configuration and where each value came from.
+func RunConfig(args []string, jsonOut bool) {
+	action := actionFromArgs(args, "show")
+	if action != "show" {
+		respondCommandError("config", jsonOut, fmt.Errorf("Unknown config action: %s. Use: show", action))
+		return
+	}
+	config := activeConfig
+	if jsonOut {
+		printJSON(map[string]any{"ok": true, "projectFile": config.ProjectFile, "userFile": config.UserFile, "values": config.Values, "campaignAliases": config.CampaignAliases, "adGroupAliases": config.AdGroupAliases, "policyFiles": config.PolicyFiles, "protectedCampaigns": config.ProtectedCampaigns})
+		return
+	}
+	fmt.Printf("projectFile=%s\n", firstNonEmptyString(config.ProjectFile, "-"))
+	fmt.Printf("userFile=%s\n", firstNonEmptyString(config.UserFile, "-"))
+	for _, setting := range configSettings {
+		value, ok := config.Values[setting.key]
+		if !ok {
+			fmt.Printf("%s=-\tsource=default\n", setting.key)
+			continue
+		}
+		fmt.Printf("%s=%s\tsource=%s\n", setting.key, value.Value, value.Source)
+	}
+	for _, name := range sortedKeys(config.CampaignAliases) {
+		alias := config.CampaignAliases[name]
+		fmt.Printf("campaign %s=%d\tsource=%s\n", name, alias.CampaignID, alias.Source)
+	}
+	for _, name := range sortedKeys(config.AdGroupAliases) {
+		alias := config.AdGroupAliases[name]
+		fmt.Printf("adGroup %s=%d/%d\tsource=%s\n", name, alias.CampaignID, alias.AdGroupID, alias.Source)
+	}
+	for _, path := range config.PolicyFiles {
+		fmt.Printf("policyFile=%s\n", path)
+	}
+	for _, entry := range config.ProtectedCampaigns {
+		fmt.Printf("protected campaign %s\n", entry)
+	}
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// parseConfigYAML reads the YAML subset config files need: nested maps,
+// block lists ("- item"), flow lists ("[a, b]"), quoted or plain scalars and
+// # comments. Scalars stay strings.
+func parseConfigYAML(data []byte) (map[string]any, error) {
+	lines := make([]yamlLine, 0, 16)
+	for idx, raw := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		content := stripYAMLComment(raw)
+		trimmed := strings.TrimSpace(content)
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+		indent := len(content) - len(strings.TrimLeft(content, " "))
+		if strings.HasPrefix(content[indent:], "\t") {
+			return nil, fmt.Errorf("line %d: indent with spaces, not tabs", idx+1)
+		}
+		lines = append(lines, yamlLine{number: idx + 1, indent: indent, text: strings.TrimRight(content[indent:], " \t")})
+	}
+	if len(lines) == 0 {
+		return map[string]any{}, nil
+	}
+	parser := yamlParser{lines: lines}
+	value, err := parser.block(lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos < len(lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", lines[parser.pos].number)
+	}
+	document, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.New("config must be a map of settings")
+	}
+	return document, nil
+}
+
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func (p *yamlParser) block(indent int) (any, error) {
+	if strings.HasPrefix(p.lines[p.pos].text, "-") {
+		return p.list(indent)
+	}
+	return p.mapping(indent)
+}
+
+func (p *yamlParser) mapping(indent int) (any, error) {
+	result := map[string]any{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent {
+		line := p.lines[p.pos]
+		key, rest, found := strings.Cut(line.text, ":")
+		key = strings.TrimSpace(key)
+		if !found || key == "" || strings.HasPrefix(key, "-") {
+			return nil, fmt.Errorf("line %d: expected key: value", line.number)
+		}
+		if unquoted, ok := unquoteYAML(key); ok {
+			key = unquoted
+		}
+		if _, exists := result[key]; exists {
+			return nil, fmt.Errorf("line %d: duplicate key %q", line.number, key)
+		}
+		p.pos++
+		rest = strings.TrimSpace(rest)
+		if rest != "" {
+			value, err := parseYAMLScalar(rest, line.number)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = value
+			continue
+		}
+		if p.pos < len(p.lines) && (p.lines[p.pos].indent > indent || (p.lines[p.pos].indent == indent && strings.HasPrefix(p.lines[p.pos].text, "-"))) {
+			value, err := p.block(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = value
+			continue
+		}
+		result[key] = ""
+	}
+	return result, nil
+}
+
+func (p *yamlParser) list(indent int) (any, error) {
+	result := make([]any, 0, 4)
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && strings.HasPrefix(p.lines[p.pos].text, "-") {
+		line := p.lines[p.pos]
+		item := strings.TrimSpace(strings.TrimPrefix(line.text, "-"))
+		p.pos++
+		if item == "" {
+			if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+				value, err := p.block(p.lines[p.pos].indent)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, value)
+				continue
+			}
+			result = append(result, "")
+			continue
+		}
+		value, err := parseYAMLScalar(item, line.number)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+func parseYAMLScalar(text string, lineNumber int) (any, error) {
+	if strings.HasPrefix(text, "[") {
+		if !strings.HasSuffix(text, "]") {
+			return nil, fmt.Errorf("line %d: unterminated list", lineNumber)
+		}
+		inner := strings.TrimSpace(text[1 : len(text)-1])
+		items := make([]any, 0, 4)
+		if inner == "" {
+			return items, nil
+		}
+		for _, part := range strings.Split(inner, ",") {
+			part = strings.TrimSpace(part)
+			if unquoted, ok := unquoteYAML(part); ok {
+				part = unquoted
+			}
+			items = append(items, part)
+		}
+		return items, nil
+	}
+	if strings.HasPrefix(text, "{") {
+		return nil, fmt.Errorf("line %d: inline maps are not supported; use indented keys", lineNumber)
+	}
+	if unquoted, ok := unquoteYAML(text); ok {
+		return unquoted, nil
+	}
+	if strings.HasPrefix(text, `"`) || strings.HasPrefix(text, "'") {
+		return nil, fmt.Errorf("line %d: unterminated string", lineNumber)
+	}
+	return text, nil
+}
+
+func unquoteYAML(text string) (string, bool) {
+	if len(text) >= 2 && strings.HasPrefix(text, "'") && strings.HasSuffix(text, "'") {
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), true
+	}
+	if len(text) >= 2 && strings.HasPrefix(text, `"`) && strings.HasSuffix(text, `"`) {
+		if unquoted, err := strconv.Unquote(text); err == nil {
+			return unquoted, true
+		}
+	}
+	return "", false
+}
+
+// stripYAMLComment drops a # comment that starts the line or follows
+// whitespace, outside quotes.
+func stripYAMLComment(line string) string {
+	var quote rune
+	for idx, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (idx == 0 || line[idx-1] == ' ' || line[idx-1] == '\t'):
+			return line[:idx]
+		}
+	}
+	return line
+}
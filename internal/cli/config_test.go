@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseConfigYAMLSubset(t *testing.T) {
+	t.Parallel()
+
+	document, err := parseConfigYAML([]byte(`# team defaults
+currency: usd   # trailing comment
+countries: [US, "GB"]
+outputRoot: 'exports/#team'
+aliases:
+  campaigns:
+    brand-uk: 123
+  adGroups:
+    "brand exact": 123/456
+listed:
+  - a
+  - b
+`))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	want := map[string]any{
+		"currency":   "usd",
+		"countries":  []any{"US", "GB"},
+		"outputRoot": "exports/#team",
+		"aliases": map[string]any{
+			"campaigns": map[string]any{"brand-uk": "123"},
+			"adGroups":  map[string]any{"brand exact": "123/456"},
+		},
+		"listed": []any{"a", "b"},
+	}
+	if !reflect.DeepEqual(document, want) {
+		t.Fatalf("unexpected document:\n got %#v\nwant %#v", document, want)
+	}
+
+	for _, invalid := range []string{"currency: USD\ncurrency: GBP\n", "a:\n\tb: 1\n", "a: [1, 2\n", "a: {b: 1}\n", "a: 1\n    b: 2\n"} {
+		if _, err := parseConfigYAML([]byte(invalid)); err == nil {
+			t.Fatalf("expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestLoadEffectiveConfigPrecedence(t *testing.T) {
+	userDir := t.TempDir()
+	projectDir := t.TempDir()
+	nested := filepath.Join(projectDir, "nested")
+	if err := os.MkdirAll(filepath.Join(userDir, userConfigDir), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(nested, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(userDir, userConfigDir, userConfigName), "currency: GBP\nmatchType: BROAD\ncountries:\n  - gb\n  - ie\naliases:\n  campaigns:\n    brand: 1\n")
+	writeTestFile(t, filepath.Join(projectDir, projectConfigName), "currency: USD\nreportTimeZone: utc\naliases:\n  campaigns:\n    Brand: 2\n  adGroups:\n    exact: 2/3\n")
+	t.Setenv("XDG_CONFIG_HOME", userDir)
+	t.Setenv("HOME", userDir)
+	t.Setenv("SEARCHADS_MATCH_TYPE", "exact")
+	t.Chdir(nested)
+
+	config, err := loadEffectiveConfig()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	want := map[string]configValue{
+		"currency":       {Value: "USD", Source: configSourceProject},
+		"matchType":      {Value: "EXACT", Source: configSourceEnv},
+		"countries":      {Value: "GB,IE", Source: configSourceUser},
+		"reportTimeZone": {Value: "UTC", Source: configSourceProject},
+	}
+	if !reflect.DeepEqual(config.Values, want) {
+		t.Fatalf("unexpected values: %#v", config.Values)
+	}
+	if config.CampaignAliases["brand"].CampaignID != 2 || config.AdGroupAliases["exact"].AdGroupID != 3 {
+		t.Fatalf("expected project aliases to override user aliases: %#v %#v", config.CampaignAliases, config.AdGroupAliases)
+	}
+	if !strings.HasSuffix(config.ProjectFile, filepath.Join(filepath.Base(projectDir), projectConfigName)) {
+		t.Fatalf("expected project file to be found from a subdirectory, got %q", config.ProjectFile)
+	}
+
+	writeTestFile(t, filepath.Join(projectDir, projectConfigName), "curency: USD\n")
+	if _, err := loadEffectiveConfig(); err == nil || !strings.Contains(err.Error(), `unknown setting "curency"`) {
+		t.Fatalf("expected unknown setting error, got %v", err)
+	}
+	writeTestFile(t, filepath.Join(projectDir, projectConfigName), "timeZone: America/New_York\n")
+	if _, err := loadEffectiveConfig(); err == nil || !strings.Contains(err.Error(), "orgTimeZone") {
+		t.Fatalf("expected the old timeZone setting to point at its replacement, got %v", err)
+	}
+}
+
+func TestReadOnlyIsStickyAndPolicyFilesAddUp(t *testing.T) {
+	userDir := t.TempDir()
+	projectDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(userDir, userConfigDir), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(userDir, userConfigDir, userConfigName), "readOnly: true\n")
+	writeTestFile(t, filepath.Join(userDir, userConfigDir, userPolicyName), "protectedCampaigns:\n  - 123\n")
+	writeTestFile(t, filepath.Join(projectDir, projectConfigName), "readOnly: false\n")
+	writeTestFile(t, filepath.Join(projectDir, projectPolicyName), "protectedCampaigns: [Brand *, 456]\n")
+	t.Setenv("XDG_CONFIG_HOME", userDir)
+	t.Setenv("HOME", userDir)
+	t.Setenv("SEARCHADS_READ_ONLY", "0")
+	t.Chdir(projectDir)
+
+	config, err := loadEffectiveConfig()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if value := config.Values["readOnly"]; value.Value != "true" || value.Source != configSourceUser {
+		t.Fatalf("expected read-only from the user config to stay on, got %#v", value)
+	}
+	protection := config.campaignProtection()
+	if !reflect.DeepEqual(protection.IDs, []int{123, 456}) || !reflect.DeepEqual(protection.Names, []string{"Brand *"}) {
+		t.Fatalf("unexpected protection: %#v", protection)
+	}
+	if len(config.PolicyFiles) != 2 {
+		t.Fatalf("expected both policy files, got %q", config.PolicyFiles)
+	}
+
+	writeTestFile(t, filepath.Join(projectDir, projectPolicyName), "protected: [1]\n")
+	if _, err := loadEffectiveConfig(); err == nil || !strings.Contains(err.Error(), `unknown policy "protected"`) {
+		t.Fatalf("expected unknown policy error, got %v", err)
+	}
+}
+
+func TestReportDateRangeUsesLastAndConfiguredWindow(t *testing.T) {
+	previous := activeConfig
+	t.Cleanup(func() { activeConfig = previous })
+	activeConfig = newEffectiveConfig()
+	activeConfig.Values["orgTimeZone"] = configValue{Value: "UTC", Source: configSourceUser}
+
+	today := time.Now().UTC().Format("2006-01-02")
+	start, end, err := reportDateRange([]string{"--last", "14d"})
+	if err != nil || end.Format("2006-01-02") != today || end.Sub(start) != 13*24*time.Hour {
+		t.Fatalf("expected a 14 day window ending today, got %s..%s err=%v", start, end, err)
+	}
+	if _, _, err := reportDateRange(nil); err == nil {
+		t.Fatal("expected missing dates to fail without a configured window")
+	}
+	activeConfig.Values["dateWindow"] = configValue{Value: "2w", Source: configSourceProject}
+	start, end, err = reportDateRange(nil)
+	if err != nil || end.Sub(start) != 13*24*time.Hour {
+		t.Fatalf("expected the configured 2w window, got %s..%s err=%v", start, end, err)
+	}
+	start, end, err = reportDateRange([]string{"--startDate", "2026-01-01", "--endDate", "2026-01-31"})
+	if err != nil || start.Format("2006-01-02") != "2026-01-01" || end.Format("2006-01-02") != "2026-01-31" {
+		t.Fatalf("expected explicit dates to win, got %s..%s err=%v", start, end, err)
+	}
+	for _, args := range [][]string{{"--last", "14d", "--startDate", "2026-01-01"}, {"--last", "soon"}, {"--startDate", "2026-02-01", "--endDate", "2026-01-01"}} {
+		if _, _, err := reportDateRange(args); err == nil {
+			t.Fatalf("expected %v to fail", args)
+		}
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"searchads-cli/internal/appleads"
+)
+
+// Dispatch runs one command; args[0] is the command name. Unknown commands
+// run a searchads-<name> plugin from PATH; when there is none it returns false
+// so the caller can print usage. Flags are checked against the action's
+// schema before it runs.
+func Dispatch(ctx context.Context, client *appleads.Client, args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	commandArgs := args[1:]
+	jsonOut := hasFlag(args, "--json")
+	config, err := loadEffectiveConfig()
+	if err != nil {
+		respondCommandError(strings.ToLower(args[0]), jsonOut, err)
+		return true
+	}
+	activeConfig = config
+	if err := checkActionArgs(args); err != nil {
+		respondCommandError(strings.ToLower(args[0]), jsonOut, err)
+		return true
+	}
+	client.SetReadOnly(configReadOnly())
+	client.SetCampaignProtection(config.campaignProtection())
+	audit := startCommandAudit(client, args)
+	defer audit.finish()
+	switch strings.ToLower(args[0]) {
+	case "status":
+		RunStatus(ctx)
+	case "campaigns":
+		RunCampaigns(ctx, client, commandArgs, jsonOut)
+	case "adgroups":
+		RunAdGroups(ctx, client, commandArgs, jsonOut)
+	case "ads":
+		RunAds(ctx, client, commandArgs, jsonOut)
+	case "creatives":
+		RunCreatives(ctx, client, commandArgs, jsonOut)
+	case "product-pages":
+		RunProductPages(ctx, client, commandArgs, jsonOut)
+	case "apps":
+		RunApps(ctx, client, commandArgs, jsonOut)
+	case "geo":
+		RunGeo(ctx, client, commandArgs, jsonOut)
+	case "ad-rejections":
+		RunAdRejections(ctx, client, commandArgs, jsonOut)
+	case "budget-orders":
+		RunBudgetOrders(ctx, client, commandArgs, jsonOut)
+	case "keywords":
+		RunKeywords(ctx, client, commandArgs, jsonOut)
+	case "searchterms":
+		RunSearchTerms(ctx, client, commandArgs, jsonOut)
+	case "negatives":
+		RunNegatives(ctx, client, commandArgs, jsonOut)
+	case "sov-report":
+		RunSovReport(ctx, client, commandArgs)
+	case "reports":
+		RunReports(ctx, client, commandArgs, jsonOut)
+	case "api":
+		RunAPI(ctx, client, commandArgs, jsonOut)
+	case "audit":
+		RunAudit(commandArgs, jsonOut)
+	case "undo":
+		RunUndo(ctx, client, commandArgs, jsonOut)
+	case "config":
+		RunConfig(commandArgs, jsonOut)
+	case "schema":
+		RunSchema(commandArgs, jsonOut)
+	case "shell":
+		RunShell(ctx, client)
+	case "serve":
+		RunServe(ctx, client, commandArgs, jsonOut)
+	case "mcp":
+		RunMCP(ctx, client, commandArgs, os.Stdin, os.Stdout)
+	default:
+		return runPlugin(ctx, client, args[0], commandArgs)
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fxRates converts spend into one report currency. A rate is how many units
+// of the report currency one unit of the other currency buys. Dated rates
+// win over undated ones for their day.
+type fxRates struct {
+	target string
+	byDay  map[string]map[string]float64
+	flat   map[string]float64
+}
+
+// loadFXRates reads a CSV of "currency,rate" or "date,currency,rate" rows.
+// A header row and blank lines are skipped.
+func loadFXRates(path, target string) (*fxRates, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return parseFXRates(file, target, path)
+}
+
+func parseFXRates(source io.Reader, target, name string) (*fxRates, error) {
+	rates := &fxRates{target: strings.ToUpper(strings.TrimSpace(target)), byDay: map[string]map[string]float64{}, flat: map[string]float64{}}
+	reader := csv.NewReader(source)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		var day, currency, rawRate string
+		switch len(record) {
+		case 2:
+			currency, rawRate = record[0], record[1]
+		case 3:
+			day, currency, rawRate = record[0], record[1], record[2]
+		default:
+			return nil, fmt.Errorf("%s line %d: want currency,rate or date,currency,rate", name, line)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rawRate), 64)
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("%s line %d: invalid rate %q", name, line, rawRate)
+		}
+		if rate <= 0 {
+			return nil, fmt.Errorf("%s line %d: rate must be positive", name, line)
+		}
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		day = strings.TrimSpace(day)
+		if day == "" {
+			rates.flat[currency] = rate
+			continue
+		}
+		if _, err := parseDate(day); err != nil {
+			return nil, fmt.Errorf("%s line %d: invalid date %q", name, line, day)
+		}
+		if rates.byDay[day] == nil {
+			rates.byDay[day] = map[string]float64{}
+		}
+		rates.byDay[day][currency] = rate
+	}
+	return rates, nil
+}
+
+// rate is the factor for currency on day; the report currency is always 1.
+func (r *fxRates) rate(day, currency string) (float64, bool) {
+	currency = strings.ToUpper(currency)
+	if currency == r.target {
+		return 1, true
+	}
+	if rate, ok := r.byDay[day][currency]; ok {
+		return rate, true
+	}
+	rate, ok := r.flat[currency]
+	return rate, ok
+}
+
+// missingFXRates formats the currency/day pairs a report needed but the table
+// lacked.
+func missingFXRates(missing map[string]struct{}) error {
+	pairs := make([]string, 0, len(missing))
+	for pair := range missing {
+		pairs = append(pairs, pair)
+	}
+	sort.Strings(pairs)
+	return fmt.Errorf("No FX rate for %s", strings.Join(pairs, ", "))
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"searchads-cli/internal/appleads"
+)
+
+func TestParseFXRates(t *testing.T) {
+	source := "date,currency,rate\n2026-03-01,eur,1.10\n,EUR,1.05\nGBP,1.25\n"
+	rates, err := parseFXRates(strings.NewReader(source), "usd", "rates.csv")
+	if err != nil {
+		t.Fatalf("parseFXRates: %v", err)
+	}
+	cases := []struct {
+		day, currency string
+		want          float64
+		ok            bool
+	}{
+		{"2026-03-01", "EUR", 1.10, true},
+		{"2026-03-02", "EUR", 1.05, true},
+		{"2026-03-02", "gbp", 1.25, true},
+		{"2026-03-02", "USD", 1, true},
+		{"2026-03-02", "JPY", 0, false},
+	}
+	for _, tc := range cases {
+		got, ok := rates.rate(tc.day, tc.currency)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("rate(%s, %s) = %v, %v; want %v, %v", tc.day, tc.currency, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestParseFXRatesRejectsBadRows(t *testing.T) {
+	for _, source := range []string{
+		"EUR,1.1\nGBP,abc\n",
+		"EUR,-1\n",
+		"03/01/2026,EUR,1.1\n",
+		"EUR\n",
+	} {
+		if _, err := parseFXRates(strings.NewReader(source), "USD", "rates.csv"); err == nil {
+			t.Errorf("parseFXRates(%q) succeeded; want an error", source)
+		}
+	}
+}
+
+func TestCampaignReportRejectsDatedRatesForWeeklyGranularity(t *testing.T) {
+	t.Setenv("OE_ADS_CREDENTIALS_JSON", testCredentialsJSON(t))
+	path := filepath.Join(t.TempDir(), "rates.csv")
+	if err := os.WriteFile(path, []byte("date,currency,rate\n2026-03-02,EUR,1.10\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	client := appleads.NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.Host == "appleid.apple.com":
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.Path == "/api/v5/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.URL.Path == "/api/v5/campaigns":
+				return jsonResponse(http.StatusOK, `{"data":[],"pagination":{"totalResults":0}}`), nil
+			}
+			t.Fatalf("unexpected request %s", req.URL)
+			return nil, nil
+		}),
+	})
+
+	for _, granularity := range []string{"WEEKLY", "MONTHLY"} {
+		stdout, _, failed, err := captureCommandOutput(func() {
+			RunCampaigns(context.Background(), client, []string{"report", "--startDate", "2026-03-04", "--endDate", "2026-06-30", "--granularity", granularity, "--reportCurrency", "USD", "--fxRates", path, "--json"}, true)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !failed || !strings.Contains(stdout, "dated rates") {
+			t.Fatalf("%s: expected dated rates to be refused, got %s", granularity, stdout)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"context"
 
@@ -25,6 +26,19 @@ func RunKeywords(ctx context.Context, client *appleads.Client, args []string, js
 		respondCommandError("keywords", jsonOut, err)
 		return
 	}
+	args, err := resolveEntityFlags(ctx, client, args)
+	if err != nil {
+		respondCommandError("keywords", jsonOut, err)
+		return
+	}
+	action := actionFromArgs(args, "list")
+	if isBulkInvocation(args) {
+		switch action {
+		case "pause", "activate", "remove", "delete", "rebid":
+			runKeywordsBulk(ctx, client, args, action, jsonOut)
+			return
+		}
+	}
 	campaignID, err := requiredIntFlag(args, "--campaignId")
 	if err != nil {
 		respondCommandError("keywords", jsonOut, err)
@@ -36,7 +50,6 @@ func RunKeywords(ctx context.Context, client *appleads.Client, args []string, js
 		return
 	}
 
-	action := actionFromArgs(args, "list")
 	switch action {
 	case "list":
 		runKeywordsList(ctx, client, args, jsonOut, campaignID, adGroupID, false)
@@ -51,7 +64,7 @@ func RunKeywords(ctx context.Context, client *appleads.Client, args []string, js
 			return
 		}
 		if len(inputs) == 0 {
-			respondCommandError("keywords", jsonOut, fmt.Errorf("No keywords provided. Use --text <kw> (repeatable) or --file <path>"))
+			respondCommandError("keywords", jsonOut, fmt.Errorf("No keywords provided. Use --text <kw> (repeatable), --file <path> or --retry <resultFile>"))
 			return
 		}
 		existingKeywords, err := client.FetchKeywords(ctx, campaignID, adGroupID)
@@ -59,108 +72,234 @@ func RunKeywords(ctx context.Context, client *appleads.Client, args []string, js
 			respondCommandError("keywords", jsonOut, err)
 			return
 		}
-		for _, input := range inputs {
-			if target := selectKeywordMutationTarget(existingKeywords, input.text, input.matchType); target != nil {
-				if err := client.UpdateKeyword(ctx, campaignID, adGroupID, target.ID, input.matchType, input.status, input.bidAmount, input.currency); err != nil {
-					respondCommandError("keywords", jsonOut, err)
-					return
-				}
-				continue
-			}
-			if err := client.AddKeyword(ctx, campaignID, adGroupID, input.text, input.matchType, input.bidAmount, input.currency, input.status); err != nil {
-				respondCommandError("keywords", jsonOut, err)
-				return
-			}
-		}
-		respondKeywordsMutation(jsonOut, "add", len(inputs), campaignID, adGroupID)
+		results := applyKeywordAdds(ctx, client, campaignID, adGroupID, existingKeywords, inputs, parseBulkOptions(args).continueOnError)
+		respondBulkResults("keywords", "add", args, jsonOut, results)
 	case "pause", "activate", "remove", "delete", "rebid":
 		keywords, err := client.FetchKeywords(ctx, campaignID, adGroupID)
 		if err != nil {
 			respondCommandError("keywords", jsonOut, err)
 			return
 		}
-		keywordByID := make(map[int]appleads.KeywordSummary, len(keywords))
-		for _, keyword := range keywords {
-			keywordByID[keyword.ID] = keyword
-		}
 		targetIDs, err := resolveKeywordTargets(args, keywords)
 		if err != nil {
 			respondCommandError("keywords", jsonOut, err)
 			return
 		}
-		if len(targetIDs) == 0 {
+		unmatched := unmatchedKeywordTexts(args, keywords)
+		if len(targetIDs) == 0 && len(unmatched) == 0 {
 			respondCommandError("keywords", jsonOut, fmt.Errorf("No matching keywords found for --keywordId/--text"))
 			return
 		}
-		if action == "remove" || action == "delete" {
-			for _, keywordID := range targetIDs {
-				if err := client.DeleteKeyword(ctx, campaignID, adGroupID, keywordID); err != nil {
-					respondCommandError("keywords", jsonOut, err)
-					return
-				}
-			}
-			respondKeywordsMutation(jsonOut, "remove", len(targetIDs), campaignID, adGroupID)
-			return
+		targets := make([]bulkTarget, 0, len(targetIDs))
+		for _, keywordID := range targetIDs {
+			targets = append(targets, bulkTarget{ID: keywordID, CampaignID: campaignID, AdGroupID: adGroupID})
 		}
-		if action == "rebid" {
-			bidAmountRaw := strings.TrimSpace(valueForFlag(args, "--bidAmount"))
-			if bidAmountRaw == "" {
-				respondCommandError("keywords", jsonOut, fmt.Errorf("rebid requires --bidAmount <number>"))
-				return
-			}
-			var bidAmount float64
-			if _, err := fmt.Sscanf(bidAmountRaw, "%f", &bidAmount); err != nil || bidAmount <= 0 {
-				respondCommandError("keywords", jsonOut, fmt.Errorf("rebid requires --bidAmount <number>"))
-				return
+		skipped := make([]bulkItemResult, 0, len(unmatched))
+		for _, text := range unmatched {
+			skipped = append(skipped, bulkItemResult{CampaignID: campaignID, AdGroupID: adGroupID, Outcome: "skipped", Text: text, Reason: "no keyword with this text in the ad group"})
+		}
+		runKeywordsMutation(ctx, client, args, action, jsonOut, targets, skipped, keywords)
+	case "pause-by-text":
+		forwarded := append([]string{}, args...)
+		if len(forwarded) > 0 {
+			forwarded[0] = "pause"
+		}
+		RunKeywords(ctx, client, forwarded, jsonOut)
+	default:
+		respondCommandError("keywords", jsonOut, fmt.Errorf("Unknown keywords action: %s", action))
+	}
+}
+
+// applyKeywordAdds creates new keywords and updates ones that already exist
+// (same text and match type) using bulk requests of appleads.KeywordBatchSize
+// rows. Repeats of an earlier row's text and match type are skipped rather than
+// sent twice. Without continueOnError, batches after the first failing one are
+// skipped.
+func applyKeywordAdds(ctx context.Context, client *appleads.Client, campaignID, adGroupID int, existing []appleads.KeywordSummary, inputs []keywordInput, continueOnError bool) []bulkItemResult {
+	results := make([]bulkItemResult, len(inputs))
+	for idx, input := range inputs {
+		results[idx] = bulkItemResult{CampaignID: campaignID, AdGroupID: adGroupID, Text: input.text, MatchType: input.matchType, Status: input.status, BidAmount: input.bidAmount}
+		if input.currency != nil {
+			results[idx].Currency = *input.currency
+		}
+	}
+
+	type keywordKey struct{ text, matchType string }
+	seen := make(map[keywordKey]struct{}, len(inputs))
+	pending := make([]int, 0, len(inputs))
+	for idx, input := range inputs {
+		key := keywordKey{text: strings.ToLower(strings.TrimSpace(input.text)), matchType: strings.ToUpper(strings.TrimSpace(input.matchType))}
+		if _, ok := seen[key]; ok {
+			results[idx].Outcome = "skipped"
+			results[idx].Reason = "duplicate of an earlier keyword with the same text and match type"
+			continue
+		}
+		seen[key] = struct{}{}
+		pending = append(pending, idx)
+	}
+
+	stopped := false
+	for start := 0; start < len(pending); start += appleads.KeywordBatchSize {
+		end := start + appleads.KeywordBatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		if stopped {
+			for _, idx := range pending[start:end] {
+				results[idx].Outcome = "skipped"
+				results[idx].Reason = "not attempted after an earlier failure"
 			}
-			var currency *string
-			if c := strings.TrimSpace(valueForFlag(args, "--currency")); c != "" {
-				currency = &c
+			continue
+		}
+
+		var adds, updates []appleads.KeywordWrite
+		var addRows, updateRows []int
+		for _, idx := range pending[start:end] {
+			input := inputs[idx]
+			write := appleads.KeywordWrite{Text: input.text, MatchType: input.matchType, Status: input.status, BidAmount: input.bidAmount, Currency: input.currency}
+			if target := selectKeywordMutationTarget(existing, input.text, input.matchType); target != nil {
+				write.ID = target.ID
+				updates = append(updates, write)
+				updateRows = append(updateRows, idx)
+				continue
 			}
-			for _, keywordID := range targetIDs {
-				if keyword, ok := keywordByID[keywordID]; ok {
-					if err := client.UpdateKeyword(ctx, campaignID, adGroupID, keywordID, keyword.MatchType, "", &bidAmount, currency); err != nil {
-						respondCommandError("keywords", jsonOut, err)
-						return
-					}
-					continue
+			adds = append(adds, write)
+			addRows = append(addRows, idx)
+		}
+
+		failed := false
+		record := func(rows []int, writes []appleads.KeywordWriteResult, err error) {
+			for pos, idx := range rows {
+				outcome := appleads.KeywordWriteResult{Err: err}
+				if err == nil {
+					outcome = writes[pos]
 				}
-				if err := client.UpdateKeyword(ctx, campaignID, adGroupID, keywordID, "", "", &bidAmount, currency); err != nil {
-					respondCommandError("keywords", jsonOut, err)
-					return
+				if outcome.ID > 0 {
+					results[idx].ID = outcome.ID
 				}
+				if outcome.Err != nil {
+					results[idx].Outcome = "error"
+					results[idx].Error = outcome.Err.Error()
+					failed = true
+					continue
+				}
+				results[idx].Outcome = "ok"
 			}
-			respondKeywordsMutation(jsonOut, action, len(targetIDs), campaignID, adGroupID)
-			return
 		}
+		if len(updates) > 0 {
+			writes, err := client.UpdateKeywords(ctx, campaignID, adGroupID, updates)
+			record(updateRows, writes, err)
+		}
+		if len(adds) > 0 {
+			writes, err := client.AddKeywords(ctx, campaignID, adGroupID, adds)
+			record(addRows, writes, err)
+		}
+		stopped = failed && !continueOnError
+	}
+	return results
+}
 
-		status := "ACTIVE"
-		if action == "pause" {
-			status = "PAUSED"
+func runKeywordsBulk(ctx context.Context, client *appleads.Client, args []string, action string, jsonOut bool) {
+	targets, err := readBulkInput(args, "keywordId")
+	if err == nil {
+		targets, err = scopeBulkTargets(targets, args, true, true)
+	}
+	if err != nil {
+		respondCommandError("keywords", jsonOut, err)
+		return
+	}
+	runKeywordsMutation(ctx, client, args, action, jsonOut, targets, nil, nil)
+}
+
+// runKeywordsMutation applies a status, bid or removal change to each target.
+// known seeds the keyword lookup for the flag-scoped ad group so it is not
+// fetched twice.
+func runKeywordsMutation(ctx context.Context, client *appleads.Client, args []string, action string, jsonOut bool, targets []bulkTarget, skipped []bulkItemResult, known []appleads.KeywordSummary) {
+	if action == "delete" {
+		action = "remove"
+	}
+	var bidAmount *float64
+	var currency *string
+	if action == "rebid" {
+		var err error
+		bidAmount, err = parseRebidAmount(args)
+		if err != nil {
+			respondCommandError("keywords", jsonOut, err)
+			return
 		}
-		for _, keywordID := range targetIDs {
-			if keyword, ok := keywordByID[keywordID]; ok {
-				if err := client.UpdateKeyword(ctx, campaignID, adGroupID, keywordID, keyword.MatchType, status, nil, nil); err != nil {
-					respondCommandError("keywords", jsonOut, err)
-					return
-				}
-				continue
+		if c := strings.TrimSpace(valueForFlag(args, "--currency")); c != "" {
+			currency = &c
+		}
+	}
+	status := ""
+	switch action {
+	case "pause":
+		status = "PAUSED"
+	case "activate":
+		status = "ACTIVE"
+	}
+
+	type adGroupKey struct{ campaignID, adGroupID int }
+	keywordsByGroup := map[adGroupKey]map[int]appleads.KeywordSummary{}
+	if len(targets) > 0 && known != nil {
+		byID := make(map[int]appleads.KeywordSummary, len(known))
+		for _, keyword := range known {
+			byID[keyword.ID] = keyword
+		}
+		keywordsByGroup[adGroupKey{campaignID: targets[0].CampaignID, adGroupID: targets[0].AdGroupID}] = byID
+	}
+	lookup := func(target bulkTarget) (appleads.KeywordSummary, error) {
+		key := adGroupKey{campaignID: target.CampaignID, adGroupID: target.AdGroupID}
+		byID, ok := keywordsByGroup[key]
+		if !ok {
+			keywords, err := client.FetchKeywords(ctx, target.CampaignID, target.AdGroupID)
+			if err != nil {
+				return appleads.KeywordSummary{}, err
 			}
-			if err := client.UpdateKeyword(ctx, campaignID, adGroupID, keywordID, "", status, nil, nil); err != nil {
-				respondCommandError("keywords", jsonOut, err)
-				return
+			byID = make(map[int]appleads.KeywordSummary, len(keywords))
+			for _, keyword := range keywords {
+				byID[keyword.ID] = keyword
 			}
+			keywordsByGroup[key] = byID
 		}
-		respondKeywordsMutation(jsonOut, action, len(targetIDs), campaignID, adGroupID)
-	case "pause-by-text":
-		forwarded := append([]string{}, args...)
-		if len(forwarded) > 0 {
-			forwarded[0] = "pause"
-		}
-		RunKeywords(ctx, client, forwarded, jsonOut)
-	default:
-		respondCommandError("keywords", jsonOut, fmt.Errorf("Unknown keywords action: %s", action))
+		return byID[target.ID], nil
+	}
+
+	results := collectBulkResults(
+		len(targets),
+		parseBulkOptions(args).continueOnError,
+		func(idx int) bulkItemResult {
+			target := targets[idx]
+			return bulkItemResult{ID: target.ID, CampaignID: target.CampaignID, AdGroupID: target.AdGroupID}
+		},
+		func(idx int) (bulkItemResult, error) {
+			target := targets[idx]
+			keyword, err := lookup(target)
+			if err != nil {
+				return bulkItemResult{}, err
+			}
+			result := bulkItemResult{Text: keyword.Text, MatchType: keyword.MatchType}
+			if action == "remove" {
+				return result, client.DeleteKeyword(ctx, target.CampaignID, target.AdGroupID, target.ID)
+			}
+			result.Status = status
+			result.BidAmount = bidAmount
+			if currency != nil {
+				result.Currency = *currency
+			}
+			return result, client.UpdateKeyword(ctx, target.CampaignID, target.AdGroupID, target.ID, keyword.MatchType, status, bidAmount, currency)
+		},
+	)
+	respondBulkResults("keywords", action, args, jsonOut, append(results, skipped...))
+}
+
+func parseRebidAmount(args []string) (*float64, error) {
+	bidAmountRaw := strings.TrimSpace(valueForFlag(args, "--bidAmount"))
+	var bidAmount float64
+	if _, err := fmt.Sscanf(bidAmountRaw, "%f", &bidAmount); bidAmountRaw == "" || err != nil || bidAmount <= 0 {
+		return nil, fmt.Errorf("rebid requires --bidAmount <number>")
 	}
+	return &bidAmount, nil
 }
 
 func runKeywordsList(ctx context.Context, client *appleads.Client, args []string, jsonOut bool, campaignID int, adGroupID int, applyFilters bool) {
@@ -229,33 +368,107 @@ func runKeywordsList(ctx context.Context, client *appleads.Client, args []string
 }
 
 func runKeywordsReport(ctx context.Context, client *appleads.Client, args []string, jsonOut bool, campaignID int, adGroupID int) {
-	startRaw := valueForFlag(args, "--startDate")
-	endRaw := valueForFlag(args, "--endDate")
-	startDate, err := parseDate(startRaw)
+	startDate, endDate, err := reportDateRange(args)
 	if err != nil {
-		respondCommandError("keywords", jsonOut, fmt.Errorf("Missing/invalid --startDate YYYY-MM-DD and --endDate YYYY-MM-DD"))
+		respondCommandError("keywords", jsonOut, err)
 		return
 	}
-	endDate, err := parseDate(endRaw)
+	options, err := reportOptions(args, startDate, endDate)
 	if err != nil {
-		respondCommandError("keywords", jsonOut, fmt.Errorf("Missing/invalid --startDate YYYY-MM-DD and --endDate YYYY-MM-DD"))
+		respondCommandError("keywords", jsonOut, err)
 		return
 	}
+	compareStart, compareEnd, compare, err := reportCompareRange(args, startDate, endDate, options.Granularity)
+	if err != nil {
+		respondCommandError("keywords", jsonOut, err)
+		return
+	}
+	startRaw, endRaw := startDate.Format("2006-01-02"), endDate.Format("2006-01-02")
 
-	minTaps := 0
-	if raw := strings.TrimSpace(valueForFlag(args, "--minTaps")); raw != "" {
-		_, _ = fmt.Sscanf(raw, "%d", &minTaps)
-		if minTaps < 0 {
-			minTaps = 0
+	window, err := collectKeywordReport(ctx, client, args, campaignID, adGroupID, startDate, endDate, options)
+	if err != nil {
+		respondCommandError("keywords", jsonOut, err)
+		return
+	}
+	keywordRows, breakdown := window.rows, window.breakdown
+	payload := map[string]any{
+		"ok":          true,
+		"campaignId":  campaignID,
+		"adGroupId":   adGroupID,
+		"startDate":   startRaw,
+		"endDate":     endRaw,
+		"timeZone":    options.TimeZone,
+		"granularity": options.Granularity,
+		"totals":      window.totals,
+		"periods":     window.periods.rows(),
+		"rows":        keywordRows,
+	}
+	if len(options.GroupBy) > 0 {
+		payload["groupBy"] = options.GroupBy
+		payload["breakdown"] = breakdown.rows()
+	}
+	compareLabels := []string{"keywordId", "keywordText", "matchType", "dimensions"}
+	if compare {
+		previous, err := collectKeywordReport(ctx, client, args, campaignID, adGroupID, compareStart, compareEnd, options)
+		if err != nil {
+			respondCommandError("keywords", jsonOut, err)
+			return
 		}
+		payload["compare"] = map[string]any{"startDate": compareStart.Format("2006-01-02"), "endDate": compareEnd.Format("2006-01-02")}
+		minTaps, minSpend := reportMinimums(args)
+		payload["comparison"] = comparisonAboveMinimums(compareReportRows(window.all, previous.all, []string{"keywordId", "dimensions"}, compareLabels), minTaps, minSpend)
+	}
+	if jsonOut {
+		printJSON(payload)
+		return
+	}
+
+	fmt.Printf("campaignId=%d adGroupId=%d range=%s...%s timeZone=%s granularity=%s\n", campaignID, adGroupID, startRaw, endRaw, options.TimeZone, options.Granularity)
+	printReportTotals(window.totals)
+	if hasFlag(args, "--granularity") {
+		printReportPeriods(payload["periods"].([]map[string]any))
 	}
-	minSpend := 0.0
-	if raw := strings.TrimSpace(valueForFlag(args, "--minSpend")); raw != "" {
-		_, _ = fmt.Sscanf(raw, "%f", &minSpend)
-		if minSpend < 0 {
-			minSpend = 0
+	breakdown.print()
+	limit := len(keywordRows)
+	if limit > 30 {
+		limit = 30
+	}
+	for i := 0; i < limit; i++ {
+		item := keywordRows[i]
+		keywordID, _ := item["keywordId"].(int)
+		text, _ := item["keywordText"].(string)
+		if len(options.GroupBy) > 0 {
+			dimensions, _ := item["dimensions"].(map[string]string)
+			text = dimensionKey(options.GroupBy, dimensions) + "\t" + text
 		}
+		matchType, _ := item["matchType"].(string)
+		status, _ := item["status"].(string)
+		taps, _ := item["taps"].(int)
+		installs, _ := item["installs"].(int)
+		spend, _ := item["spend"].(float64)
+		itemCPT, _ := item["cpt"].(float64)
+		fmt.Printf("%.4f\t%d\t%d\t%.4f\t%d\t%s\t%s\t%s\n", spend, taps, installs, itemCPT, keywordID, status, matchType, text)
+	}
+	if compare {
+		printReportComparison(compareStart, compareEnd, payload["comparison"].([]map[string]any), compareLabels)
 	}
+}
+
+// keywordReportWindow is one date range of a keyword report. rows are
+// filtered by --minTaps and --minSpend; all are not, so --compare can apply
+// them across both windows.
+type keywordReportWindow struct {
+	rows      []map[string]any
+	all       []map[string]any
+	totals    map[string]any
+	periods   reportPeriods
+	breakdown *reportBreakdown
+}
+
+// collectKeywordReport fetches and filters one date range, so --compare can
+// run the same report over two windows.
+func collectKeywordReport(ctx context.Context, client *appleads.Client, args []string, campaignID, adGroupID int, startDate, endDate time.Time, options appleads.ReportOptions) (*keywordReportWindow, error) {
+	minTaps, minSpend := reportMinimums(args)
 
 	idFilters := parseIntFlagSet(args, "--keywordId")
 	exactText := parseStringSet(valuesForFlag(args, "--text"), false)
@@ -263,10 +476,9 @@ func runKeywordsReport(ctx context.Context, client *appleads.Client, args []stri
 	statusFilters := parseStringSet(splitCSVValues(valuesForFlag(args, "--status")), true)
 	matchTypeFilters := parseStringSet(splitCSVValues(valuesForFlag(args, "--matchType")), true)
 
-	rows, err := client.FetchKeywordDailyMetrics(ctx, startDate, endDate, campaignID, adGroupID)
+	rows, err := client.FetchKeywordDailyMetrics(ctx, startDate, endDate, options, campaignID, adGroupID)
 	if err != nil {
-		respondCommandError("keywords", jsonOut, err)
-		return
+		return nil, err
 	}
 
 	type agg struct {
@@ -279,10 +491,14 @@ func runKeywordsReport(ctx context.Context, client *appleads.Client, args []stri
 		installs     int
 		spend        float64
 		currencyCode *string
+		dimensions   map[string]string
+		periods      reportPeriods
 	}
-	byKeyword := map[int]*agg{}
+	// With --groupBy, each keyword gets a row per dimension combination.
+	byKeyword := map[string]*agg{}
 	for _, row := range rows {
-		entry := byKeyword[row.KeywordID]
+		key := fmt.Sprintf("%d\t%s", row.KeywordID, dimensionKey(options.GroupBy, row.Dimensions))
+		entry := byKeyword[key]
 		if entry == nil {
 			entry = &agg{
 				keywordID:    row.KeywordID,
@@ -290,21 +506,29 @@ func runKeywordsReport(ctx context.Context, client *appleads.Client, args []stri
 				matchType:    row.MatchType,
 				status:       row.Status,
 				currencyCode: row.CurrencyCode,
+				dimensions:   row.Dimensions,
+				periods:      reportPeriods{},
 			}
-			byKeyword[row.KeywordID] = entry
+			byKeyword[key] = entry
 		}
-		entry.impressions += row.Impressions
-		entry.taps += row.Taps
+		installs := 0
 		if row.Installs != nil {
-			entry.installs += *row.Installs
+			installs = *row.Installs
 		}
+		entry.impressions += row.Impressions
+		entry.taps += row.Taps
+		entry.installs += installs
 		entry.spend += row.Spend
+		entry.periods.add(row.Date, row.Spend, row.Taps, row.Impressions, installs)
 		if entry.currencyCode == nil {
 			entry.currencyCode = row.CurrencyCode
 		}
 	}
 
 	keywordRows := make([]map[string]any, 0, len(byKeyword))
+	allRows := make([]map[string]any, 0, len(byKeyword))
+	periods := reportPeriods{}
+	breakdown := newReportBreakdown(options.GroupBy)
 	for _, row := range byKeyword {
 		if len(idFilters) > 0 {
 			if _, ok := idFilters[row.keywordID]; !ok {
@@ -329,10 +553,6 @@ func runKeywordsReport(ctx context.Context, client *appleads.Client, args []stri
 				continue
 			}
 		}
-		if row.taps < minTaps || row.spend < minSpend {
-			continue
-		}
-
 		cpt := 0.0
 		if row.taps > 0 {
 			cpt = row.spend / float64(row.taps)
@@ -363,7 +583,16 @@ func runKeywordsReport(ctx context.Context, client *appleads.Client, args []stri
 		} else {
 			item["currency"] = nil
 		}
+		if row.dimensions != nil {
+			item["dimensions"] = row.dimensions
+		}
+		allRows = append(allRows, item)
+		if row.taps < minTaps || row.spend < minSpend {
+			continue
+		}
 		keywordRows = append(keywordRows, item)
+		periods.merge(row.periods)
+		breakdown.add(row.dimensions, derefString(row.currencyCode), row.spend, row.taps, row.impressions, row.installs)
 	}
 
 	sort.Slice(keywordRows, func(i, j int) bool {
@@ -402,13 +631,10 @@ func runKeywordsReport(ctx context.Context, client *appleads.Client, args []stri
 		installRate = float64(totals.installs) / float64(totals.taps)
 	}
 
-	payload := map[string]any{
-		"ok":         true,
-		"campaignId": campaignID,
-		"adGroupId":  adGroupID,
-		"startDate":  startRaw,
-		"endDate":    endRaw,
-		"totals": map[string]any{
+	return &keywordReportWindow{
+		rows: keywordRows,
+		all:  allRows,
+		totals: map[string]any{
 			"impressions": totals.impressions,
 			"taps":        totals.taps,
 			"installs":    totals.installs,
@@ -417,40 +643,45 @@ func runKeywordsReport(ctx context.Context, client *appleads.Client, args []stri
 			"ttr":         ttr,
 			"installRate": installRate,
 		},
-		"rows": keywordRows,
-	}
-	if jsonOut {
-		printJSON(payload)
-		return
-	}
+		periods:   periods,
+		breakdown: breakdown,
+	}, nil
+}
 
-	fmt.Printf("campaignId=%d adGroupId=%d range=%s...%s\n", campaignID, adGroupID, startRaw, endRaw)
-	fmt.Printf("totals taps=%d installs=%d spend=%.4f cpt=%.4f ttr=%.4f\n", totals.taps, totals.installs, totals.spend, cpt, ttr)
-	limit := len(keywordRows)
-	if limit > 30 {
-		limit = 30
+// keywordDefaults are the match type, status and currency given to keywords
+// that do not set their own.
+type keywordDefaults struct {
+	matchType string
+	status    string
+	currency  *string
+}
+
+// keywordDefaultsFromArgs resolves each default from its flag, then the
+// config, then a built-in value.
+func keywordDefaultsFromArgs(args []string) keywordDefaults {
+	defaults := keywordDefaults{
+		matchType: strings.ToUpper(firstNonEmptyString(valueForFlag(args, "--matchType"), configDefault("matchType"), "BROAD")),
+		status:    strings.ToUpper(firstNonEmptyString(valueForFlag(args, "--status"), "ACTIVE")),
 	}
-	for i := 0; i < limit; i++ {
-		item := keywordRows[i]
-		keywordID, _ := item["keywordId"].(int)
-		text, _ := item["keywordText"].(string)
-		matchType, _ := item["matchType"].(string)
-		status, _ := item["status"].(string)
-		taps, _ := item["taps"].(int)
-		installs, _ := item["installs"].(int)
-		spend, _ := item["spend"].(float64)
-		itemCPT, _ := item["cpt"].(float64)
-		fmt.Printf("%.4f\t%d\t%d\t%.4f\t%d\t%s\t%s\t%s\n", spend, taps, installs, itemCPT, keywordID, status, matchType, text)
+	if raw := firstNonEmptyString(valueForFlag(args, "--currency"), configDefault("currency")); raw != "" {
+		defaults.currency = &raw
 	}
+	return defaults
 }
 
 func parseAddKeywordInputs(args []string) ([]keywordInput, error) {
+	defaults := keywordDefaultsFromArgs(args)
+	if retryPath := strings.TrimSpace(valueForFlag(args, "--retry")); retryPath != "" {
+		rows, err := readRetryRows(retryPath)
+		if err != nil {
+			return nil, err
+		}
+		return keywordInputsFromRows(rows, defaults), nil
+	}
 	if filePath := strings.TrimSpace(valueForFlag(args, "--file")); filePath != "" {
-		return parseKeywordFile(filePath, args)
+		return parseKeywordFile(filePath, defaults)
 	}
 	texts := valuesForFlag(args, "--text")
-	matchType := strings.ToUpper(firstNonEmptyString(valueForFlag(args, "--matchType"), "BROAD"))
-	status := strings.ToUpper(firstNonEmptyString(valueForFlag(args, "--status"), "ACTIVE"))
 	var bidAmount *float64
 	if raw := strings.TrimSpace(valueForFlag(args, "--bidAmount")); raw != "" {
 		v := 0.0
@@ -458,59 +689,25 @@ func parseAddKeywordInputs(args []string) ([]keywordInput, error) {
 			bidAmount = &v
 		}
 	}
-	var currency *string
-	if raw := strings.TrimSpace(valueForFlag(args, "--currency")); raw != "" {
-		currency = &raw
-	}
 	inputs := make([]keywordInput, 0, len(texts))
 	for _, text := range texts {
-		inputs = append(inputs, keywordInput{text: text, matchType: matchType, bidAmount: bidAmount, currency: currency, status: status})
+		inputs = append(inputs, keywordInput{text: text, matchType: defaults.matchType, bidAmount: bidAmount, currency: defaults.currency, status: defaults.status})
 	}
 	return inputs, nil
 }
 
-func parseKeywordFile(path string, args []string) ([]keywordInput, error) {
+func parseKeywordFile(path string, defaults keywordDefaults) ([]keywordInput, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	defaultMatchType := strings.ToUpper(firstNonEmptyString(valueForFlag(args, "--matchType"), "BROAD"))
-	defaultStatus := strings.ToUpper(firstNonEmptyString(valueForFlag(args, "--status"), "ACTIVE"))
-	defaultCurrencyRaw := strings.TrimSpace(valueForFlag(args, "--currency"))
-	var defaultCurrency *string
-	if defaultCurrencyRaw != "" {
-		defaultCurrency = &defaultCurrencyRaw
-	}
 
 	if strings.HasSuffix(strings.ToLower(path), ".json") {
 		var rows []map[string]any
 		if err := json.Unmarshal(data, &rows); err != nil {
 			return nil, fmt.Errorf("Invalid JSON keyword file; expected array of objects")
 		}
-		inputs := make([]keywordInput, 0, len(rows))
-		for _, row := range rows {
-			text := strings.TrimSpace(stringFromAny(row["text"]))
-			if text == "" {
-				continue
-			}
-			matchType := strings.ToUpper(firstNonEmptyString(stringFromAny(row["matchType"]), defaultMatchType))
-			status := strings.ToUpper(firstNonEmptyString(stringFromAny(row["status"]), defaultStatus))
-			var bidAmount *float64
-			if raw := strings.TrimSpace(stringFromAny(row["bidAmount"])); raw != "" {
-				v := 0.0
-				if _, err := fmt.Sscanf(raw, "%f", &v); err == nil {
-					bidAmount = &v
-				}
-			}
-			var currency *string
-			if c := strings.TrimSpace(stringFromAny(row["currency"])); c != "" {
-				currency = &c
-			} else {
-				currency = defaultCurrency
-			}
-			inputs = append(inputs, keywordInput{text: text, matchType: matchType, bidAmount: bidAmount, currency: currency, status: status})
-		}
-		return inputs, nil
+		return keywordInputsFromRows(rows, defaults), nil
 	}
 
 	content := string(data)
@@ -545,8 +742,8 @@ func parseKeywordFile(path string, args []string) ([]keywordInput, error) {
 		if text == "" {
 			continue
 		}
-		matchType := defaultMatchType
-		status := defaultStatus
+		matchType := defaults.matchType
+		status := defaults.status
 		if hasHeader {
 			if v := strings.TrimSpace(valueAt(header, cols, "matchtype")); v != "" {
 				matchType = strings.ToUpper(v)
@@ -564,7 +761,7 @@ func parseKeywordFile(path string, args []string) ([]keywordInput, error) {
 				}
 			}
 		}
-		currency := defaultCurrency
+		currency := defaults.currency
 		if hasHeader {
 			if c := strings.TrimSpace(valueAt(header, cols, "currency")); c != "" {
 				currency = &c
@@ -575,6 +772,62 @@ func parseKeywordFile(path string, args []string) ([]keywordInput, error) {
 	return inputs, nil
 }
 
+// keywordInputsFromRows reads JSON keyword rows. A --resultFile from an
+// earlier add has the same shape, so it can be fed back in as-is.
+func keywordInputsFromRows(rows []map[string]any, defaults keywordDefaults) []keywordInput {
+	inputs := make([]keywordInput, 0, len(rows))
+	for _, row := range rows {
+		text := strings.TrimSpace(stringFromAny(row["text"]))
+		if text == "" {
+			continue
+		}
+		matchType := strings.ToUpper(firstNonEmptyString(stringFromAny(row["matchType"]), defaults.matchType))
+		status := strings.ToUpper(firstNonEmptyString(stringFromAny(row["status"]), defaults.status))
+		var bidAmount *float64
+		if raw := strings.TrimSpace(stringFromAny(row["bidAmount"])); raw != "" {
+			v := 0.0
+			if _, err := fmt.Sscanf(raw, "%f", &v); err == nil {
+				bidAmount = &v
+			}
+		}
+		var currency *string
+		if c := strings.TrimSpace(stringFromAny(row["currency"])); c != "" {
+			currency = &c
+		} else {
+			currency = defaults.currency
+		}
+		inputs = append(inputs, keywordInput{text: text, matchType: matchType, bidAmount: bidAmount, currency: currency, status: status})
+	}
+	return inputs
+}
+
+// unmatchedKeywordTexts returns --text values that match no keyword, so they
+// can be reported as skipped rather than silently dropped.
+func unmatchedKeywordTexts(args []string, keywords []appleads.KeywordSummary) []string {
+	existing := make(map[string]struct{}, len(keywords))
+	for _, keyword := range keywords {
+		existing[strings.ToLower(strings.TrimSpace(keyword.Text))] = struct{}{}
+	}
+	seen := map[string]struct{}{}
+	out := make([]string, 0)
+	for _, raw := range valuesForFlag(args, "--text") {
+		trimmed := strings.TrimSpace(raw)
+		normalized := strings.ToLower(trimmed)
+		if normalized == "" {
+			continue
+		}
+		if _, ok := existing[normalized]; ok {
+			continue
+		}
+		if _, ok := seen[normalized]; ok {
+			continue
+		}
+		seen[normalized] = struct{}{}
+		out = append(out, trimmed)
+	}
+	return out
+}
+
 func resolveKeywordTargets(args []string, keywords []appleads.KeywordSummary) ([]int, error) {
 	explicitIDs := make(map[int]struct{})
 	for _, raw := range valuesForFlag(args, "--keywordId") {
@@ -628,14 +881,6 @@ func selectKeywordMutationTarget(keywords []appleads.KeywordSummary, text, match
 	return nil
 }
 
-func respondKeywordsMutation(jsonOut bool, action string, count int, campaignID int, adGroupID int) {
-	if jsonOut {
-		printJSON(map[string]any{"ok": true, "action": action, "campaignId": campaignID, "adGroupId": adGroupID, "affected": count})
-		return
-	}
-	fmt.Printf("ok action=%s campaignId=%d adGroupId=%d affected=%d\n", action, campaignID, adGroupID, count)
-}
-
 func valueAt(header []string, cols []string, key string) string {
 	for idx, h := range header {
 		if h == key && idx < len(cols) {
@@ -719,6 +964,8 @@ func stringFromAny(v any) string {
 		return fmt.Sprintf("%v", value)
 	case int:
 		return fmt.Sprintf("%d", value)
+	case json.Number:
+		return value.String()
 	default:
 		return ""
 	}
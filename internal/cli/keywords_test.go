@@ -1,7 +1,12 @@
 package cli
 
 import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
 	"testing"
+	"time"
 
 	"searchads-cli/internal/appleads"
 )
@@ -37,3 +42,94 @@ func TestSelectKeywordMutationTargetReturnsNilWhenExactMatchMissing(t *testing.T
 		t.Fatalf("expected nil when no exact match exists, got %+v", got)
 	}
 }
+
+func TestApplyKeywordAddsSkipsDuplicateRows(t *testing.T) {
+	t.Setenv("OE_ADS_CREDENTIALS_JSON", testCredentialsJSON(t))
+	var sent []any
+	client := appleads.NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.Host == "appleid.apple.com":
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.Path == "/api/v5/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.URL.Path == "/api/v5/campaigns/7/adgroups/8/targetingkeywords/bulk" && req.Method == http.MethodPost:
+				_ = json.NewDecoder(req.Body).Decode(&sent)
+				return jsonResponse(http.StatusOK, `{"data":[{"id":21},{"id":22}]}`), nil
+			}
+			return jsonResponse(http.StatusNotFound, `{"error":"unexpected request"}`), nil
+		}),
+	})
+
+	inputs := []keywordInput{
+		{text: "maps", matchType: "EXACT"},
+		{text: "Maps ", matchType: "exact"},
+		{text: "maps", matchType: "BROAD"},
+	}
+	results := applyKeywordAdds(context.Background(), client, 7, 8, nil, inputs, false)
+	if len(sent) != 2 {
+		t.Fatalf("expected 2 keywords sent, got %v", sent)
+	}
+	if results[0].Outcome != "ok" || results[0].ID != 21 || results[2].Outcome != "ok" || results[2].ID != 22 {
+		t.Fatalf("unexpected results for unique rows: %+v", results)
+	}
+	if results[1].Outcome != "skipped" || results[1].Reason == "" {
+		t.Fatalf("expected duplicate row to be skipped, got %+v", results[1])
+	}
+}
+
+func TestKeywordReportCompareAcrossWindows(t *testing.T) {
+	t.Setenv("OE_ADS_CREDENTIALS_JSON", testCredentialsJSON(t))
+	reports := map[string]string{
+		"2026-10-08": `[{"metadata":{"keywordId":11,"keywordText":"maps"},"granularity":[{"date":"2026-10-08","localSpend":{"amount":"9.00","currency":"USD"},"taps":3,"impressions":30,"totalInstalls":3}]},` +
+			`{"metadata":{"keywordId":12,"keywordText":"atlas"},"granularity":[{"date":"2026-10-08","localSpend":{"amount":"2.00","currency":"USD"},"taps":1,"impressions":20}]}]`,
+		"2026-10-07": `[{"metadata":{"keywordId":11,"keywordText":"maps"},"granularity":[{"date":"2026-10-07","localSpend":{"amount":"6.00","currency":"USD"},"taps":3,"impressions":60,"totalInstalls":1}]},` +
+			`{"metadata":{"keywordId":13,"keywordText":"globe"},"granularity":[{"date":"2026-10-07","localSpend":{"amount":"1.00","currency":"USD"},"taps":1,"impressions":5}]}]`,
+	}
+	client := appleads.NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.Host == "appleid.apple.com":
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.Path == "/api/v5/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.URL.Path == "/api/v5/reports/campaigns/7/adgroups/8/keywords":
+				var body map[string]any
+				_ = json.NewDecoder(req.Body).Decode(&body)
+				start, _ := body["startTime"].(string)
+				return jsonResponse(http.StatusOK, `{"data":{"reportingDataResponse":{"row":`+reports[start]+`}}}`), nil
+			}
+			return jsonResponse(http.StatusNotFound, `{"error":"unexpected request"}`), nil
+		}),
+	})
+
+	ctx := context.Background()
+	start := time.Date(2026, 10, 8, 0, 0, 0, 0, time.UTC)
+	args := []string{"report", "--compare", "previous"}
+	options := appleads.ReportOptions{Granularity: appleads.ReportGranularityDaily}
+	compareStart, compareEnd, compare, err := reportCompareRange(args, start, start, options.Granularity)
+	if err != nil || !compare || !compareStart.Equal(start.AddDate(0, 0, -1)) || !compareEnd.Equal(start.AddDate(0, 0, -1)) {
+		t.Fatalf("unexpected compare window %v..%v, %v", compareStart, compareEnd, err)
+	}
+	current, err := collectKeywordReport(ctx, client, args, 7, 8, start, start, options)
+	if err != nil {
+		t.Fatalf("current window: %v", err)
+	}
+	previous, err := collectKeywordReport(ctx, client, args, 7, 8, compareStart, compareEnd, options)
+	if err != nil {
+		t.Fatalf("previous window: %v", err)
+	}
+
+	rows := compareReportRows(current.rows, previous.rows, []string{"keywordId", "dimensions"}, []string{"keywordId", "keywordText"})
+	flags := map[string]any{}
+	for _, row := range rows {
+		flags[row["keywordText"].(string)] = row["flag"]
+	}
+	if len(rows) != 3 || flags["maps"] != nil || flags["atlas"] != "NEW" || flags["globe"] != "GONE" {
+		t.Fatalf("unexpected comparison: %v", rows)
+	}
+	maps := rows[0]
+	if maps["keywordText"] != "maps" || maps["delta"].(map[string]any)["installs"] != 2.0 || math.Abs(maps["deltaPct"].(map[string]any)["cr"].(float64)-2) > 1e-9 {
+		t.Fatalf("unexpected maps row: %v", maps)
+	}
+}
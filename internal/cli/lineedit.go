@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var errLineInterrupted = errors.New("interrupted")
+
+// lineEditor reads one line at a time from a terminal in raw mode, with
+// cursor movement, history and tab completion.
+type lineEditor struct {
+	in       *bufio.Reader
+	out      io.Writer
+	history  []string
+	complete func(before string) (partial string, candidates []string)
+}
+
+func newLineEditor(in io.Reader, out io.Writer) *lineEditor {
+	return &lineEditor{in: bufio.NewReader(in), out: out}
+}
+
+// readLine returns the entered line, errLineInterrupted on Ctrl-C, or io.EOF
+// on Ctrl-D at an empty prompt.
+func (e *lineEditor) readLine(prompt string) (string, error) {
+	var line []rune
+	pos := 0
+	historyIndex := len(e.history)
+	draft := ""
+	redraw := func() {
+		fmt.Fprintf(e.out, "\r%s%s\x1b[K", prompt, string(line))
+		if back := len(line) - pos; back > 0 {
+			fmt.Fprintf(e.out, "\x1b[%dD", back)
+		}
+	}
+	setLine := func(text string) {
+		line = []rune(text)
+		pos = len(line)
+		redraw()
+	}
+	fmt.Fprint(e.out, prompt)
+
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		switch r {
+		case '\r', '\n':
+			fmt.Fprint(e.out, "\r\n")
+			return string(line), nil
+		case 3: // Ctrl-C
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", errLineInterrupted
+		case 4: // Ctrl-D
+			if len(line) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+			if pos < len(line) {
+				line = append(line[:pos], line[pos+1:]...)
+				redraw()
+			}
+		case 127, 8: // Backspace
+			if pos > 0 {
+				line = append(line[:pos-1], line[pos:]...)
+				pos--
+				redraw()
+			}
+		case 1: // Ctrl-A
+			pos = 0
+			redraw()
+		case 5: // Ctrl-E
+			pos = len(line)
+			redraw()
+		case 11: // Ctrl-K
+			line = line[:pos]
+			redraw()
+		case 12: // Ctrl-L
+			fmt.Fprint(e.out, "\x1b[H\x1b[2J")
+			redraw()
+		case 21: // Ctrl-U
+			line = line[pos:]
+			pos = 0
+			redraw()
+		case 23: // Ctrl-W
+			start := pos
+			for start > 0 && line[start-1] == ' ' {
+				start--
+			}
+			for start > 0 && line[start-1] != ' ' {
+				start--
+			}
+			line = append(line[:start], line[pos:]...)
+			pos = start
+			redraw()
+		case '\t':
+			e.completeAt(&line, &pos)
+			redraw()
+		case 27: // Escape sequences: arrows, Home/End, Delete
+			key := e.readEscape()
+			switch key {
+			case 'A':
+				if historyIndex > 0 {
+					if historyIndex == len(e.history) {
+						draft = string(line)
+					}
+					historyIndex--
+					setLine(e.history[historyIndex])
+				}
+			case 'B':
+				if historyIndex < len(e.history) {
+					historyIndex++
+					if historyIndex == len(e.history) {
+						setLine(draft)
+					} else {
+						setLine(e.history[historyIndex])
+					}
+				}
+			case 'C':
+				if pos < len(line) {
+					pos++
+					redraw()
+				}
+			case 'D':
+				if pos > 0 {
+					pos--
+					redraw()
+				}
+			case 'H':
+				pos = 0
+				redraw()
+			case 'F':
+				pos = len(line)
+				redraw()
+			case '~':
+				if pos < len(line) {
+					line = append(line[:pos], line[pos+1:]...)
+					redraw()
+				}
+			}
+		default:
+			if r < 32 {
+				continue
+			}
+			line = append(line[:pos], append([]rune{r}, line[pos:]...)...)
+			pos++
+			redraw()
+		}
+	}
+}
+
+// readEscape reads the rest of an escape sequence and returns its final
+// byte; "ESC [ 3 ~" (Delete) returns '~'.
+func (e *lineEditor) readEscape() rune {
+	next, _, err := e.in.ReadRune()
+	if err != nil || (next != '[' && next != 'O') {
+		return 0
+	}
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return 0
+		}
+		if r >= '@' && r <= '~' {
+			return r
+		}
+	}
+}
+
+func (e *lineEditor) completeAt(line *[]rune, pos *int) {
+	if e.complete == nil {
+		return
+	}
+	before := string((*line)[:*pos])
+	partial, candidates := e.complete(before)
+	if len(candidates) == 0 {
+		return
+	}
+	insert := commonPrefix(candidates)
+	if len(candidates) == 1 {
+		insert += " "
+	}
+	// The partial token is replaced rather than extended, so completing
+	// "bra" to "'Brand UK'" can fix case and add quotes.
+	if len(insert) > len(partial) {
+		start := *pos - len([]rune(partial))
+		replaced := append([]rune(insert), (*line)[*pos:]...)
+		*line = append((*line)[:start], replaced...)
+		*pos = start + len([]rune(insert))
+		return
+	}
+	if len(candidates) > 1 {
+		fmt.Fprintf(e.out, "\r\n%s\r\n", strings.Join(candidates, "  "))
+	}
+}
+
+func commonPrefix(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	prefix := values[0]
+	for _, value := range values[1:] {
+		for !strings.HasPrefix(value, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
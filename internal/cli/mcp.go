@@ -0,0 +1,356 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"searchads-cli/internal/appleads"
+)
+
+const (
+	mcpServerName             = "searchads"
+	mcpServerVersion          = "0.1.0"
+	mcpLatestProtocolVersion  = "2025-06-18"
+	jsonRPCParseError         = -32700
+	jsonRPCInvalidRequest     = -32600
+	jsonRPCMethodNotFound     = -32601
+	jsonRPCInvalidParams      = -32602
+	mcpConfirmArgument        = "confirm"
+	mcpMaxMessageBytes        = 16 * 1024 * 1024
+	mcpDryRunMessage          = "Not executed. Call again with confirm: true to apply this change."
+	mcpServerDryRunMessage    = "Not executed: the server was started with --dryRun."
+	mcpMutationsDisabledError = "Mutation tools are disabled; restart with searchads mcp --allowMutations"
+)
+
+var mcpSupportedProtocolVersions = map[string]struct{}{
+	"2024-11-05": {},
+	"2025-03-26": {},
+	"2025-06-18": {},
+}
+
+type mcpServerOptions struct {
+	allowMutations bool
+	dryRun         bool
+}
+
+type mcpTool struct {
+	Name        string         `json:"name"`
+	Title       string         `json:"title,omitempty"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+	Annotations map[string]any `json:"annotations,omitempty"`
+
+	command string
+	action  actionSpec
+}
+
+type jsonRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// RunMCP serves the Model Context Protocol over stdio: newline-delimited
+// JSON-RPC 2.0 requests on in, responses on out. Read-only actions from the
+// command schema become tools; mutations are only listed with
+// --allowMutations and run only when called with confirm: true.
+func RunMCP(ctx context.Context, client *appleads.Client, args []string, in io.Reader, out io.Writer) {
+	options := mcpServerOptions{
+		allowMutations: hasFlag(args, "--allowMutations"),
+		dryRun:         hasFlag(args, "--dryRun"),
+	}
+	tools := buildMCPTools(options)
+	toolByName := make(map[string]mcpTool, len(tools))
+	for _, tool := range tools {
+		toolByName[tool.Name] = tool
+	}
+
+	encoder := json.NewEncoder(out)
+	reader := bufio.NewReaderSize(in, 64*1024)
+	for {
+		line, err := readMCPLine(reader)
+		if len(strings.TrimSpace(string(line))) > 0 {
+			if response := handleMCPMessage(ctx, client, options, tools, toolByName, line); response != nil {
+				if encodeErr := encoder.Encode(response); encodeErr != nil {
+					failText("mcp failed to write response: %s", encodeErr.Error())
+					markCommandFailed()
+					return
+				}
+			}
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				failText("mcp failed to read request: %s", err.Error())
+				markCommandFailed()
+			}
+			return
+		}
+	}
+}
+
+func readMCPLine(reader *bufio.Reader) ([]byte, error) {
+	var line []byte
+	for {
+		chunk, isPrefix, err := reader.ReadLine()
+		line = append(line, chunk...)
+		if len(line) > mcpMaxMessageBytes {
+			return nil, fmt.Errorf("message exceeds %d bytes", mcpMaxMessageBytes)
+		}
+		if err != nil || !isPrefix {
+			return line, err
+		}
+	}
+}
+
+func handleMCPMessage(ctx context.Context, client *appleads.Client, options mcpServerOptions, tools []mcpTool, toolByName map[string]mcpTool, line []byte) map[string]any {
+	var request jsonRPCRequest
+	if err := json.Unmarshal(line, &request); err != nil {
+		return jsonRPCErrorResponse(nil, jsonRPCParseError, "Parse error")
+	}
+	isNotification := len(request.ID) == 0
+	if request.JSONRPC != "2.0" || request.Method == "" {
+		if isNotification {
+			return nil
+		}
+		return jsonRPCErrorResponse(request.ID, jsonRPCInvalidRequest, "Invalid request")
+	}
+
+	var result any
+	var rpcErr *jsonRPCError
+	switch request.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(request.Params, &params)
+		version := mcpLatestProtocolVersion
+		if _, ok := mcpSupportedProtocolVersions[params.ProtocolVersion]; ok {
+			version = params.ProtocolVersion
+		}
+		result = map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{"listChanged": false}},
+			"serverInfo":      map[string]any{"name": mcpServerName, "version": mcpServerVersion},
+			"instructions":    "Tools mirror searchads commands and return their --json output. Mutation tools are dry runs unless called with confirm: true.",
+		}
+	case "ping":
+		result = map[string]any{}
+	case "tools/list":
+		result = map[string]any{"tools": tools}
+	case "tools/call":
+		var params struct {
+			Name      string         `json:"name"`
+			Arguments map[string]any `json:"arguments"`
+		}
+		if err := json.Unmarshal(request.Params, &params); err != nil {
+			rpcErr = &jsonRPCError{Code: jsonRPCInvalidParams, Message: "Invalid tools/call params"}
+			break
+		}
+		tool, ok := toolByName[params.Name]
+		if !ok {
+			rpcErr = &jsonRPCError{Code: jsonRPCInvalidParams, Message: fmt.Sprintf("Unknown tool: %s", params.Name)}
+			break
+		}
+		result = callMCPTool(ctx, client, options, tool, params.Arguments)
+	default:
+		if strings.HasPrefix(request.Method, "notifications/") {
+			return nil
+		}
+		rpcErr = &jsonRPCError{Code: jsonRPCMethodNotFound, Message: fmt.Sprintf("Method not found: %s", request.Method)}
+	}
+
+	if isNotification {
+		return nil
+	}
+	if rpcErr != nil {
+		return jsonRPCErrorResponse(request.ID, rpcErr.Code, rpcErr.Message)
+	}
+	return map[string]any{"jsonrpc": "2.0", "id": request.ID, "result": result}
+}
+
+func jsonRPCErrorResponse(id json.RawMessage, code int, message string) map[string]any {
+	var responseID any = id
+	if len(id) == 0 {
+		responseID = nil
+	}
+	return map[string]any{"jsonrpc": "2.0", "id": responseID, "error": jsonRPCError{Code: code, Message: message}}
+}
+
+func callMCPTool(ctx context.Context, client *appleads.Client, options mcpServerOptions, tool mcpTool, arguments map[string]any) map[string]any {
+	if tool.action.Mutates && !options.allowMutations {
+		return mcpToolResult(mcpMutationsDisabledError, true)
+	}
+	commandArgs, err := actionCommandLine(tool.command, tool.action, arguments, mcpConfirmArgument)
+	if err != nil {
+		return mcpToolResult(err.Error(), true)
+	}
+
+	if tool.action.Mutates {
+		confirmed, _ := arguments[mcpConfirmArgument].(bool)
+		if options.dryRun || !confirmed {
+			message := mcpDryRunMessage
+			if options.dryRun {
+				message = mcpServerDryRunMessage
+			}
+			payload, _ := json.MarshalIndent(map[string]any{
+				"ok":      true,
+				"dryRun":  true,
+				"command": "searchads " + strings.Join(quoteArgs(commandArgs), " "),
+				"message": message,
+			}, "", "  ")
+			return mcpToolResult(string(payload), false)
+		}
+	}
+
+	stdout, stderr, failed, err := captureCommandOutput(func() {
+		Dispatch(ctx, client, commandArgs)
+	})
+	if err != nil {
+		return mcpToolResult(err.Error(), true)
+	}
+	text := strings.TrimSpace(stdout)
+	if trimmed := strings.TrimSpace(stderr); trimmed != "" {
+		if text != "" {
+			text += "\n"
+		}
+		text += trimmed
+	}
+	if text == "" {
+		text = `{"ok":true}`
+	}
+	return mcpToolResult(text, failed)
+}
+
+func mcpToolResult(text string, isError bool) map[string]any {
+	return map[string]any{
+		"content": []map[string]any{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
+
+func buildMCPTools(options mcpServerOptions) []mcpTool {
+	tools := make([]mcpTool, 0, 64)
+	for _, spec := range commandSpecs() {
+		if _, excluded := remoteExcludedCommands[spec.Name]; excluded {
+			continue
+		}
+		for _, action := range spec.Actions {
+			if action.Mutates && !options.allowMutations {
+				continue
+			}
+			single := action.Name == spec.Name
+			name := spec.Name
+			title := "searchads " + spec.Name
+			if !single {
+				name += "_" + action.Name
+				title += " " + action.Name
+			}
+			tool := mcpTool{
+				Name:        strings.ReplaceAll(name, "-", "_"),
+				Title:       title,
+				Description: action.Summary,
+				InputSchema: mcpInputSchema(action),
+				command:     spec.Name,
+				action:      action,
+			}
+			if action.Mutates {
+				tool.Description += ". Dry run unless confirm is true."
+				tool.Annotations = map[string]any{"readOnlyHint": false, "destructiveHint": true}
+			} else {
+				tool.Annotations = map[string]any{"readOnlyHint": true}
+			}
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+func mcpInputSchema(action actionSpec) map[string]any {
+	properties := map[string]any{}
+	alternatives := map[string][]string{}
+	for _, flag := range action.Flags {
+		if flag.AlternativeTo != "" {
+			alternatives[flag.AlternativeTo] = append(alternatives[flag.AlternativeTo], strings.TrimPrefix(flag.Name, "--"))
+		}
+	}
+	required := make([]string, 0, 4)
+	for _, flag := range action.Flags {
+		if remoteExcludedFlag(flag) {
+			continue
+		}
+		name := strings.TrimPrefix(flag.Name, "--")
+		property := mcpPropertySchema(flag)
+		if flag.Repeatable {
+			property = map[string]any{"type": "array", "items": property}
+		}
+		description := flag.Description
+		if alts := alternatives[flag.Name]; len(alts) > 0 {
+			description += fmt.Sprintf(" (or %s)", strings.Join(alts, ", "))
+		}
+		if description != "" {
+			property["description"] = description
+		}
+		if flag.Default != "" {
+			property["default"] = flag.Default
+		}
+		properties[name] = property
+		if flag.Required && len(alternatives[flag.Name]) == 0 {
+			required = append(required, name)
+		}
+	}
+	if action.Mutates {
+		properties[mcpConfirmArgument] = map[string]any{"type": "boolean", "description": "Apply the change; without it the call is a dry run"}
+	}
+	schema := map[string]any{"type": "object", "properties": properties, "additionalProperties": false}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func mcpPropertySchema(flag flagSpec) map[string]any {
+	switch flag.Type {
+	case "int":
+		return map[string]any{"type": "integer"}
+	case "number":
+		return map[string]any{"type": "number"}
+	case "bool":
+		return map[string]any{"type": "boolean"}
+	case "date":
+		return map[string]any{"type": "string", "format": "date"}
+	case "datetime":
+		return map[string]any{"type": "string", "format": "date-time"}
+	case "enum":
+		values := make([]any, 0, len(flag.Enum))
+		for _, value := range flag.Enum {
+			values = append(values, value)
+		}
+		return map[string]any{"type": "string", "enum": values}
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
+
+func quoteArgs(args []string) []string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\"'*?[]$") {
+			quoted = append(quoted, strconv.Quote(arg))
+			continue
+		}
+		quoted = append(quoted, arg)
+	}
+	return quoted
+}
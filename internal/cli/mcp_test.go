@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"searchads-cli/internal/appleads"
+)
+
+func runMCPSession(t *testing.T, args []string, requests ...string) []map[string]any {
+	t.Helper()
+
+	var out bytes.Buffer
+	RunMCP(context.Background(), appleads.NewClient(nil), args, strings.NewReader(strings.Join(requests, "\n")+"\n"), &out)
+	responses := make([]map[string]any, 0, len(requests))
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var response map[string]any
+		if err := decoder.Decode(&response); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+func mcpToolNames(t *testing.T, response map[string]any) map[string]map[string]any {
+	t.Helper()
+
+	result, _ := response["result"].(map[string]any)
+	tools, _ := result["tools"].([]any)
+	names := map[string]map[string]any{}
+	for _, raw := range tools {
+		tool, _ := raw.(map[string]any)
+		name, _ := tool["name"].(string)
+		names[name] = tool
+	}
+	return names
+}
+
+func mcpResultText(t *testing.T, response map[string]any) (string, bool) {
+	t.Helper()
+
+	result, ok := response["result"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected a result, got %v", response)
+	}
+	content, _ := result["content"].([]any)
+	if len(content) != 1 {
+		t.Fatalf("expected one content item, got %v", result)
+	}
+	text, _ := content[0].(map[string]any)["text"].(string)
+	isError, _ := result["isError"].(bool)
+	return text, isError
+}
+
+func TestMCPListsReadToolsAndHidesMutationsByDefault(t *testing.T) {
+	responses := runMCPSession(t, nil,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"bogus"}`,
+	)
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 responses (notification gets none), got %d: %v", len(responses), responses)
+	}
+	initResult, _ := responses[0]["result"].(map[string]any)
+	if initResult["protocolVersion"] != "2025-03-26" {
+		t.Fatalf("expected negotiated protocol version, got %v", initResult["protocolVersion"])
+	}
+
+	tools := mcpToolNames(t, responses[1])
+	for _, want := range []string{"campaigns_list", "keywords_find", "product_pages_list", "reports_list", "searchterms_report", "geo_search", "apps_search"} {
+		if _, ok := tools[want]; !ok {
+			t.Fatalf("expected tool %s in %v", want, tools)
+		}
+	}
+	for _, hidden := range []string{"campaigns_pause", "keywords_add", "mcp", "schema"} {
+		if _, ok := tools[hidden]; ok {
+			t.Fatalf("did not expect tool %s without --allowMutations", hidden)
+		}
+	}
+	schema, _ := tools["keywords_find"]["inputSchema"].(map[string]any)
+	properties, _ := schema["properties"].(map[string]any)
+	matchType, _ := properties["matchType"].(map[string]any)
+	items, _ := matchType["items"].(map[string]any)
+	if matchType["type"] != "array" || len(items["enum"].([]any)) != 2 {
+		t.Fatalf("expected matchType to be an enum array, got %v", matchType)
+	}
+
+	rpcErr, _ := responses[2]["error"].(map[string]any)
+	if rpcErr["code"] != float64(jsonRPCMethodNotFound) {
+		t.Fatalf("expected method not found, got %v", responses[2])
+	}
+}
+
+func TestMCPMutationToolsAreDryRunUntilConfirmed(t *testing.T) {
+	responses := runMCPSession(t, []string{"--allowMutations"},
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"keywords_pause","arguments":{"campaignId":1,"adGroupId":2,"keywordId":[3,4]}}}`,
+	)
+	text, isError := mcpResultText(t, responses[0])
+	if isError {
+		t.Fatalf("dry run should not be an error: %s", text)
+	}
+	for _, want := range []string{`"dryRun": true`, "searchads keywords pause --adGroupId 2 --campaignId 1 --keywordId 3 --keywordId 4 --json"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in %s", want, text)
+		}
+	}
+
+	responses = runMCPSession(t, []string{"--allowMutations", "--dryRun"},
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"keywords_pause","arguments":{"campaignId":1,"adGroupId":2,"keywordId":[3],"confirm":true}}}`,
+	)
+	text, _ = mcpResultText(t, responses[0])
+	if !strings.Contains(text, mcpServerDryRunMessage) {
+		t.Fatalf("expected server dry run message, got %s", text)
+	}
+}
+
+func TestMCPToolCallReturnsCommandJSON(t *testing.T) {
+	for _, key := range []string{"OE_ADS_CREDENTIALS_JSON", "OE_ADS_CLIENT_ID", "OE_ADS_TEAM_ID", "OE_ADS_KEY_ID", "OE_ADS_PRIVATE_KEY"} {
+		t.Setenv(key, "")
+	}
+	responses := runMCPSession(t, nil,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"campaigns_list","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"campaigns_list","arguments":{"nope":1}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"campaigns_pause","arguments":{"campaignId":1}}}`,
+	)
+	text, isError := mcpResultText(t, responses[0])
+	if !isError || !strings.Contains(text, `"ok": false`) || !strings.Contains(text, "Missing Apple Ads credentials") {
+		t.Fatalf("expected the command's JSON error, got isError=%v %s", isError, text)
+	}
+	text, isError = mcpResultText(t, responses[1])
+	if !isError || !strings.Contains(text, `Unknown argument "nope"`) {
+		t.Fatalf("expected unknown argument error, got %s", text)
+	}
+	rpcErr, _ := responses[2]["error"].(map[string]any)
+	if rpcErr["code"] != float64(jsonRPCInvalidParams) {
+		t.Fatalf("expected hidden mutation tool to be unknown, got %v", responses[2])
+	}
+}
+
+func TestMCPToolsDoNotTakePaths(t *testing.T) {
+	responses := runMCPSession(t, []string{"--allowMutations"},
+		`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"reports_download","arguments":{"reportId":1,"out":"/tmp/report.csv"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"sov_report","arguments":{"adamId":1,"out":"/tmp/sov"}}}`,
+	)
+	tools := mcpToolNames(t, responses[0])
+	for name, field := range map[string]string{"reports_download": "out", "sov_report": "out", "campaigns_report": "fxRates", "keywords_add": "file", "campaigns_pause": "resultFile"} {
+		tool, ok := tools[name]
+		if !ok {
+			t.Fatalf("expected tool %s", name)
+		}
+		schema, _ := tool["inputSchema"].(map[string]any)
+		properties, _ := schema["properties"].(map[string]any)
+		if _, ok := properties[field]; ok {
+			t.Fatalf("did not expect %s to take %s", name, field)
+		}
+	}
+	for _, response := range responses[1:] {
+		text, isError := mcpResultText(t, response)
+		if !isError || !strings.Contains(text, "only available on the command line") {
+			t.Fatalf("expected the out argument to be refused, got isError=%v %s", isError, text)
+		}
+	}
+}
+
+func TestMCPArgumentValuesCannotAddFlags(t *testing.T) {
+	responses := runMCPSession(t, []string{"--allowMutations"},
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"campaigns_pause","arguments":{"campaign":"--stdin","confirm":true}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"keywords_pause","arguments":{"campaignId":1,"adGroupId":2,"text":["ok","--continue-on-error"],"confirm":true}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"campaigns_get","arguments":{"campaign":" --dryRun"}}}`,
+	)
+	for _, response := range responses {
+		text, isError := mcpResultText(t, response)
+		if !isError || !strings.Contains(text, "cannot start with --") {
+			t.Fatalf("expected the flag-like value to be refused, got isError=%v %s", isError, text)
+		}
+	}
+
+	args, err := actionCommandLine("campaigns", mustActionSpec(t, "campaigns", "pause"), map[string]any{"campaign": "Brand --stdin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasFlag(args, stdinFlag) {
+		t.Fatalf("expected --stdin to stay off, got %v", args)
+	}
+}
+
+func mustActionSpec(t *testing.T, command, action string) actionSpec {
+	t.Helper()
+
+	spec, ok := findCommandSpec(command)
+	if !ok {
+		t.Fatalf("no command %s", command)
+	}
+	found, ok := spec.findActionSpec(action)
+	if !ok {
+		t.Fatalf("no action %s %s", command, action)
+	}
+	return found
+}
@@ -14,7 +14,19 @@ func RunNegatives(ctx context.Context, client *appleads.Client, args []string, j
 		respondCommandError("negatives", jsonOut, err)
 		return
 	}
+	args, err := resolveEntityFlags(ctx, client, args)
+	if err != nil {
+		respondCommandError("negatives", jsonOut, err)
+		return
+	}
 	action := actionFromArgs(args, "list")
+	if isBulkInvocation(args) {
+		switch action {
+		case "remove", "delete", "pause", "activate":
+			runNegativesBulk(ctx, client, args, action, jsonOut)
+			return
+		}
+	}
 	switch action {
 	case "list":
 		runNegativesList(ctx, client, args, jsonOut)
@@ -294,3 +306,36 @@ func resolveNegativeTargets(keywordIDs map[int]struct{}, textFilters map[string]
 	sort.Ints(resolved)
 	return resolved
 }
+
+func runNegativesBulk(ctx context.Context, client *appleads.Client, args []string, action string, jsonOut bool) {
+	targets, err := readBulkInput(args, "negativeKeywordId")
+	if err == nil {
+		targets, err = scopeBulkTargets(targets, args, true, false)
+	}
+	if err != nil {
+		respondCommandError("negatives", jsonOut, err)
+		return
+	}
+	status := "PAUSED"
+	if action == "activate" {
+		status = "ACTIVE"
+	}
+	runBulkTargets("negatives", action, args, jsonOut, targets, func(target bulkTarget) (bulkItemResult, error) {
+		switch {
+		case action == "remove" || action == "delete":
+			if target.AdGroupID > 0 {
+				return bulkItemResult{}, client.DeleteNegativeKeyword(ctx, target.CampaignID, target.AdGroupID, target.ID)
+			}
+			return bulkItemResult{}, client.DeleteCampaignNegativeKeyword(ctx, target.CampaignID, target.ID)
+		case target.AdGroupID > 0:
+			if err := client.UpdateNegativeKeywordStatus(ctx, target.CampaignID, target.AdGroupID, target.ID, status); err != nil {
+				return bulkItemResult{}, err
+			}
+		default:
+			if err := client.UpdateCampaignNegativeKeywordStatus(ctx, target.CampaignID, target.ID, status); err != nil {
+				return bulkItemResult{}, err
+			}
+		}
+		return bulkItemResult{Status: status}, nil
+	})
+}
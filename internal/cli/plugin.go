@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"searchads-cli/internal/appleads"
+)
+
+const pluginPrefix = "searchads-"
+
+// credentialEnvPrefix starts every variable the Apple Ads credentials are
+// read from.
+const credentialEnvPrefix = "OE_ADS_"
+
+var pluginNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// runPlugin runs the searchads-<name> executable from PATH for an unknown
+// command, as git and kubectl do. It returns false when there is no such
+// executable. The child inherits stdio and the environment, plus the current
+// session so it can call the API without minting its own token:
+//
+//	SEARCHADS_BIN, SEARCHADS_PROFILE, SEARCHADS_API_BASE, SEARCHADS_CLIENT_ID, SEARCHADS_ORG_ID,
+//	SEARCHADS_ACCESS_TOKEN, SEARCHADS_TOKEN_EXPIRES_AT
+//
+// In read-only mode, or when campaigns are protected, the plugin gets
+// SEARCHADS_READ_ONLY=1 (read-only only) but no session, and the OE_ADS_*
+// credential variables are removed from what it inherits: with either it
+// could send changes the guards never see. This keeps credentials from the
+// plugin; it does not sandbox it.
+func runPlugin(ctx context.Context, client *appleads.Client, name string, args []string) bool {
+	if !pluginNamePattern.MatchString(name) {
+		return false
+	}
+	path, err := exec.LookPath(pluginPrefix + name)
+	if err != nil {
+		return false
+	}
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdin = stdinSource
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(pluginInheritedEnv(os.Environ()), pluginEnv(ctx, client, name)...)
+	if err := cmd.Run(); err != nil {
+		markCommandFailed()
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			failText("%s failed: %s", pluginPrefix+name, err.Error())
+		}
+	}
+	return true
+}
+
+func pluginEnv(ctx context.Context, client *appleads.Client, name string) []string {
+	env := make([]string, 0, 8)
+	if self, err := os.Executable(); err == nil {
+		env = append(env, "SEARCHADS_BIN="+self)
+	}
+	env = append(env, "SEARCHADS_PROFILE="+configProfile())
+	if configReadOnly() {
+		env = append(env, "SEARCHADS_READ_ONLY=1")
+	}
+	if pluginChangesGuarded() {
+		return env
+	}
+	creds, err := appleads.LoadCredentials()
+	if err != nil || creds == nil || !creds.IsComplete() {
+		return env
+	}
+	session, err := client.Session(ctx)
+	if err != nil {
+		failText("%s: running without an access token: %s", pluginPrefix+name, err.Error())
+		return env
+	}
+	return append(env,
+		"SEARCHADS_API_BASE="+session.APIBase,
+		"SEARCHADS_CLIENT_ID="+creds.ClientID,
+		"SEARCHADS_ORG_ID="+session.OrgID,
+		"SEARCHADS_ACCESS_TOKEN="+session.AccessToken,
+		"SEARCHADS_TOKEN_EXPIRES_AT="+session.ExpiresAt.UTC().Format(time.RFC3339),
+	)
+}
+
+// pluginChangesGuarded reports whether read-only mode or protected campaigns
+// apply, so plugins must not get credentials.
+func pluginChangesGuarded() bool {
+	return configReadOnly() || len(activeConfig.ProtectedCampaigns) > 0
+}
+
+// pluginInheritedEnv is the environment a plugin inherits. When changes are
+// guarded, the Apple Ads credentials are left out.
+func pluginInheritedEnv(environ []string) []string {
+	if !pluginChangesGuarded() {
+		return environ
+	}
+	env := make([]string, 0, len(environ))
+	for _, entry := range environ {
+		if strings.HasPrefix(entry, credentialEnvPrefix) {
+			continue
+		}
+		env = append(env, entry)
+	}
+	return env
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"searchads-cli/internal/appleads"
+)
+
+func TestDispatchRunsPluginsFromPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin fixture is a shell script")
+	}
+	for _, key := range []string{"OE_ADS_CREDENTIALS_JSON", "OE_ADS_CLIENT_ID", "OE_ADS_TEAM_ID", "OE_ADS_KEY_ID", "OE_ADS_PRIVATE_KEY"} {
+		t.Setenv(key, "")
+	}
+	dir := t.TempDir()
+	t.Setenv("SEARCHADS_PROFILE", "team-a")
+	script := "#!/bin/sh\necho \"args=$*\"\necho \"bin=${SEARCHADS_BIN:+set} profile=${SEARCHADS_PROFILE} token=${SEARCHADS_ACCESS_TOKEN:-none}\"\n[ \"$1\" != fail ]\n"
+	if err := os.WriteFile(filepath.Join(dir, "searchads-hello"), []byte(script), 0o755); err != nil {
+		t.Fatalf("write plugin: %v", err)
+	}
+	t.Setenv("PATH", dir)
+
+	var handled bool
+	stdout, _, failed, err := captureCommandOutput(func() {
+		handled = Dispatch(context.Background(), appleads.NewClient(nil), []string{"hello", "world", "--json"})
+	})
+	if err != nil || !handled || failed {
+		t.Fatalf("expected plugin to run, handled=%v failed=%v err=%v", handled, failed, err)
+	}
+	for _, want := range []string{"args=world --json", "bin=set profile=team-a token=none"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q in plugin output %q", want, stdout)
+		}
+	}
+
+	_, _, failed, _ = captureCommandOutput(func() {
+		Dispatch(context.Background(), appleads.NewClient(nil), []string{"hello", "fail"})
+	})
+	if !failed {
+		t.Fatal("expected a non-zero plugin exit to fail the command")
+	}
+
+	for _, name := range []string{"missing", "../hello", "-hello"} {
+		if Dispatch(context.Background(), appleads.NewClient(nil), []string{name}) {
+			t.Fatalf("expected %q not to dispatch", name)
+		}
+	}
+}
+
+func TestPluginsGetNoCredentialsWhenChangesAreGuarded(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin fixture is a shell script")
+	}
+	t.Setenv("OE_ADS_CREDENTIALS_JSON", testCredentialsJSON(t))
+	t.Setenv("OE_ADS_PRIVATE_KEY", "key")
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	projectDir := t.TempDir()
+	t.Chdir(projectDir)
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho \"readOnly=${SEARCHADS_READ_ONLY:-0} token=${SEARCHADS_ACCESS_TOKEN:-none} org=${SEARCHADS_ORG_ID:-none} creds=${OE_ADS_CREDENTIALS_JSON:+set}${OE_ADS_PRIVATE_KEY:+key} path=${PATH:+set}\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "searchads-peek"), []byte(script), 0o755); err != nil {
+		t.Fatalf("write plugin: %v", err)
+	}
+	t.Setenv("PATH", dir)
+	client := appleads.NewClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host == "appleid.apple.com" {
+			return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+	})})
+	runPeek := func() string {
+		stdout, _, failed, err := captureCommandOutput(func() {
+			Dispatch(context.Background(), client, []string{"peek"})
+		})
+		if err != nil || failed {
+			t.Fatalf("expected plugin to run, failed=%v err=%v", failed, err)
+		}
+		return strings.TrimSpace(stdout)
+	}
+
+	if got := runPeek(); got != "readOnly=0 token=token org=123 creds=setkey path=set" {
+		t.Fatalf("expected the session without guards, got %q", got)
+	}
+	t.Setenv("SEARCHADS_READ_ONLY", "1")
+	if got := runPeek(); got != "readOnly=1 token=none org=none creds= path=set" {
+		t.Fatalf("expected no session or credentials in read-only mode, got %q", got)
+	}
+	t.Setenv("SEARCHADS_READ_ONLY", "0")
+	if err := os.WriteFile(filepath.Join(projectDir, projectPolicyName), []byte("protectedCampaigns:\n  - 42\n"), 0o600); err != nil {
+		t.Fatalf("write policy: %v", err)
+	}
+	if got := runPeek(); got != "readOnly=0 token=none org=none creds= path=set" {
+		t.Fatalf("expected no session or credentials with protected campaigns, got %q", got)
+	}
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// reportBreakdown sums report rows per combination of --groupBy values and
+// currency, so each storefront or device can be compared on CPA.
+type reportBreakdown struct {
+	groupBy []string
+	entries map[string]*reportBreakdownEntry
+}
+
+type reportBreakdownEntry struct {
+	dimensions map[string]string
+	currency   string
+	total      reportPeriodTotal
+}
+
+func newReportBreakdown(groupBy []string) *reportBreakdown {
+	return &reportBreakdown{groupBy: groupBy, entries: map[string]*reportBreakdownEntry{}}
+}
+
+func (b *reportBreakdown) add(dimensions map[string]string, currency string, spend float64, taps, impressions, installs int) {
+	if len(b.groupBy) == 0 {
+		return
+	}
+	key := dimensionKey(b.groupBy, dimensions) + "\t" + currency
+	entry := b.entries[key]
+	if entry == nil {
+		entry = &reportBreakdownEntry{dimensions: dimensions, currency: currency}
+		b.entries[key] = entry
+	}
+	entry.total.spend += spend
+	entry.total.taps += taps
+	entry.total.impressions += impressions
+	entry.total.installs += installs
+}
+
+// rows lists the combinations by spend, highest first. cpa is null when
+// nothing installed.
+func (b *reportBreakdown) rows() []map[string]any {
+	entries := make([]*reportBreakdownEntry, 0, len(b.entries))
+	for _, entry := range b.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].total.spend != entries[j].total.spend {
+			return entries[i].total.spend > entries[j].total.spend
+		}
+		left, right := dimensionKey(b.groupBy, entries[i].dimensions), dimensionKey(b.groupBy, entries[j].dimensions)
+		if left != right {
+			return left < right
+		}
+		return entries[i].currency < entries[j].currency
+	})
+	rows := make([]map[string]any, 0, len(entries))
+	for _, entry := range entries {
+		total := entry.total
+		row := map[string]any{
+			"dimensions":  entry.dimensions,
+			"currency":    nullableString(entry.currency),
+			"spend":       total.spend,
+			"taps":        total.taps,
+			"installs":    total.installs,
+			"impressions": total.impressions,
+			"cpa":         nil,
+		}
+		if total.installs > 0 {
+			row["cpa"] = total.spend / float64(total.installs)
+		}
+		addReportRatios(row, total.spend, total.taps, total.impressions, total.installs)
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (b *reportBreakdown) print() {
+	for _, row := range b.rows() {
+		dimensions, _ := row["dimensions"].(map[string]string)
+		spend, _ := row["spend"].(float64)
+		taps, _ := row["taps"].(int)
+		installs, _ := row["installs"].(int)
+		cpa := "-"
+		if value, ok := row["cpa"].(float64); ok {
+			cpa = fmt.Sprintf("%.4f", value)
+		}
+		currency, _ := row["currency"].(string)
+		fmt.Printf("by\t%s\t%.2f\t%d\t%d\t%s\t%s\n", dimensionKey(b.groupBy, dimensions), spend, taps, installs, cpa, firstNonEmptyString(currency, "-"))
+	}
+}
+
+// dimensionKey joins the groupBy values in order, with "-" for a value the
+// report left out.
+func dimensionKey(groupBy []string, dimensions map[string]string) string {
+	values := make([]string, 0, len(groupBy))
+	for _, field := range groupBy {
+		values = append(values, firstNonEmptyString(dimensions[field], "-"))
+	}
+	return strings.Join(values, "/")
+}
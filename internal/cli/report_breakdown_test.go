@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportBreakdownSortsBySpendWithCPA(t *testing.T) {
+	groupBy := []string{"countryOrRegion", "deviceClass"}
+	breakdown := newReportBreakdown(groupBy)
+	breakdown.add(map[string]string{"countryOrRegion": "US", "deviceClass": "IPHONE"}, "USD", 10, 5, 100, 2)
+	breakdown.add(map[string]string{"countryOrRegion": "GB"}, "GBP", 3, 1, 10, 0)
+	breakdown.add(map[string]string{"countryOrRegion": "US", "deviceClass": "IPHONE"}, "USD", 2, 1, 10, 1)
+
+	rows := breakdown.rows()
+	if len(rows) != 2 {
+		t.Fatalf("unexpected breakdown: %v", rows)
+	}
+	if rows[0]["spend"] != 12.0 || rows[0]["cpa"] != 4.0 || rows[0]["currency"] != "USD" {
+		t.Fatalf("unexpected first row: %v", rows[0])
+	}
+	if rows[1]["cpa"] != nil || dimensionKey(groupBy, rows[1]["dimensions"].(map[string]string)) != "GB/-" {
+		t.Fatalf("unexpected second row: %v", rows[1])
+	}
+
+	options, err := reportOptions([]string{"--groupBy", "country,Device", "--groupBy", "countryOrRegion"}, time.Now(), time.Now())
+	if err != nil || strings.Join(options.GroupBy, ",") != "countryOrRegion,deviceClass" {
+		t.Fatalf("unexpected groupBy %v, %v", options.GroupBy, err)
+	}
+	if _, err := reportOptions([]string{"--groupBy", "platform"}, time.Now(), time.Now()); err == nil {
+		t.Fatal("expected an invalid --groupBy error")
+	}
+}
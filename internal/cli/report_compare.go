@@ -0,0 +1,274 @@
+package cli
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"searchads-cli/internal/appleads"
+)
+
+const (
+	compareNew  = "NEW"
+	compareGone = "GONE"
+)
+
+// reportCompareMetrics are the metrics --compare reports deltas for.
+var reportCompareMetrics = []string{"spend", "taps", "installs", "cpt", "ttr", "cr"}
+
+// reportCompareRange reads --compare: "previous" is the same number of days
+// just before the range, "yoy" the same dates a year earlier and
+// "YYYY-MM-DD..YYYY-MM-DD" any other window. ok is false without --compare.
+func reportCompareRange(args []string, startDate, endDate time.Time, granularity string) (time.Time, time.Time, bool, error) {
+	raw := strings.TrimSpace(valueForFlag(args, "--compare"))
+	if raw == "" {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	var compareStart, compareEnd time.Time
+	switch strings.ToLower(raw) {
+	case "previous":
+		days := int(endDate.Sub(startDate).Hours()/24) + 1
+		compareEnd = startDate.AddDate(0, 0, -1)
+		compareStart = compareEnd.AddDate(0, 0, 1-days)
+	case "yoy":
+		compareStart, compareEnd = startDate.AddDate(-1, 0, 0), endDate.AddDate(-1, 0, 0)
+	default:
+		startRaw, endRaw, found := strings.Cut(raw, "..")
+		var startErr, endErr error
+		compareStart, startErr = parseDate(strings.TrimSpace(startRaw))
+		compareEnd, endErr = parseDate(strings.TrimSpace(endRaw))
+		if !found || startErr != nil || endErr != nil {
+			return time.Time{}, time.Time{}, false, fmt.Errorf("Invalid --compare %q. Use previous, yoy or YYYY-MM-DD..YYYY-MM-DD", raw)
+		}
+	}
+	if err := appleads.ValidateReportRange(granularity, compareStart, compareEnd, reportToday()); err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("--compare window: %w", err)
+	}
+	return compareStart, compareEnd, true, nil
+}
+
+// reportMinimums reads --minTaps and --minSpend; negative values count as 0.
+func reportMinimums(args []string) (int, float64) {
+	minTaps := 0
+	if raw := strings.TrimSpace(valueForFlag(args, "--minTaps")); raw != "" {
+		_, _ = fmt.Sscanf(raw, "%d", &minTaps)
+		if minTaps < 0 {
+			minTaps = 0
+		}
+	}
+	minSpend := 0.0
+	if raw := strings.TrimSpace(valueForFlag(args, "--minSpend")); raw != "" {
+		_, _ = fmt.Sscanf(raw, "%f", &minSpend)
+		if minSpend < 0 {
+			minSpend = 0
+		}
+	}
+	return minTaps, minSpend
+}
+
+// comparisonAboveMinimums keeps the compared entities that reach --minTaps and
+// --minSpend in either window, so one that crossed a threshold is reported
+// with both windows' metrics rather than as NEW or GONE.
+func comparisonAboveMinimums(rows []map[string]any, minTaps int, minSpend float64) []map[string]any {
+	if minTaps == 0 && minSpend == 0 {
+		return rows
+	}
+	kept := make([]map[string]any, 0, len(rows))
+	for _, row := range rows {
+		for _, window := range []string{"current", "previous"} {
+			metrics, _ := row[window].(map[string]any)
+			taps, _ := metrics["taps"].(int)
+			spend, _ := metrics["spend"].(float64)
+			if taps >= minTaps && spend >= minSpend {
+				kept = append(kept, row)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+type compareEntity struct {
+	labels   map[string]any
+	current  reportPeriodTotal
+	previous reportPeriodTotal
+}
+
+// compareReportRows sums each window's rows per entity, named by keyFields,
+// and reports absolute and percentage deltas. An entity with activity in only
+// one window is flagged NEW or GONE; one with none in either is left out.
+// Rows are ordered by the size of the spend change.
+func compareReportRows(current, previous []map[string]any, keyFields, labelFields []string) []map[string]any {
+	entities := map[string]*compareEntity{}
+	order := []string{}
+	collect := func(rows []map[string]any, isCurrent bool) {
+		for _, row := range rows {
+			key := compareKey(row, keyFields)
+			entity := entities[key]
+			if entity == nil {
+				entity = &compareEntity{labels: map[string]any{}}
+				for _, field := range labelFields {
+					entity.labels[field] = row[field]
+				}
+				entities[key] = entity
+				order = append(order, key)
+			}
+			total := &entity.previous
+			if isCurrent {
+				total = &entity.current
+			}
+			spend, _ := row["spend"].(float64)
+			taps, _ := row["taps"].(int)
+			impressions, _ := row["impressions"].(int)
+			installs, _ := row["installs"].(int)
+			total.spend += spend
+			total.taps += taps
+			total.impressions += impressions
+			total.installs += installs
+		}
+	}
+	collect(current, true)
+	collect(previous, false)
+
+	type comparison struct {
+		row        map[string]any
+		spendDelta float64
+	}
+	comparisons := make([]comparison, 0, len(entities))
+	for _, key := range order {
+		entity := entities[key]
+		hasCurrent, hasPrevious := entity.current.active(), entity.previous.active()
+		if !hasCurrent && !hasPrevious {
+			continue
+		}
+		now, before := compareMetrics(entity.current), compareMetrics(entity.previous)
+		delta, deltaPct := map[string]any{}, map[string]any{}
+		for _, metric := range reportCompareMetrics {
+			change := now[metric] - before[metric]
+			delta[metric] = change
+			deltaPct[metric] = nil
+			if before[metric] != 0 {
+				deltaPct[metric] = change / math.Abs(before[metric])
+			}
+		}
+		row := map[string]any{}
+		for field, value := range entity.labels {
+			if value != nil {
+				row[field] = value
+			}
+		}
+		row["current"], row["previous"] = metricsAny(now), metricsAny(before)
+		row["delta"], row["deltaPct"] = delta, deltaPct
+		switch {
+		case !hasPrevious:
+			row["flag"] = compareNew
+		case !hasCurrent:
+			row["flag"] = compareGone
+		}
+		comparisons = append(comparisons, comparison{row: row, spendDelta: math.Abs(now["spend"] - before["spend"])})
+	}
+	sort.SliceStable(comparisons, func(i, j int) bool { return comparisons[i].spendDelta > comparisons[j].spendDelta })
+	results := make([]map[string]any, 0, len(comparisons))
+	for _, item := range comparisons {
+		results = append(results, item.row)
+	}
+	return results
+}
+
+func (t reportPeriodTotal) active() bool {
+	return t.spend != 0 || t.taps != 0 || t.impressions != 0 || t.installs != 0
+}
+
+func compareMetrics(total reportPeriodTotal) map[string]float64 {
+	metrics := map[string]float64{
+		"spend":    total.spend,
+		"taps":     float64(total.taps),
+		"installs": float64(total.installs),
+	}
+	if total.taps > 0 {
+		metrics["cpt"] = total.spend / float64(total.taps)
+		metrics["cr"] = float64(total.installs) / float64(total.taps)
+	}
+	if total.impressions > 0 {
+		metrics["ttr"] = float64(total.taps) / float64(total.impressions)
+	}
+	return metrics
+}
+
+func metricsAny(metrics map[string]float64) map[string]any {
+	values := make(map[string]any, len(reportCompareMetrics))
+	for _, metric := range reportCompareMetrics {
+		values[metric] = metrics[metric]
+	}
+	values["taps"], values["installs"] = int(metrics["taps"]), int(metrics["installs"])
+	return values
+}
+
+// compareKey names an entity by its key fields. Text is matched without case.
+func compareKey(row map[string]any, keyFields []string) string {
+	parts := make([]string, 0, len(keyFields))
+	for _, field := range keyFields {
+		parts = append(parts, compareLabel(row[field]))
+	}
+	return strings.ToLower(strings.Join(parts, "\t"))
+}
+
+func compareLabel(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return "-"
+	case map[string]string:
+		fields := make([]string, 0, len(typed))
+		for field := range typed {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		values := make([]string, 0, len(fields))
+		for _, field := range fields {
+			values = append(values, typed[field])
+		}
+		return strings.Join(values, "/")
+	default:
+		return fmt.Sprint(typed)
+	}
+}
+
+// printReportComparison prints one line per entity: its labels, flag, and
+// each metric as previous->current with the percentage change.
+func printReportComparison(compareStart, compareEnd time.Time, rows []map[string]any, labelFields []string) {
+	fmt.Printf("compare range=%s...%s entities=%d\n", compareStart.Format("2006-01-02"), compareEnd.Format("2006-01-02"), len(rows))
+	for _, row := range rows {
+		labels := make([]string, 0, len(labelFields))
+		for _, field := range labelFields {
+			if value, ok := row[field]; ok && value != nil {
+				labels = append(labels, compareLabel(value))
+			}
+		}
+		flag, _ := row["flag"].(string)
+		now, _ := row["current"].(map[string]any)
+		before, _ := row["previous"].(map[string]any)
+		deltaPct, _ := row["deltaPct"].(map[string]any)
+		parts := make([]string, 0, len(reportCompareMetrics))
+		for _, metric := range reportCompareMetrics {
+			pct := "n/a"
+			if value, ok := deltaPct[metric].(float64); ok {
+				pct = fmt.Sprintf("%+.1f%%", value*100)
+			}
+			parts = append(parts, fmt.Sprintf("%s=%s->%s(%s)", metric, compareValue(before[metric]), compareValue(now[metric]), pct))
+		}
+		fmt.Printf("%s\t%s\t%s\n", firstNonEmptyString(flag, "-"), strings.Join(parts, " "), strings.Join(labels, "\t"))
+	}
+}
+
+func compareValue(value any) string {
+	switch typed := value.(type) {
+	case int:
+		return fmt.Sprintf("%d", typed)
+	case float64:
+		return fmt.Sprintf("%.4f", typed)
+	default:
+		return "-"
+	}
+}
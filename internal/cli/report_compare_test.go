@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReportCompareRange(t *testing.T) {
+	day := func(value string) time.Time {
+		parsed, _ := time.Parse("2006-01-02", value)
+		return parsed
+	}
+	start, end := day("2026-10-05"), day("2026-10-11")
+	cases := []struct {
+		value, wantStart, wantEnd string
+	}{
+		{"previous", "2026-09-28", "2026-10-04"},
+		{"yoy", "2025-10-05", "2025-10-11"},
+		{"2026-08-01..2026-08-07", "2026-08-01", "2026-08-07"},
+	}
+	for _, tc := range cases {
+		gotStart, gotEnd, ok, err := reportCompareRange([]string{"--compare", tc.value}, start, end, "DAILY")
+		if err != nil || !ok || gotStart.Format("2006-01-02") != tc.wantStart || gotEnd.Format("2006-01-02") != tc.wantEnd {
+			t.Errorf("--compare %s = %v..%v, %v, %v; want %s..%s", tc.value, gotStart, gotEnd, ok, err, tc.wantStart, tc.wantEnd)
+		}
+	}
+	if _, _, ok, err := reportCompareRange(nil, start, end, "DAILY"); ok || err != nil {
+		t.Fatalf("expected no comparison without --compare, got %v, %v", ok, err)
+	}
+	if _, _, _, err := reportCompareRange([]string{"--compare", "lastweek"}, start, end, "DAILY"); err == nil {
+		t.Fatal("expected an invalid --compare error")
+	}
+}
+
+func TestCompareReportRowsDeltasAndFlags(t *testing.T) {
+	current := []map[string]any{
+		{"adGroupId": 1, "adGroupName": "Brand", "spend": 6.0, "taps": 3, "impressions": 30, "installs": 1},
+		{"adGroupId": 1, "adGroupName": "Brand", "spend": 6.0, "taps": 3, "impressions": 30, "installs": 2},
+		{"adGroupId": 2, "adGroupName": "Generic", "spend": 5.0, "taps": 1, "impressions": 10, "installs": 0},
+		{"adGroupId": 4, "adGroupName": "Idle", "spend": 0.0, "taps": 0, "impressions": 0, "installs": 0},
+	}
+	previous := []map[string]any{
+		{"adGroupId": 1, "adGroupName": "Brand", "spend": 8.0, "taps": 4, "impressions": 80, "installs": 2},
+		{"adGroupId": 3, "adGroupName": "Old", "spend": 1.0, "taps": 1, "impressions": 5, "installs": 0},
+	}
+	rows := compareReportRows(current, previous, []string{"adGroupId", "dimensions"}, []string{"adGroupId", "adGroupName", "dimensions"})
+	if len(rows) != 3 {
+		t.Fatalf("unexpected comparison: %v", rows)
+	}
+	byID := map[int]map[string]any{}
+	for _, row := range rows {
+		byID[row["adGroupId"].(int)] = row
+	}
+	brand := byID[1]
+	if brand["flag"] != nil || brand["delta"].(map[string]any)["spend"] != 4.0 || brand["deltaPct"].(map[string]any)["spend"] != 0.5 {
+		t.Fatalf("unexpected brand row: %v", brand)
+	}
+	if brand["current"].(map[string]any)["taps"] != 6 || brand["current"].(map[string]any)["cpt"] != 2.0 {
+		t.Fatalf("unexpected brand totals: %v", brand["current"])
+	}
+	if byID[2]["flag"] != "NEW" || byID[2]["deltaPct"].(map[string]any)["spend"] != nil {
+		t.Fatalf("unexpected new row: %v", byID[2])
+	}
+	if byID[3]["flag"] != "GONE" || byID[3]["adGroupName"] != "Old" {
+		t.Fatalf("unexpected gone row: %v", byID[3])
+	}
+	if _, ok := brand["dimensions"]; ok {
+		t.Fatalf("nil labels should be left out: %v", brand)
+	}
+	if rows[0]["adGroupId"] != 2 {
+		t.Fatalf("expected the biggest spend change first, got %v", rows[0])
+	}
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+)
+
+type reportPeriodTotal struct {
+	spend       float64
+	taps        int
+	impressions int
+	installs    int
+}
+
+// reportPeriods sums report rows per period key (an hour, day, ISO week or
+// month, as appleads.ReportPeriodKey formats them).
+type reportPeriods map[string]reportPeriodTotal
+
+func (p reportPeriods) add(key string, spend float64, taps, impressions, installs int) {
+	total := p[key]
+	total.spend += spend
+	total.taps += taps
+	total.impressions += impressions
+	total.installs += installs
+	p[key] = total
+}
+
+func (p reportPeriods) merge(other reportPeriods) {
+	for key, total := range other {
+		p.add(key, total.spend, total.taps, total.impressions, total.installs)
+	}
+}
+
+// rows lists the periods in order with the same ratios as report totals.
+func (p reportPeriods) rows() []map[string]any {
+	keys := make([]string, 0, len(p))
+	for key := range p {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	rows := make([]map[string]any, 0, len(keys))
+	for _, key := range keys {
+		total := p[key]
+		row := map[string]any{
+			"period":      key,
+			"spend":       total.spend,
+			"taps":        total.taps,
+			"installs":    total.installs,
+			"impressions": total.impressions,
+		}
+		addReportRatios(row, total.spend, total.taps, total.impressions, total.installs)
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func printReportPeriods(rows []map[string]any) {
+	for _, row := range rows {
+		period, _ := row["period"].(string)
+		spend, _ := row["spend"].(float64)
+		taps, _ := row["taps"].(int)
+		installs, _ := row["installs"].(int)
+		cpt, _ := row["cpt"].(float64)
+		ttr, _ := row["ttr"].(float64)
+		cr, _ := row["cr"].(float64)
+		fmt.Printf("period\t%s\t%.2f\t%d\t%d\t%.4f\t%.4f\t%.4f\n", period, spend, taps, installs, cpt, ttr, cr)
+	}
+}
+
+// printReportTotals prints the totals line of the keyword and search term
+// reports.
+func printReportTotals(totals map[string]any) {
+	taps, _ := totals["taps"].(int)
+	installs, _ := totals["installs"].(int)
+	spend, _ := totals["spend"].(float64)
+	cpt, _ := totals["cpt"].(float64)
+	ttr, _ := totals["ttr"].(float64)
+	fmt.Printf("totals taps=%d installs=%d spend=%.4f cpt=%.4f ttr=%.4f\n", taps, installs, spend, cpt, ttr)
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportOptionsChecksGranularityRange(t *testing.T) {
+	day := func(value string) time.Time {
+		parsed, _ := time.Parse("2006-01-02", value)
+		return parsed
+	}
+	options, err := reportOptions([]string{"--granularity", "weekly", "--timeZone", "UTC"}, day("2026-01-05"), day("2026-03-29"))
+	if err != nil || options.Granularity != "WEEKLY" || options.TimeZone != "UTC" {
+		t.Fatalf("unexpected options %+v, %v", options, err)
+	}
+	if _, err := reportOptions(nil, day("2026-01-01"), day("2026-06-30")); err == nil || !strings.Contains(err.Error(), "90 days") {
+		t.Fatalf("expected a DAILY range error, got %v", err)
+	}
+	if _, err := reportOptions([]string{"--granularity", "YEARLY"}, day("2026-01-01"), day("2026-01-02")); err == nil {
+		t.Fatal("expected an invalid granularity error")
+	}
+}
+
+func TestReportPeriodsMergeInOrder(t *testing.T) {
+	periods := reportPeriods{}
+	periods.add("2026-W42", 10, 5, 100, 1)
+	other := reportPeriods{}
+	other.add("2026-W41", 4, 2, 50, 0)
+	other.add("2026-W42", 2, 1, 20, 1)
+	periods.merge(other)
+
+	rows := periods.rows()
+	if len(rows) != 2 || rows[0]["period"] != "2026-W41" || rows[1]["spend"] != 12.0 || rows[1]["taps"] != 6 || rows[1]["cpt"] != 2.0 {
+		t.Fatalf("unexpected periods: %v", rows)
+	}
+}
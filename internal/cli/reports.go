@@ -135,7 +135,7 @@ func runReportsDownload(ctx context.Context, client *appleads.Client, args []str
 
 	outPath := strings.TrimSpace(valueForFlag(args, "--out"))
 	if outPath == "" {
-		outPath = filepath.Join("reports", "custom", fmt.Sprintf("%d.csv", reportID))
+		outPath = filepath.Join(configOutputRoot(), "custom", fmt.Sprintf("%d.csv", reportID))
 	}
 	if err := os.MkdirAll(filepath.Dir(outPath), 0o700); err != nil {
 		respondCommandError("reports", jsonOut, err)
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"searchads-cli/internal/appleads"
+)
+
+type namedEntity struct {
+	id   int
+	name string
+}
+
+// resolveEntityFlags rewrites --campaign/--adGroup name selectors into the
+// --campaignId/--adGroupId flags the commands already understand. Aliases from
+// the config resolve without an API call.
+func resolveEntityFlags(ctx context.Context, client *appleads.Client, args []string) ([]string, error) {
+	campaignPattern := strings.TrimSpace(valueForFlag(args, "--campaign"))
+	adGroupPattern := strings.TrimSpace(valueForFlag(args, "--adGroup"))
+	if campaignPattern == "" && adGroupPattern == "" {
+		return args, nil
+	}
+
+	resolved := args
+	if campaignPattern != "" {
+		if strings.TrimSpace(valueForFlag(args, "--campaignId")) != "" {
+			return nil, fmt.Errorf("Use either --campaignId or --campaign, not both")
+		}
+		campaignID := 0
+		if alias, ok := activeConfig.CampaignAliases[strings.ToLower(campaignPattern)]; ok {
+			campaignID = alias.CampaignID
+		} else {
+			campaigns, err := client.FetchCampaigns(ctx)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]namedEntity, 0, len(campaigns))
+			for _, campaign := range campaigns {
+				candidates = append(candidates, namedEntity{id: campaign.ID, name: campaign.Name})
+			}
+			if campaignID, err = selectNamedEntity("campaign", "--campaign", campaignPattern, candidates); err != nil {
+				return nil, err
+			}
+		}
+		resolved = replaceFlag(resolved, "--campaign", "--campaignId", strconv.Itoa(campaignID))
+	}
+
+	if adGroupPattern != "" {
+		if strings.TrimSpace(valueForFlag(args, "--adGroupId")) != "" {
+			return nil, fmt.Errorf("Use either --adGroupId or --adGroup, not both")
+		}
+		if alias, ok := activeConfig.AdGroupAliases[strings.ToLower(adGroupPattern)]; ok {
+			raw := strings.TrimSpace(valueForFlag(resolved, "--campaignId"))
+			if raw == "" {
+				resolved = append(resolved, "--campaignId", strconv.Itoa(alias.CampaignID))
+			} else if raw != strconv.Itoa(alias.CampaignID) {
+				return nil, fmt.Errorf("--adGroup %q belongs to campaign %d, not %s", adGroupPattern, alias.CampaignID, raw)
+			}
+			return replaceFlag(resolved, "--adGroup", "--adGroupId", strconv.Itoa(alias.AdGroupID)), nil
+		}
+		campaignID, err := requiredIntFlag(resolved, "--campaignId")
+		if err != nil {
+			return nil, fmt.Errorf("--adGroup requires --campaignId <id> or --campaign <name>")
+		}
+		adGroups, err := client.FetchAdGroups(ctx, campaignID)
+		if err != nil {
+			return nil, err
+		}
+		candidates := make([]namedEntity, 0, len(adGroups))
+		for _, group := range adGroups {
+			candidates = append(candidates, namedEntity{id: group.ID, name: group.Name})
+		}
+		adGroupID, err := selectNamedEntity("ad group", "--adGroup", adGroupPattern, candidates)
+		if err != nil {
+			return nil, err
+		}
+		resolved = replaceFlag(resolved, "--adGroup", "--adGroupId", strconv.Itoa(adGroupID))
+	}
+	return resolved, nil
+}
+
+func selectNamedEntity(kind, flag, pattern string, candidates []namedEntity) (int, error) {
+	matches := matchNamedEntities(pattern, candidates)
+	switch len(matches) {
+	case 0:
+		return 0, fmt.Errorf("No %s matches %s %q", kind, flag, pattern)
+	case 1:
+		return matches[0].id, nil
+	}
+	listed := make([]string, 0, len(matches))
+	for _, match := range matches {
+		listed = append(listed, fmt.Sprintf("%d (%s)", match.id, match.name))
+	}
+	return 0, fmt.Errorf("%s %q is ambiguous; matching %ss: %s", flag, pattern, kind, strings.Join(listed, ", "))
+}
+
+// matchNamedEntities matches case-insensitively. Patterns containing glob
+// metacharacters use path.Match semantics; anything else must match the whole
+// name, or the numeric ID.
+func matchNamedEntities(pattern string, candidates []namedEntity) []namedEntity {
+	normalized := strings.ToLower(strings.TrimSpace(pattern))
+	isGlob := strings.ContainsAny(normalized, "*?[")
+	matches := make([]namedEntity, 0, 2)
+	for _, candidate := range candidates {
+		name := strings.ToLower(strings.TrimSpace(candidate.name))
+		if isGlob {
+			// path.Match never lets * cross a slash; names like "Brand / UK" should still match.
+			if ok, err := path.Match(strings.ReplaceAll(normalized, "/", "\x1f"), strings.ReplaceAll(name, "/", "\x1f")); err == nil && ok {
+				matches = append(matches, candidate)
+			}
+			continue
+		}
+		if name == normalized || strconv.Itoa(candidate.id) == normalized {
+			matches = append(matches, candidate)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].id < matches[j].id })
+	return matches
+}
+
+func replaceFlag(args []string, flag, replacement, value string) []string {
+	out := make([]string, 0, len(args))
+	for idx := 0; idx < len(args); idx++ {
+		if args[idx] == flag && idx+1 < len(args) {
+			out = append(out, replacement, value)
+			idx++
+			continue
+		}
+		out = append(out, args[idx])
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+package cli
+
+import "testing"
+
+func TestMatchNamedEntities(t *testing.T) {
+	t.Parallel()
+
+	candidates := []namedEntity{
+		{id: 3, name: "Brand / UK"},
+		{id: 1, name: "Brand / US"},
+		{id: 2, name: "Generic UK"},
+	}
+
+	if got := matchNamedEntities("generic uk", candidates); len(got) != 1 || got[0].id != 2 {
+		t.Fatalf("expected exact case-insensitive match on id 2, got %+v", got)
+	}
+	if got := matchNamedEntities("Brand*", candidates); len(got) != 2 || got[0].id != 1 || got[1].id != 3 {
+		t.Fatalf("expected glob to match ids 1 and 3 in order, got %+v", got)
+	}
+	if got := matchNamedEntities("brand / u?", candidates); len(got) != 2 {
+		t.Fatalf("expected glob across slash to match both brand campaigns, got %+v", got)
+	}
+	if got := matchNamedEntities("3", candidates); len(got) != 1 || got[0].id != 3 {
+		t.Fatalf("expected numeric pattern to match id 3, got %+v", got)
+	}
+	if got := matchNamedEntities("Brand", candidates); len(got) != 0 {
+		t.Fatalf("expected non-glob partial name not to match, got %+v", got)
+	}
+}
+
+func TestSelectNamedEntityReportsAmbiguity(t *testing.T) {
+	t.Parallel()
+
+	candidates := []namedEntity{{id: 1, name: "Brand US"}, {id: 2, name: "Brand UK"}}
+	_, err := selectNamedEntity("campaign", "--campaign", "brand*", candidates)
+	if err == nil {
+		t.Fatal("expected ambiguity error")
+	}
+	want := `--campaign "brand*" is ambiguous; matching campaigns: 1 (Brand US), 2 (Brand UK)`
+	if err.Error() != want {
+		t.Fatalf("expected %q, got %q", want, err.Error())
+	}
+}
+
+func TestReplaceFlag(t *testing.T) {
+	t.Parallel()
+
+	got := replaceFlag([]string{"list", "--campaign", "Brand*", "--json"}, "--campaign", "--campaignId", "42")
+	want := []string{"list", "--campaignId", "42", "--json"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
@@ -0,0 +1,973 @@
+package cli
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"searchads-cli/internal/appleads"
+)
+
+// commandSpec describes a top-level command for `searchads schema`. These
+// declarations are what tooling and agents read, and Dispatch checks every
+// command line against them, so a flag is accepted only if it is declared.
+type commandSpec struct {
+	Name          string       `json:"name"`
+	Summary       string       `json:"summary"`
+	DefaultAction string       `json:"defaultAction,omitempty"`
+	Actions       []actionSpec `json:"actions"`
+}
+
+type actionSpec struct {
+	Name    string     `json:"name"`
+	Aliases []string   `json:"aliases,omitempty"`
+	Summary string     `json:"summary"`
+	Mutates bool       `json:"mutates"`
+	Flags   []flagSpec `json:"flags"`
+	Output  outputSpec `json:"output"`
+}
+
+type flagSpec struct {
+	Name          string   `json:"name"`
+	Type          string   `json:"type"`
+	Required      bool     `json:"required,omitempty"`
+	Repeatable    bool     `json:"repeatable,omitempty"`
+	CommaList     bool     `json:"commaSeparated,omitempty"`
+	Enum          []string `json:"enum,omitempty"`
+	Default       string   `json:"default,omitempty"`
+	AlternativeTo string   `json:"alternativeTo,omitempty"`
+	Description   string   `json:"description,omitempty"`
+}
+
+// outputSpec describes the JSON printed with --json. Fields are read from the
+// model's json tags so they cannot drift from the structs that are printed.
+type outputSpec struct {
+	Shape  string      `json:"shape"`
+	Model  string      `json:"model,omitempty"`
+	Fields []string    `json:"fields,omitempty"`
+	Items  *outputSpec `json:"items,omitempty"`
+}
+
+const schemaVersion = 1
+
+var globalFlagSpecs = []flagSpec{
+	{Name: "--json", Type: "bool", Description: "Print machine-readable JSON instead of text"},
+	{Name: "--help", Type: "bool", Description: "Print usage"},
+}
+
+// Enum values declared by more than one flag.
+var (
+	campaignStatusEnum    = []string{"ENABLED", "PAUSED"}
+	keywordStatusEnum     = []string{"ACTIVE", "PAUSED"}
+	matchTypeEnum         = []string{"EXACT", "BROAD"}
+	creativeTypeEnum      = []string{"CUSTOM_PRODUCT_PAGE", "DEFAULT_PRODUCT_PAGE", "CREATIVE_SET"}
+	adCreativeTypeEnum    = creativeTypeEnum[:2]
+	creativeStateEnum     = []string{"VALID", "INVALID"}
+	supplySourceEnum      = []string{"APPSTORE_SEARCH_RESULTS", "APPSTORE_SEARCH_TAB", "APPSTORE_TODAY_TAB", "APPSTORE_PRODUCT_PAGES_BROWSE"}
+	budgetTypeEnum        = []string{"DAILY"}
+	productPageStateEnum  = []string{"VISIBLE", "HIDDEN"}
+	eligibilityStateEnum  = []string{"ELIGIBLE", "INELIGIBLE"}
+	eligibleEnum          = []string{"true", "false"}
+	geoEntityEnum         = []string{"COUNTRY", "ADMIN_AREA", "LOCALITY"}
+	assetTypeEnum         = []string{"APP_PREVIEW", "SCREENSHOT"}
+	orientationEnum       = []string{"LANDSCAPE", "PORTRAIT"}
+	budgetOrderStatusEnum = []string{"ACTIVE", "CANCELLED", "COMPLETED", "EXHAUSTED", "INACTIVE"}
+	sovDateRangeEnum      = []string{"LAST_WEEK", "LAST_2_WEEKS", "LAST_4_WEEKS"}
+	customReportStateEnum = []string{"QUEUED", "PENDING", "COMPLETED", "FAILED"}
+)
+
+func intFlag(name string, required bool, description string) flagSpec {
+	return flagSpec{Name: name, Type: "int", Required: required, Description: description}
+}
+
+func repeatableIntFlag(name, description string) flagSpec {
+	return flagSpec{Name: name, Type: "int", Repeatable: true, CommaList: true, Description: description}
+}
+
+func stringFlag(name string, required bool, description string) flagSpec {
+	return flagSpec{Name: name, Type: "string", Required: required, Description: description}
+}
+
+func numberFlag(name string, required bool, description string) flagSpec {
+	return flagSpec{Name: name, Type: "number", Required: required, Description: description}
+}
+
+func boolFlag(name, description string) flagSpec {
+	return flagSpec{Name: name, Type: "bool", Description: description}
+}
+
+func pathFlag(name string, required bool, description string) flagSpec {
+	return flagSpec{Name: name, Type: "path", Required: required, Description: description}
+}
+
+func enumFlag(name string, values []string, defaultValue, description string) flagSpec {
+	return flagSpec{Name: name, Type: "enum", Enum: values, Default: defaultValue, Description: description}
+}
+
+func enumListFlag(name string, values []string, description string) flagSpec {
+	return flagSpec{Name: name, Type: "enum", Enum: values, Repeatable: true, CommaList: true, Description: description}
+}
+
+func listFlag(name, description string) flagSpec {
+	return flagSpec{Name: name, Type: "string", Repeatable: true, CommaList: true, Description: description}
+}
+
+// reportFlags are the date range and request options every report action takes.
+func reportFlags() []flagSpec {
+	return []flagSpec{
+		{Name: "--startDate", Type: "date", Required: true, Description: "YYYY-MM-DD"},
+		{Name: "--endDate", Type: "date", Description: "YYYY-MM-DD; defaults to today"},
+		{Name: "--last", Type: "string", AlternativeTo: "--startDate", Description: "Window ending today, e.g. 14d or 2w; defaults to the configured dateWindow"},
+		enumFlag("--timeZone", appleads.ReportTimeZones(), appleads.DefaultReportTimeZone, "Time zone report days start and end in; ORTZ is the org's time zone"),
+		enumFlag("--granularity", appleads.ReportGranularities(), appleads.ReportGranularityDaily, "Period rows are bucketed by; each allows a different date range"),
+		enumListFlag("--groupBy", appleads.ReportDimensionNames(), "Break results down by country, device, age, gender, adminArea or locality"),
+		stringFlag("--compare", false, "Also report changes against previous, yoy or YYYY-MM-DD..YYYY-MM-DD"),
+	}
+}
+
+// campaignScopeFlags returns --campaignId and its --campaign name selector.
+func campaignScopeFlags(required bool) []flagSpec {
+	return []flagSpec{
+		intFlag("--campaignId", required, "Campaign ID"),
+		{Name: "--campaign", Type: "string", AlternativeTo: "--campaignId", Description: "Campaign name or glob; must match exactly one campaign"},
+	}
+}
+
+func adGroupScopeFlags(required bool) []flagSpec {
+	return []flagSpec{
+		intFlag("--adGroupId", required, "Ad group ID"),
+		{Name: "--adGroup", Type: "string", AlternativeTo: "--adGroupId", Description: "Ad group name or glob within the campaign; must match exactly one ad group"},
+	}
+}
+
+// bulkFlagSpecs are accepted by mutations that go through the bulk runner.
+// With --stdin or --retry the entity ID flag is not required.
+func bulkFlagSpecs() []flagSpec {
+	return []flagSpec{
+		boolFlag(stdinFlag, "Read target IDs from stdin (plain IDs, JSON lines or find --json output)"),
+		pathFlag("--retry", false, "Rerun the items of a --resultFile whose outcome is not ok"),
+		boolFlag("--continue-on-error", "Keep processing after a failed item"),
+		pathFlag("--resultFile", false, "Write per-item outcomes as a JSON array"),
+	}
+}
+
+func flags(groups ...[]flagSpec) []flagSpec {
+	out := make([]flagSpec, 0, 8)
+	for _, group := range groups {
+		out = append(out, group...)
+	}
+	return out
+}
+
+func one(specs ...flagSpec) []flagSpec {
+	return specs
+}
+
+func listOutput(model any) outputSpec {
+	name, fields := modelFields(model)
+	return outputSpec{Shape: "list", Model: name, Fields: fields}
+}
+
+func objectOutput(model any) outputSpec {
+	name, fields := modelFields(model)
+	return outputSpec{Shape: "object", Model: name, Fields: fields}
+}
+
+func fieldsOutput(fields ...string) outputSpec {
+	return outputSpec{Shape: "object", Fields: fields}
+}
+
+func bulkOutput() outputSpec {
+	items := listOutput(bulkItemResult{})
+	return outputSpec{
+		Shape:  "object",
+		Model:  "BulkResults",
+		Fields: []string{"ok", "command", "action", "requested", "succeeded", "skipped", "failed", "results", "resultFile"},
+		Items:  &items,
+	}
+}
+
+func modelFields(model any) (string, []string) {
+	typ := reflect.TypeOf(model)
+	for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	fields := make([]string, 0, typ.NumField())
+	for idx := 0; idx < typ.NumField(); idx++ {
+		field := typ.Field(idx)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		fields = append(fields, name)
+	}
+	name := typ.Name()
+	return strings.ToUpper(name[:1]) + name[1:], fields
+}
+
+func commandSpecs() []commandSpec {
+	return []commandSpec{
+		{
+			Name:    "status",
+			Summary: "Check that credentials are configured and can mint an access token",
+			Actions: []actionSpec{
+				{Name: "status", Summary: "Validate credentials", Flags: []flagSpec{}, Output: outputSpec{Shape: "text"}},
+			},
+		},
+		{
+			Name:          "campaigns",
+			Summary:       "List, create and manage campaigns",
+			DefaultAction: "list",
+			Actions: []actionSpec{
+				{Name: "list", Summary: "List campaigns", Flags: one(wideFlag()), Output: listOutput(appleads.CampaignSummary{})},
+				{Name: "get", Summary: "Show a campaign with budgets, countries, supply sources, dates and serving-state reasons", Flags: campaignScopeFlags(true), Output: objectOutput(appleads.CampaignSummary{})},
+				{Name: "find", Summary: "Filter campaigns", Flags: one(
+					repeatableIntFlag("--campaignId", "Keep only these campaign IDs"),
+					repeatableIntFlag("--adamId", "Keep only campaigns for these app IDs"),
+					enumListFlag("--status", campaignStatusEnum, "Keep only these statuses"),
+					stringFlag("--nameContains", false, "Case-insensitive name substring"),
+					wideFlag(),
+				), Output: listOutput(appleads.CampaignSummary{})},
+				{Name: "create", Summary: "Create a campaign for one App Store placement", Mutates: true, Flags: one(
+					stringFlag("--name", true, "Campaign name"),
+					enumFlag("--placement", appleads.PlacementNames(), appleads.DefaultPlacement, "Where ads serve; sets the channel, supply source and billing"),
+					numberFlag("--budgetAmount", true, "Daily budget amount"),
+					flagSpec{Name: "--budgetCurrency", Type: "string", Default: "GBP", Description: "ISO currency code"},
+					enumFlag("--budgetType", budgetTypeEnum, "DAILY", "Budget type"),
+					enumFlag("--status", campaignStatusEnum, "ENABLED", "Initial status"),
+					intFlag("--adamId", false, "App ID; defaults to OE_ADS_ADAM_ID"),
+					listFlag("--countries", "Countries or regions, e.g. GB,US"),
+					flagSpec{Name: "--startTime", Type: "datetime", Description: "RFC3339 start time"},
+					flagSpec{Name: "--endTime", Type: "datetime", Description: "RFC3339 end time"},
+				), Output: fieldsOutput("ok", "id", "name", "status", "placement")},
+				{Name: "pause", Summary: "Pause a campaign", Mutates: true, Flags: flags(campaignScopeFlags(true), bulkFlagSpecs()), Output: fieldsOutput("ok", "id", "name", "status", "action")},
+				{Name: "activate", Summary: "Enable a campaign", Mutates: true, Flags: flags(campaignScopeFlags(true), bulkFlagSpecs()), Output: fieldsOutput("ok", "id", "name", "status", "action")},
+				{Name: "delete", Summary: "Delete a campaign", Mutates: true, Flags: flags(campaignScopeFlags(true), bulkFlagSpecs()), Output: fieldsOutput("ok", "action", "campaignId")},
+				{Name: "update-budget", Aliases: []string{"set-budget"}, Summary: "Set the daily budget", Mutates: true, Flags: flags(
+					campaignScopeFlags(true),
+					one(
+						numberFlag("--budgetAmount", true, "Daily budget amount"),
+						flagSpec{Name: "--budgetCurrency", Type: "string", Default: "GBP", Description: "ISO currency code"},
+					),
+					bulkFlagSpecs(),
+				), Output: fieldsOutput("ok", "id", "name", "status", "action", "dailyBudgetAmount", "dailyBudgetCurrency")},
+				{Name: "update", Summary: "Change a campaign's name, countries or regions, end date or lifetime budget", Mutates: true, Flags: flags(
+					campaignScopeFlags(true),
+					one(
+						stringFlag("--name", false, "New campaign name"),
+						listFlag("--countries", "Replace the countries or regions (ISO codes)"),
+						listFlag("--addCountries", "Add countries or regions to the current list"),
+						boolFlag("--clearGeoTargeting", "Let Apple drop ad group locations in countries no longer targeted"),
+						flagSpec{Name: "--endTime", Type: "datetime", Description: "New end time (RFC 3339)"},
+						boolFlag("--clearEndTime", "Remove the end time"),
+						numberFlag("--budgetAmount", false, "Lifetime budget amount"),
+						stringFlag("--budgetCurrency", false, "ISO currency code; defaults to the campaign's currency"),
+					),
+				), Output: fieldsOutput("ok", "action", "campaign", "changes")},
+				{Name: "clone", Summary: "Copy a campaign with its ad groups, keywords, negatives and ads into new countries or regions", Mutates: true, Flags: flags(
+					campaignScopeFlags(true),
+					one(
+						listFlag("--countries", "Countries or regions for the copy (ISO codes)"),
+						numberFlag("--bidMultiplier", false, "Scale default and keyword bids, e.g. 0.8"),
+						flagSpec{Name: "--nameTemplate", Type: "string", Default: defaultCloneNameTemplate, Description: "Name for the copy; {name} and {countries} are replaced"},
+						enumFlag("--status", campaignStatusEnum, "PAUSED", "Status of the new campaign"),
+						boolFlag("--dryRun", "Print what would be copied without creating anything"),
+					),
+				), Output: fieldsOutput("ok", "dryRun", "sourceCampaignId", "name", "countries", "placement", "bidMultiplier", "mapping", "errors")},
+				{Name: "pacing", Summary: "Today's spend against daily budgets, with an end-of-day projection", Flags: one(
+					repeatableIntFlag("--campaignId", "Keep only these campaign IDs"),
+					flagSpec{Name: "--underspendBelow", Type: "number", Default: "0.5", Description: "Flag campaigns projected to spend less than this fraction of the daily budget"},
+					boolFlag("--includePaused", "Include paused campaigns"),
+					boolFlag("--flaggedOnly", "Only campaigns flagged CAPPED, WILL_CAP or UNDERSPEND"),
+				), Output: fieldsOutput("ok", "asOf", "dayElapsed", "underspendBelow", "campaigns")},
+				{Name: "report", Summary: "Spend and installs per day or other period across campaigns", Flags: flags(
+					reportFlags(),
+					one(
+						stringFlag("--nameIncludes", false, "Keep campaigns whose name contains text"),
+						stringFlag("--nameExcludes", false, "Drop campaigns whose name contains text"),
+						boolFlag("--includePaused", "Include paused campaigns"),
+						stringFlag("--reportCurrency", false, "Convert all spend to this currency; needs --fxRates"),
+						pathFlag("--fxRates", false, "CSV of currency,rate or date,currency,rate (report currency per unit)"),
+					),
+				), Output: fieldsOutput("ok", "startDate", "endDate", "timeZone", "granularity", "groupBy", "campaignCount", "reportCurrency", "totals", "breakdown", "campaigns", "compare", "comparison")},
+			},
+		},
+		{
+			Name:          "adgroups",
+			Summary:       "List, create and manage ad groups",
+			DefaultAction: "list",
+			Actions: []actionSpec{
+				{Name: "list", Summary: "List ad groups in a campaign", Flags: campaignScopeFlags(true), Output: listOutput(appleads.AdGroupSummary{})},
+				{Name: "find", Summary: "Filter ad groups in a campaign", Flags: flags(campaignScopeFlags(true), one(
+					repeatableIntFlag("--adGroupId", "Keep only these ad group IDs"),
+					enumListFlag("--status", campaignStatusEnum, "Keep only these statuses"),
+					stringFlag("--nameContains", false, "Case-insensitive name substring"),
+				)), Output: listOutput(appleads.AdGroupSummary{})},
+				{Name: "create", Summary: "Create an ad group", Mutates: true, Flags: flags(campaignScopeFlags(true), one(
+					stringFlag("--name", true, "Ad group name"),
+					numberFlag("--defaultBid", true, "Default max CPT bid"),
+					flagSpec{Name: "--currency", Type: "string", Default: "GBP", Description: "ISO currency code"},
+					enumFlag("--status", campaignStatusEnum, "ENABLED", "Initial status"),
+					boolFlag("--automatedKeywordsOptIn", "Enable search match"),
+				)), Output: fieldsOutput("ok", "id", "name", "status", "defaultBid", "currency")},
+				{Name: "pause", Summary: "Pause an ad group", Mutates: true, Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(true), bulkFlagSpecs()), Output: fieldsOutput("ok", "id", "name", "status", "action", "defaultBid", "currency")},
+				{Name: "activate", Summary: "Enable an ad group", Mutates: true, Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(true), bulkFlagSpecs()), Output: fieldsOutput("ok", "id", "name", "status", "action", "defaultBid", "currency")},
+				{Name: "delete", Summary: "Delete an ad group", Mutates: true, Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(true), bulkFlagSpecs()), Output: fieldsOutput("ok", "action", "campaignId", "adGroupId")},
+				{Name: "report", Summary: "Ad group metrics per day or other period", Flags: flags(campaignScopeFlags(true), reportFlags(), adGroupScopeFlags(false)), Output: fieldsOutput("ok", "campaignId", "adGroupCount", "startDate", "endDate", "timeZone", "granularity", "groupBy", "totals", "breakdown", "rows", "compare", "comparison")},
+			},
+		},
+		{
+			Name:          "ads",
+			Summary:       "List, create and manage ads",
+			DefaultAction: "list",
+			Actions: []actionSpec{
+				{Name: "list", Summary: "List ads in an ad group", Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(true)), Output: listOutput(appleads.AdSummary{})},
+				{Name: "find", Summary: "Find ads across the org or within a scope", Flags: flags(campaignScopeFlags(false), adGroupScopeFlags(false), one(
+					enumListFlag("--status", campaignStatusEnum, "Keep only these statuses"),
+					enumListFlag("--creativeType", adCreativeTypeEnum, "Keep only these creative types"),
+					stringFlag("--nameContains", false, "Case-insensitive name substring"),
+					intFlag("--offset", false, "Pagination offset"),
+					intFlag("--limit", false, "Maximum rows"),
+				)), Output: listOutput(appleads.AdSummary{})},
+				{Name: "get", Aliases: []string{"show"}, Summary: "Show one ad", Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(true), one(intFlag("--adId", true, "Ad ID"))), Output: objectOutput(appleads.AdSummary{})},
+				{Name: "create", Summary: "Create an ad from a creative", Mutates: true, Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(true), one(
+					intFlag("--creativeId", true, "Creative ID"),
+					stringFlag("--name", false, "Ad name"),
+					enumFlag("--status", campaignStatusEnum, "ENABLED", "Initial status"),
+				)), Output: fieldsOutput("ok", "action", "ad")},
+				{Name: "update", Summary: "Rename an ad or change its status", Mutates: true, Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(true), one(
+					intFlag("--adId", true, "Ad ID"),
+					stringFlag("--name", false, "New name"),
+					enumFlag("--status", campaignStatusEnum, "", "New status"),
+				), bulkFlagSpecs()), Output: fieldsOutput("ok", "action", "ad")},
+				{Name: "pause", Summary: "Pause an ad", Mutates: true, Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(true), one(intFlag("--adId", true, "Ad ID")), bulkFlagSpecs()), Output: fieldsOutput("ok", "action", "status", "id")},
+				{Name: "activate", Summary: "Enable an ad", Mutates: true, Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(true), one(intFlag("--adId", true, "Ad ID")), bulkFlagSpecs()), Output: fieldsOutput("ok", "action", "status", "id")},
+				{Name: "delete", Aliases: []string{"remove"}, Summary: "Delete an ad", Mutates: true, Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(true), one(intFlag("--adId", true, "Ad ID")), bulkFlagSpecs()), Output: fieldsOutput("ok", "action", "campaignId", "adGroupId", "adId")},
+			},
+		},
+		{
+			Name:          "creatives",
+			Summary:       "List, inspect and create creatives",
+			DefaultAction: "list",
+			Actions: []actionSpec{
+				{Name: "list", Summary: "List creatives", Flags: []flagSpec{}, Output: listOutput(appleads.CreativeSummary{})},
+				{Name: "find", Summary: "Filter creatives", Flags: one(
+					stringFlag("--nameContains", false, "Case-insensitive name substring"),
+					enumListFlag("--type", creativeTypeEnum, "Keep only these creative types"),
+					enumListFlag("--state", creativeStateEnum, "Keep only these states"),
+					repeatableIntFlag("--adamId", "Keep only creatives for these app IDs"),
+					intFlag("--offset", false, "Pagination offset"),
+					intFlag("--limit", false, "Maximum rows"),
+				), Output: listOutput(appleads.CreativeSummary{})},
+				{Name: "get", Aliases: []string{"show"}, Summary: "Show one creative", Flags: one(intFlag("--creativeId", true, "Creative ID")), Output: objectOutput(appleads.CreativeSummary{})},
+				{Name: "create", Summary: "Create a creative", Mutates: true, Flags: one(
+					intFlag("--adamId", true, "App ID"),
+					stringFlag("--name", true, "Creative name"),
+					enumFlag("--type", adCreativeTypeEnum, "CUSTOM_PRODUCT_PAGE", "Creative type"),
+					stringFlag("--productPageId", false, "Custom product page ID; required for CUSTOM_PRODUCT_PAGE"),
+				), Output: fieldsOutput("ok", "action", "creative")},
+			},
+		},
+		{
+			Name:          "product-pages",
+			Summary:       "Inspect custom product pages and supported countries and devices",
+			DefaultAction: "list",
+			Actions: []actionSpec{
+				{Name: "list", Aliases: []string{"find"}, Summary: "List product pages for an app", Flags: one(
+					intFlag("--adamId", true, "App ID"),
+					enumListFlag("--state", productPageStateEnum, "Keep only these states"),
+					stringFlag("--nameContains", false, "Case-insensitive name substring"),
+				), Output: listOutput(appleads.ProductPageSummary{})},
+				{Name: "get", Aliases: []string{"show"}, Summary: "Show one product page", Flags: one(intFlag("--adamId", true, "App ID"), stringFlag("--productPageId", true, "Product page ID")), Output: objectOutput(appleads.ProductPageSummary{})},
+				{Name: "locales", Summary: "List product page locales", Flags: one(intFlag("--adamId", true, "App ID"), stringFlag("--productPageId", true, "Product page ID"), boolFlag("--expand", "Include full locale details")), Output: listOutput(appleads.ProductPageLocaleDetail{})},
+				{Name: "countries", Summary: "List supported countries and regions", Flags: one(listFlag("--code", "Keep only these country codes"), stringFlag("--nameContains", false, "Case-insensitive name substring")), Output: listOutput(appleads.CountryOrRegionSummary{})},
+				{Name: "devices", Aliases: []string{"device-sizes"}, Summary: "List creative device size mappings", Flags: one(listFlag("--deviceClass", "Keep only these device classes, e.g. IPHONE,IPAD"), stringFlag("--nameContains", false, "Case-insensitive name substring")), Output: listOutput(appleads.DeviceSizeMapping{})},
+			},
+		},
+		{
+			Name:          "apps",
+			Summary:       "Search apps and check eligibility",
+			DefaultAction: "search",
+			Actions: []actionSpec{
+				{Name: "search", Aliases: []string{"list"}, Summary: "Search the App Store", Flags: one(
+					stringFlag("--query", true, "Search text"),
+					boolFlag("--returnOwnedApps", "Only apps owned by the org"),
+					intFlag("--limit", false, "Maximum rows"),
+					intFlag("--offset", false, "Pagination offset"),
+				), Output: listOutput(appleads.AppSummary{})},
+				{Name: "get", Aliases: []string{"show"}, Summary: "Show app details", Flags: one(intFlag("--adamId", true, "App ID")), Output: objectOutput(appleads.AppDetail{})},
+				{Name: "localized-details", Aliases: []string{"localized"}, Summary: "Show localized app details", Flags: one(intFlag("--adamId", true, "App ID")), Output: objectOutput(appleads.AppDetail{})},
+				{Name: "eligibility", Summary: "Check app eligibility by country and supply source", Flags: one(
+					repeatableIntFlag("--adamId", "App IDs"),
+					listFlag("--countryOrRegion", "Countries or regions, e.g. GB,US"),
+					enumListFlag("--supplySource", supplySourceEnum, "Keep only these supply sources"),
+					enumListFlag("--state", eligibilityStateEnum, "Keep only these states"),
+					enumFlag("--eligible", eligibleEnum, "", "Keep only eligible or ineligible rows"),
+					stringFlag("--appNameContains", false, "Case-insensitive app name substring"),
+					intFlag("--offset", false, "Pagination offset"),
+					intFlag("--limit", false, "Maximum rows"),
+				), Output: listOutput(appleads.AppEligibilityRecord{})},
+			},
+		},
+		{
+			Name:          "geo",
+			Summary:       "Search geo targeting locations",
+			DefaultAction: "search",
+			Actions: []actionSpec{
+				{Name: "search", Aliases: []string{"find", "list"}, Summary: "Search locations", Flags: one(
+					stringFlag("--query", true, "Search text"),
+					stringFlag("--countryCode", false, "Restrict to a country, e.g. GB"),
+					enumFlag("--entity", geoEntityEnum, "", "Location type"),
+					intFlag("--limit", false, "Maximum rows"),
+				), Output: listOutput(appleads.GeoSearchEntity{})},
+				{Name: "get", Aliases: []string{"show"}, Summary: "Show one location", Flags: one(stringFlag("--geoId", true, "Geo identifier")), Output: outputSpec{Shape: "object"}},
+			},
+		},
+		{
+			Name:          "ad-rejections",
+			Summary:       "Inspect ad rejection reasons and app assets",
+			DefaultAction: "find",
+			Actions: []actionSpec{
+				{Name: "find", Aliases: []string{"list"}, Summary: "Find rejection reasons", Flags: one(
+					repeatableIntFlag("--adamId", "App IDs"),
+					listFlag("--productPageId", "Product page IDs"),
+					listFlag("--reasonType", "Reason types"),
+					listFlag("--reasonLevel", "Reason levels"),
+					listFlag("--reasonCode", "Reason codes"),
+					listFlag("--countryOrRegion", "Countries or regions, e.g. GB,US"),
+					stringFlag("--languageCode", false, "Language, e.g. en-GB"),
+					enumListFlag("--supplySource", supplySourceEnum, "Keep only these supply sources"),
+					stringFlag("--commentContains", false, "Case-insensitive comment substring"),
+					intFlag("--offset", false, "Pagination offset"),
+					intFlag("--limit", false, "Maximum rows"),
+				), Output: listOutput(appleads.AdRejectionSummary{})},
+				{Name: "get", Aliases: []string{"show"}, Summary: "Show one rejection reason", Flags: one(intFlag("--reasonId", true, "Rejection reason ID")), Output: objectOutput(appleads.AdRejectionSummary{})},
+				{Name: "assets", Summary: "List app assets", Flags: one(
+					intFlag("--adamId", true, "App ID"),
+					enumListFlag("--assetType", assetTypeEnum, "Keep only these asset types"),
+					enumListFlag("--orientation", orientationEnum, "Keep only these orientations"),
+					listFlag("--appPreviewDevice", "App preview devices"),
+					listFlag("--assetGenId", "Asset generation IDs"),
+					boolFlag("--includeDeleted", "Include deleted assets"),
+					intFlag("--offset", false, "Pagination offset"),
+					intFlag("--limit", false, "Maximum rows"),
+				), Output: listOutput(appleads.AppAssetSummary{})},
+			},
+		},
+		{
+			Name:          "budget-orders",
+			Summary:       "Manage monthly budget orders for line-of-credit invoicing",
+			DefaultAction: "list",
+			Actions: []actionSpec{
+				{Name: "list", Summary: "List budget orders", Flags: one(
+					enumListFlag("--status", budgetOrderStatusEnum, "Keep only these statuses"),
+				), Output: listOutput(appleads.BudgetOrder{})},
+				{Name: "get", Summary: "Show a budget order", Flags: one(intFlag("--budgetOrderId", true, "Budget order ID")), Output: objectOutput(appleads.BudgetOrder{})},
+				{Name: "create", Summary: "Create a budget order", Mutates: true, Flags: budgetOrderFlagSpecs(true), Output: fieldsOutput("ok", "action", "budgetOrder")},
+				{Name: "update", Summary: "Change a budget order; only the flags given are sent", Mutates: true, Flags: flags(
+					one(intFlag("--budgetOrderId", true, "Budget order ID")),
+					budgetOrderFlagSpecs(false),
+				), Output: fieldsOutput("ok", "action", "budgetOrder")},
+			},
+		},
+		{
+			Name:          "keywords",
+			Summary:       "List, report on and manage targeting keywords",
+			DefaultAction: "list",
+			Actions: []actionSpec{
+				{Name: "list", Summary: "List keywords in an ad group", Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(true)), Output: listOutput(appleads.KeywordSummary{})},
+				{Name: "find", Summary: "Filter keywords in an ad group", Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(true), keywordFilterFlags()), Output: listOutput(appleads.KeywordSummary{})},
+				{Name: "report", Summary: "Keyword metrics for a date range", Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(true), reportFlags(), one(
+					intFlag("--minTaps", false, "Drop keywords with fewer taps"),
+					numberFlag("--minSpend", false, "Drop keywords with less spend"),
+				), keywordFilterFlags()), Output: fieldsOutput("ok", "campaignId", "adGroupId", "startDate", "endDate", "timeZone", "granularity", "groupBy", "totals", "periods", "breakdown", "rows", "compare", "comparison")},
+				{Name: "add", Summary: "Add keywords, updating ones that already exist with the same match type", Mutates: true, Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(true), one(
+					flagSpec{Name: "--text", Type: "string", Repeatable: true, Description: "Keyword text"},
+					pathFlag("--file", false, "CSV or JSON file of keywords (text, matchType, status, bidAmount, currency)"),
+					enumFlag("--matchType", matchTypeEnum, "BROAD", "Match type"),
+					enumFlag("--status", keywordStatusEnum, "ACTIVE", "Keyword status"),
+					numberFlag("--bidAmount", false, "Max CPT bid"),
+					stringFlag("--currency", false, "ISO currency code for --bidAmount"),
+					pathFlag("--retry", false, "Rerun the rows of a --resultFile whose outcome is not ok"),
+					boolFlag("--continue-on-error", "Keep sending batches after a failed row"),
+					pathFlag("--resultFile", false, "Write per-row outcomes as a JSON array"),
+				)), Output: bulkOutput()},
+				keywordMutationSpec("pause", nil, "Pause keywords"),
+				keywordMutationSpec("activate", nil, "Activate keywords"),
+				keywordMutationSpec("remove", []string{"delete"}, "Delete keywords"),
+				{Name: "rebid", Summary: "Set the bid on keywords", Mutates: true, Flags: flags(keywordMutationSpec("rebid", nil, "").Flags, one(
+					numberFlag("--bidAmount", true, "New max CPT bid"),
+					stringFlag("--currency", false, "ISO currency code"),
+				)), Output: bulkOutput()},
+				{Name: "pause-by-text", Summary: "Pause keywords by exact text", Mutates: true, Flags: keywordMutationSpec("pause", nil, "").Flags, Output: bulkOutput()},
+			},
+		},
+		{
+			Name:          "searchterms",
+			Summary:       "Search term metrics",
+			DefaultAction: "report",
+			Actions: []actionSpec{
+				{Name: "report", Summary: "Search term metrics for a date range", Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(false), reportFlags(), one(
+					intFlag("--minTaps", false, "Drop terms with fewer taps"),
+					numberFlag("--minSpend", false, "Drop terms with less spend"),
+				)), Output: fieldsOutput("ok", "campaignId", "adGroupCount", "startDate", "endDate", "timeZone", "granularity", "groupBy", "totals", "periods", "breakdown", "rows", "compare", "comparison")},
+			},
+		},
+		{
+			Name:          "negatives",
+			Summary:       "Manage campaign and ad group negative keywords",
+			DefaultAction: "list",
+			Actions: []actionSpec{
+				{Name: "list", Summary: "List negative keywords; ad group scope when --adGroupId is set", Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(false)), Output: listOutput(appleads.NegativeKeywordSummary{})},
+				{Name: "add", Summary: "Add negative keywords", Mutates: true, Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(false), one(
+					flagSpec{Name: "--text", Type: "string", Required: true, Repeatable: true, Description: "Keyword text"},
+					enumFlag("--matchType", matchTypeEnum, "EXACT", "Match type"),
+				)), Output: fieldsOutput("ok", "scope", "campaignId", "adGroupId", "added", "matchType")},
+				negativeMutationSpec("remove", []string{"delete"}, "Delete negative keywords", fieldsOutput("ok", "scope", "campaignId", "adGroupId", "removed")),
+				negativeMutationSpec("pause", nil, "Pause negative keywords", fieldsOutput("ok", "scope", "campaignId", "adGroupId", "action", "status", "affected")),
+				negativeMutationSpec("activate", nil, "Activate negative keywords", fieldsOutput("ok", "scope", "campaignId", "adGroupId", "action", "status", "affected")),
+			},
+		},
+		{
+			Name:    "sov-report",
+			Summary: "Request an impression share report and write CSV and JSON outputs",
+			Actions: []actionSpec{
+				{Name: "sov-report", Summary: "Generate a share of voice report", Mutates: true, Flags: one(
+					intFlag("--adamId", true, "App ID"),
+					flagSpec{Name: "--appId", Type: "int", AlternativeTo: "--adamId", Description: "Alias for --adamId"},
+					listFlag("--country", "Countries or regions, e.g. GB,US"),
+					enumFlag("--dateRange", sovDateRangeEnum, "LAST_4_WEEKS", "Report window"),
+					stringFlag("--name", false, "Report name"),
+					pathFlag("--out", false, "Output directory"),
+				), Output: fieldsOutput("ok", "reportId", "state", "csvPath", "normalizedJsonPath", "decisionTablePath", "rowCount")},
+			},
+		},
+		{
+			Name:          "reports",
+			Summary:       "List and download custom reports",
+			DefaultAction: "list",
+			Actions: []actionSpec{
+				{Name: "list", Summary: "List custom reports", Flags: one(
+					enumListFlag("--state", customReportStateEnum, "Keep only these states"),
+					stringFlag("--nameContains", false, "Case-insensitive name substring"),
+					intFlag("--limit", false, "Maximum rows"),
+				), Output: listOutput(appleads.CustomReport{})},
+				{Name: "get", Aliases: []string{"show"}, Summary: "Show one custom report", Flags: one(intFlag("--reportId", true, "Report ID")), Output: objectOutput(appleads.CustomReport{})},
+				{Name: "download", Summary: "Download a completed report", Flags: one(intFlag("--reportId", true, "Report ID"), pathFlag("--out", false, "Output file")), Output: fieldsOutput("ok", "reportId", "state", "bytes", "out")},
+			},
+		},
+		{
+			Name:    "mcp",
+			Summary: "Serve commands as Model Context Protocol tools over stdio",
+			Actions: []actionSpec{
+				{Name: "mcp", Summary: "Run the MCP server until stdin closes", Flags: one(
+					boolFlag("--allowMutations", "List mutation tools; they still need confirm: true"),
+					boolFlag("--dryRun", "Never execute mutations, even when confirmed"),
+				), Output: outputSpec{Shape: "stream"}},
+			},
+		},
+		{
+			Name:    "api",
+			Summary: "Send an authenticated request to any Apple Ads endpoint: searchads api <METHOD> <path>",
+			Actions: []actionSpec{
+				{Name: "api", Summary: "Run one request and pretty-print the JSON response", Mutates: true, Flags: one(
+					stringFlag("--data", false, "JSON request body, @file.json or @- for stdin"),
+					boolFlag("--paginate", "Follow offset/limit pagination and merge every page's data"),
+				), Output: outputSpec{Shape: "object"}},
+			},
+		},
+		{
+			Name:    "shell",
+			Summary: "Interactive prompt with a campaign/ad group context, history and tab completion",
+			Actions: []actionSpec{
+				{Name: "shell", Summary: "Read commands until exit; use campaign/adgroup set --campaignId/--adGroupId for later commands", Flags: []flagSpec{}, Output: outputSpec{Shape: "stream"}},
+			},
+		},
+		{
+			Name:    "serve",
+			Summary: "Serve read commands as a local REST API",
+			Actions: []actionSpec{
+				{Name: "serve", Summary: "Run the HTTP server until interrupted", Flags: one(
+					flagSpec{Name: "--addr", Type: "string", Default: defaultServeAddr, Description: "Listen address"},
+					stringFlag("--token", false, "Bearer token callers must send; defaults to SEARCHADS_SERVE_TOKEN or a generated token"),
+					boolFlag("--allowMutations", "Allow POST /cli/{command}/{action} for mutating actions"),
+					flagSpec{Name: "--rateLimit", Type: "number", Default: "10", Description: "Apple Ads requests per second across all callers; 0 disables"},
+				), Output: outputSpec{Shape: "stream"}},
+			},
+		},
+		{
+			Name:    "audit",
+			Summary: "Query the local log of every change the CLI sent to the API",
+			Actions: []actionSpec{
+				{Name: "log", Summary: "List audit records, oldest first", Flags: one(
+					stringFlag("--since", false, "Only records from this date, RFC 3339 time or age (7d, 12h)"),
+					stringFlag("--entity", false, "Only records touching this entity, e.g. campaign:123 or a bare ID"),
+				), Output: fieldsOutput("ok", "file", "count", "records")},
+			},
+		},
+		{
+			Name:    "undo",
+			Summary: "Reverse recorded changes from the audit log: searchads undo <auditId>... | --last N",
+			Actions: []actionSpec{
+				{Name: "undo", Summary: "Restore prior field values and re-add removed keywords and negatives", Mutates: true, Flags: one(
+					intFlag("--last", false, "Undo the last N commands that made changes instead of naming audit IDs"),
+					boolFlag("--dryRun", "Print the inverse requests without sending them"),
+					boolFlag("--force", "Restore even when the entity changed again since"),
+				), Output: fieldsOutput("ok", "dryRun", "requested", "undone", "planned", "skipped", "failed", "results")},
+			},
+		},
+		{
+			Name:    "config",
+			Summary: "Show configured defaults from .searchads.yaml, the user config and SEARCHADS_* env",
+			Actions: []actionSpec{
+				{Name: "show", Summary: "Print the effective value and source of each setting", Flags: []flagSpec{}, Output: fieldsOutput("ok", "projectFile", "userFile", "values", "campaignAliases", "adGroupAliases", "policyFiles", "protectedCampaigns")},
+			},
+		},
+		{
+			Name:    "schema",
+			Summary: "Describe every command, action and flag",
+			Actions: []actionSpec{
+				{Name: "schema", Summary: "Print this schema", Flags: []flagSpec{}, Output: fieldsOutput("version", "globalFlags", "commands")},
+			},
+		},
+	}
+}
+
+func budgetOrderFlagSpecs(create bool) []flagSpec {
+	return one(
+		stringFlag("--name", create, "Order name"),
+		stringFlag("--orderNumber", create, "Purchase order number"),
+		flagSpec{Name: "--startDate", Type: "date", Required: create, Description: "First day, YYYY-MM-DD"},
+		flagSpec{Name: "--endDate", Type: "date", Required: create, Description: "Last day, YYYY-MM-DD"},
+		numberFlag("--budgetAmount", create, "Order budget"),
+		budgetOrderCurrencyFlag(create),
+		stringFlag("--clientName", create, "Client the order is for"),
+		stringFlag("--primaryBuyerName", create, "Primary buyer name"),
+		stringFlag("--primaryBuyerEmail", create, "Primary buyer email"),
+		stringFlag("--billingEmail", create, "Invoice email"),
+		enumListFlag("--supplySources", supplySourceEnum, "Placements the order funds; create defaults to APPSTORE_SEARCH_RESULTS"),
+	)
+}
+
+func budgetOrderCurrencyFlag(create bool) flagSpec {
+	if create {
+		return flagSpec{Name: "--budgetCurrency", Type: "string", Default: "GBP", Description: "ISO currency code"}
+	}
+	return stringFlag("--budgetCurrency", false, "ISO currency code; defaults to the order's currency")
+}
+
+func wideFlag() flagSpec {
+	return boolFlag("--wide", "Text output adds serving status, daily budget, countries, supply sources, dates and serving-state reasons")
+}
+
+func keywordFilterFlags() []flagSpec {
+	return one(
+		repeatableIntFlag("--keywordId", "Keep only these keyword IDs"),
+		flagSpec{Name: "--text", Type: "string", Repeatable: true, Description: "Exact keyword text (case-insensitive)"},
+		stringFlag("--textContains", false, "Case-insensitive text substring"),
+		enumListFlag("--status", keywordStatusEnum, "Keep only these statuses"),
+		enumListFlag("--matchType", matchTypeEnum, "Keep only these match types"),
+	)
+}
+
+func keywordMutationSpec(name string, aliases []string, summary string) actionSpec {
+	return actionSpec{
+		Name:    name,
+		Aliases: aliases,
+		Summary: summary,
+		Mutates: true,
+		Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(true), one(
+			flagSpec{Name: "--keywordId", Type: "int", Repeatable: true, Description: "Keyword ID; --keywordId or --text is required"},
+			flagSpec{Name: "--text", Type: "string", Repeatable: true, Description: "Exact keyword text"},
+		), bulkFlagSpecs()),
+		Output: bulkOutput(),
+	}
+}
+
+func negativeMutationSpec(name string, aliases []string, summary string, output outputSpec) actionSpec {
+	return actionSpec{
+		Name:    name,
+		Aliases: aliases,
+		Summary: summary,
+		Mutates: true,
+		Flags: flags(campaignScopeFlags(true), adGroupScopeFlags(false), one(
+			flagSpec{Name: "--negativeKeywordId", Type: "int", Repeatable: true, Description: "Negative keyword ID; --negativeKeywordId or --text is required"},
+			flagSpec{Name: "--text", Type: "string", Repeatable: true, Description: "Exact keyword text"},
+		), bulkFlagSpecs()),
+		Output: output,
+	}
+}
+
+func findCommandSpec(name string) (commandSpec, bool) {
+	for _, spec := range commandSpecs() {
+		if spec.Name == name {
+			return spec, true
+		}
+	}
+	return commandSpec{}, false
+}
+
+// findActionSpec resolves an action name or alias.
+func (spec commandSpec) findActionSpec(action string) (actionSpec, bool) {
+	for _, candidate := range spec.Actions {
+		if candidate.Name == action {
+			return candidate, true
+		}
+		for _, alias := range candidate.Aliases {
+			if alias == action {
+				return candidate, true
+			}
+		}
+	}
+	return actionSpec{}, false
+}
+
+// commandActionSpec finds the action a command line will run.
+func commandActionSpec(args []string) (actionSpec, bool) {
+	if len(args) == 0 {
+		return actionSpec{}, false
+	}
+	spec, ok := findCommandSpec(strings.ToLower(args[0]))
+	if !ok {
+		return actionSpec{}, false
+	}
+	if len(spec.Actions) == 1 {
+		return spec.Actions[0], true
+	}
+	return spec.findActionSpec(actionFromArgs(args[1:], spec.DefaultAction))
+}
+
+func actionHasFlag(action actionSpec, name string) bool {
+	for _, flag := range action.Flags {
+		if flag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkActionArgs reads a command line with its action's flag specs, so the
+// schema is also what the CLI accepts: every flag must be declared on the
+// action or globally, and each flag that is not a bool takes the next
+// argument as its value. Plugins and unknown actions are left to their
+// runners.
+func checkActionArgs(args []string) error {
+	action, ok := commandActionSpec(args)
+	if !ok {
+		return nil
+	}
+	specByName := make(map[string]flagSpec, len(action.Flags)+len(globalFlagSpecs))
+	for _, flag := range globalFlagSpecs {
+		specByName[flag.Name] = flag
+	}
+	for _, flag := range action.Flags {
+		specByName[flag.Name] = flag
+	}
+	command := strings.ToLower(args[0])
+	for idx := 1; idx < len(args); idx++ {
+		arg := args[idx]
+		if !strings.HasPrefix(arg, "--") {
+			continue
+		}
+		flag, ok := specByName[arg]
+		if !ok {
+			if command == action.Name {
+				return fmt.Errorf("Unknown flag %s for %s", arg, command)
+			}
+			return fmt.Errorf("Unknown flag %s for %s %s", arg, command, action.Name)
+		}
+		if flag.Type == "bool" {
+			continue
+		}
+		if idx+1 >= len(args) || strings.HasPrefix(args[idx+1], "--") {
+			return fmt.Errorf("Missing value for %s", arg)
+		}
+		idx++
+	}
+	return nil
+}
+
+func RunSchema(args []string, jsonOut bool) {
+	specs := commandSpecs()
+	if name := strings.TrimSpace(firstPositional(args)); name != "" {
+		spec, ok := findCommandSpec(name)
+		if !ok {
+			respondCommandError("schema", jsonOut, fmt.Errorf("Unknown command: %s", name))
+			return
+		}
+		specs = []commandSpec{spec}
+	}
+	if jsonOut {
+		printJSON(map[string]any{"version": schemaVersion, "globalFlags": globalFlagSpecs, "commands": specs})
+		return
+	}
+	for _, spec := range specs {
+		fmt.Printf("%s\t%s\n", spec.Name, spec.Summary)
+		for _, action := range spec.Actions {
+			names := append([]string{action.Name}, action.Aliases...)
+			fmt.Printf("  %s\t%s\n", strings.Join(names, "|"), action.Summary)
+			for _, flag := range action.Flags {
+				fmt.Printf("    %s\n", formatFlagSpec(flag))
+			}
+		}
+	}
+}
+
+func formatFlagSpec(flag flagSpec) string {
+	value := flag.Type
+	if len(flag.Enum) > 0 {
+		value = strings.Join(flag.Enum, "|")
+	}
+	line := flag.Name
+	if flag.Type != "bool" {
+		line += " <" + value + ">"
+	}
+	if flag.Repeatable {
+		line += " ..."
+	}
+	if !flag.Required {
+		line = "[" + line + "]"
+	}
+	if flag.Description != "" {
+		line += "\t" + flag.Description
+	}
+	return line
+}
+
+// Commands that remote callers (MCP tools, serve) cannot run.
+var remoteExcludedCommands = map[string]struct{}{
+	"api":    {},
+	"config": {},
+	"mcp":    {},
+	"schema": {},
+	"serve":  {},
+	"shell":  {},
+	"undo":   {},
+}
+
+// Flags that cannot be used by remote callers (MCP, serve); stdin is not
+// theirs to read.
+var remoteExcludedFlags = map[string]struct{}{
+	stdinFlag: {},
+}
+
+// remoteExcludedFlag reports whether remote callers are kept from a flag.
+// Besides remoteExcludedFlags that is every path flag, which would let them
+// read or write any file the CLI user can; outputs go to the default location
+// under the output root instead.
+func remoteExcludedFlag(flag flagSpec) bool {
+	_, excluded := remoteExcludedFlags[flag.Name]
+	return excluded || flag.Type == "path"
+}
+
+// actionCommandLine turns named arguments (flag names without "--") back into
+// a command line using the action's flag specs, so remote callers accept
+// exactly what the CLI accepts. Values that look like flags are refused, so
+// an argument cannot switch on a flag the caller was not given, such as
+// --stdin. Arguments named in ignore are skipped.
+func actionCommandLine(command string, action actionSpec, arguments map[string]any, ignore ...string) ([]string, error) {
+	args := []string{command}
+	if action.Name != command {
+		args = append(args, action.Name)
+	}
+	specByName := make(map[string]flagSpec, len(action.Flags))
+	for _, flag := range action.Flags {
+		specByName[strings.TrimPrefix(flag.Name, "--")] = flag
+	}
+	skipped := make(map[string]struct{}, len(ignore))
+	for _, name := range ignore {
+		skipped[name] = struct{}{}
+	}
+
+	names := make([]string, 0, len(arguments))
+	for name := range arguments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := skipped[name]; ok {
+			continue
+		}
+		flag, ok := specByName[name]
+		if !ok {
+			return nil, fmt.Errorf("Unknown argument %q for %s %s", name, command, action.Name)
+		}
+		if remoteExcludedFlag(flag) {
+			return nil, fmt.Errorf("Argument %q is only available on the command line", name)
+		}
+		values, err := flagArgumentValues(flag, arguments[name])
+		if err != nil {
+			return nil, err
+		}
+		if flag.Type == "bool" {
+			if len(values) > 0 && values[0] == "true" {
+				args = append(args, flag.Name)
+			}
+			continue
+		}
+		for _, value := range values {
+			args = append(args, flag.Name, value)
+		}
+	}
+	return append(args, "--json"), nil
+}
+
+func flagArgumentValues(flag flagSpec, raw any) ([]string, error) {
+	name := strings.TrimPrefix(flag.Name, "--")
+	items := []any{raw}
+	if list, ok := raw.([]any); ok {
+		if !flag.Repeatable && len(list) != 1 {
+			return nil, fmt.Errorf("%s takes a single value", name)
+		}
+		items = list
+	}
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		switch typed := item.(type) {
+		case bool:
+			if flag.Type != "bool" {
+				return nil, fmt.Errorf("%s expects a %s", name, flag.Type)
+			}
+			values = append(values, strconv.FormatBool(typed))
+		case float64:
+			if flag.Type == "int" && typed != float64(int64(typed)) {
+				return nil, fmt.Errorf("%s expects an integer", name)
+			}
+			values = append(values, strconv.FormatFloat(typed, 'f', -1, 64))
+		case string:
+			if flag.Type == "bool" {
+				parsed, err := strconv.ParseBool(typed)
+				if err != nil {
+					return nil, fmt.Errorf("%s expects a boolean", name)
+				}
+				values = append(values, strconv.FormatBool(parsed))
+				continue
+			}
+			if strings.HasPrefix(strings.TrimSpace(typed), "--") {
+				// The CLI would read the value as a flag of its own.
+				return nil, fmt.Errorf("%s cannot start with --", name)
+			}
+			values = append(values, typed)
+		case nil:
+			continue
+		default:
+			return nil, fmt.Errorf("%s has an unsupported value", name)
+		}
+	}
+	return values, nil
+}
+
+func firstPositional(args []string) string {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return ""
+	}
+	return args[0]
+}
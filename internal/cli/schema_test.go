@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSchemaSpecsAreWellFormed(t *testing.T) {
+	t.Parallel()
+
+	types := map[string]struct{}{"int": {}, "number": {}, "string": {}, "bool": {}, "date": {}, "datetime": {}, "path": {}, "enum": {}}
+	seenCommands := map[string]struct{}{}
+	for _, spec := range commandSpecs() {
+		if _, dup := seenCommands[spec.Name]; dup {
+			t.Fatalf("duplicate command %s", spec.Name)
+		}
+		seenCommands[spec.Name] = struct{}{}
+		if spec.DefaultAction != "" {
+			if _, ok := spec.findActionSpec(spec.DefaultAction); !ok {
+				t.Errorf("%s default action %s is not declared", spec.Name, spec.DefaultAction)
+			}
+		}
+		seenActions := map[string]struct{}{}
+		for _, action := range spec.Actions {
+			for _, name := range append([]string{action.Name}, action.Aliases...) {
+				if _, dup := seenActions[name]; dup {
+					t.Errorf("%s declares action %s twice", spec.Name, name)
+				}
+				seenActions[name] = struct{}{}
+			}
+			if action.Output.Shape == "" {
+				t.Errorf("%s %s has no output shape", spec.Name, action.Name)
+			}
+			seenFlags := map[string]struct{}{}
+			for _, flag := range action.Flags {
+				if _, dup := seenFlags[flag.Name]; dup {
+					t.Errorf("%s %s declares %s twice", spec.Name, action.Name, flag.Name)
+				}
+				seenFlags[flag.Name] = struct{}{}
+				if _, ok := types[flag.Type]; !ok {
+					t.Errorf("%s %s %s has unknown type %q", spec.Name, action.Name, flag.Name, flag.Type)
+				}
+				if (flag.Type == "enum") != (len(flag.Enum) > 0) {
+					t.Errorf("%s %s %s: enum type and values must go together", spec.Name, action.Name, flag.Name)
+				}
+			}
+		}
+	}
+}
+
+func TestCheckActionArgsReadsFlagsFromTheSchema(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		{"campaigns", "--json"},
+		{"campaigns", "get", "--campaign", "Brand*", "--json"},
+		{"keywords", "pause", "--campaignId", "1", "--adGroupId", "2", "--stdin", "--continue-on-error"},
+		{"undo", "12", "13", "--dryRun"},
+		{"campaigns", "bogus", "--anything"},
+		{"searchads-plugin", "--anything"},
+	} {
+		if err := checkActionArgs(args); err != nil {
+			t.Errorf("%v: unexpected error %v", args, err)
+		}
+	}
+
+	cases := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"campaigns", "list", "--campaignId", "1"}, "Unknown flag --campaignId for campaigns list"},
+		{[]string{"sov-report", "--adamId", "1", "--status", "PAUSED"}, "Unknown flag --status for sov-report"},
+		{[]string{"campaigns", "get", "--campaignId"}, "Missing value for --campaignId"},
+		{[]string{"campaigns", "pause", "--campaign", "--stdin"}, "Missing value for --campaign"},
+	}
+	for _, tc := range cases {
+		err := checkActionArgs(tc.args)
+		if err == nil || err.Error() != tc.want {
+			t.Errorf("%v: expected %q, got %v", tc.args, tc.want, err)
+		}
+	}
+}
+
+func TestModelFieldsUseJSONTags(t *testing.T) {
+	t.Parallel()
+
+	name, fields := modelFields(bulkItemResult{})
+	if name != "BulkItemResult" {
+		t.Fatalf("unexpected model name %s", name)
+	}
+	if strings.Join(fields[:4], ",") != "id,campaignId,adGroupId,outcome" {
+		t.Fatalf("unexpected fields %v", fields)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"searchads-cli/internal/appleads"
 )
@@ -14,38 +15,116 @@ func RunSearchTerms(ctx context.Context, client *appleads.Client, args []string,
 		respondCommandError("searchterms", jsonOut, err)
 		return
 	}
+	args, err := resolveEntityFlags(ctx, client, args)
+	if err != nil {
+		respondCommandError("searchterms", jsonOut, err)
+		return
+	}
 	campaignID, err := requiredIntFlag(args, "--campaignId")
 	if err != nil {
 		respondCommandError("searchterms", jsonOut, err)
 		return
 	}
-	startRaw := valueForFlag(args, "--startDate")
-	endRaw := valueForFlag(args, "--endDate")
-	startDate, err := parseDate(startRaw)
+	startDate, endDate, err := reportDateRange(args)
 	if err != nil {
-		respondCommandError("searchterms", jsonOut, fmt.Errorf("Missing/invalid --startDate YYYY-MM-DD and --endDate YYYY-MM-DD"))
+		respondCommandError("searchterms", jsonOut, err)
 		return
 	}
-	endDate, err := parseDate(endRaw)
+	options, err := reportOptions(args, startDate, endDate)
 	if err != nil {
-		respondCommandError("searchterms", jsonOut, fmt.Errorf("Missing/invalid --startDate YYYY-MM-DD and --endDate YYYY-MM-DD"))
+		respondCommandError("searchterms", jsonOut, err)
 		return
 	}
+	compareStart, compareEnd, compare, err := reportCompareRange(args, startDate, endDate, options.Granularity)
+	if err != nil {
+		respondCommandError("searchterms", jsonOut, err)
+		return
+	}
+	startRaw, endRaw := startDate.Format("2006-01-02"), endDate.Format("2006-01-02")
 
-	minTaps := 0
-	if raw := strings.TrimSpace(valueForFlag(args, "--minTaps")); raw != "" {
-		_, _ = fmt.Sscanf(raw, "%d", &minTaps)
-		if minTaps < 0 {
-			minTaps = 0
+	window, err := collectSearchTermReport(ctx, client, args, campaignID, startDate, endDate, options)
+	if err != nil {
+		respondCommandError("searchterms", jsonOut, err)
+		return
+	}
+	filtered, breakdown := window.rows, window.breakdown
+	payload := map[string]any{
+		"ok":           true,
+		"campaignId":   campaignID,
+		"adGroupCount": window.adGroupCount,
+		"startDate":    startRaw,
+		"endDate":      endRaw,
+		"timeZone":     options.TimeZone,
+		"granularity":  options.Granularity,
+		"totals":       window.totals,
+		"periods":      window.periods.rows(),
+		"rows":         filtered,
+	}
+	if len(options.GroupBy) > 0 {
+		payload["groupBy"] = options.GroupBy
+		payload["breakdown"] = breakdown.rows()
+	}
+	compareLabels := []string{"searchTerm", "dimensions"}
+	if compare {
+		previous, err := collectSearchTermReport(ctx, client, args, campaignID, compareStart, compareEnd, options)
+		if err != nil {
+			respondCommandError("searchterms", jsonOut, err)
+			return
 		}
+		payload["compare"] = map[string]any{"startDate": compareStart.Format("2006-01-02"), "endDate": compareEnd.Format("2006-01-02")}
+		minTaps, minSpend := reportMinimums(args)
+		payload["comparison"] = comparisonAboveMinimums(compareReportRows(window.all, previous.all, []string{"searchTerm", "dimensions"}, compareLabels), minTaps, minSpend)
 	}
-	minSpend := 0.0
-	if raw := strings.TrimSpace(valueForFlag(args, "--minSpend")); raw != "" {
-		_, _ = fmt.Sscanf(raw, "%f", &minSpend)
-		if minSpend < 0 {
-			minSpend = 0
+
+	if jsonOut {
+		printJSON(payload)
+		return
+	}
+	fmt.Printf("campaignId=%d adGroupCount=%d range=%s...%s timeZone=%s granularity=%s\n", campaignID, window.adGroupCount, startRaw, endRaw, options.TimeZone, options.Granularity)
+	printReportTotals(window.totals)
+	if hasFlag(args, "--granularity") {
+		printReportPeriods(payload["periods"].([]map[string]any))
+	}
+	breakdown.print()
+	limit := len(filtered)
+	if limit > 30 {
+		limit = 30
+	}
+	for i := 0; i < limit; i++ {
+		row := filtered[i]
+		term, _ := row["searchTerm"].(string)
+		if len(options.GroupBy) > 0 {
+			dimensions, _ := row["dimensions"].(map[string]string)
+			term = dimensionKey(options.GroupBy, dimensions) + "\t" + term
 		}
+		taps, _ := row["taps"].(int)
+		installs, _ := row["installs"].(int)
+		spend, _ := row["spend"].(float64)
+		itemCPT, _ := row["cpt"].(float64)
+		ir, _ := row["installRate"].(float64)
+		fmt.Printf("%.4f\t%d\t%d\t%.4f\t%.4f\t%s\n", spend, taps, installs, itemCPT, ir, term)
 	}
+	if compare {
+		printReportComparison(compareStart, compareEnd, payload["comparison"].([]map[string]any), compareLabels)
+	}
+}
+
+// searchTermReportWindow is one date range of a search term report. rows
+// are filtered by --minTaps and --minSpend; all are not, so --compare can
+// apply them across both windows.
+type searchTermReportWindow struct {
+	rows         []map[string]any
+	all          []map[string]any
+	adGroupCount int
+	totals       map[string]any
+	periods      reportPeriods
+	breakdown    *reportBreakdown
+}
+
+// collectSearchTermReport fetches and filters one date range, so --compare
+// can run the same report over two windows.
+func collectSearchTermReport(ctx context.Context, client *appleads.Client, args []string, campaignID int, startDate, endDate time.Time, options appleads.ReportOptions) (*searchTermReportWindow, error) {
+	minTaps, minSpend := reportMinimums(args)
 
 	adGroupIDs := []int{}
 	if raw := strings.TrimSpace(valueForFlag(args, "--adGroupId")); raw != "" {
@@ -57,8 +136,7 @@ func RunSearchTerms(ctx context.Context, client *appleads.Client, args []string,
 	if len(adGroupIDs) == 0 {
 		adGroups, err := client.FetchAdGroups(ctx, campaignID)
 		if err != nil {
-			respondCommandError("searchterms", jsonOut, err)
-			return
+			return nil, err
 		}
 		for _, group := range adGroups {
 			adGroupIDs = append(adGroupIDs, group.ID)
@@ -73,32 +151,39 @@ func RunSearchTerms(ctx context.Context, client *appleads.Client, args []string,
 		installs    int
 		spend       float64
 		currency    *string
+		dimensions  map[string]string
+		periods     reportPeriods
 	}
 	grouped := map[string]*agg{}
 
 	for _, adGroupID := range adGroupIDs {
-		rows, err := client.FetchSearchTermDailyMetrics(ctx, startDate, endDate, campaignID, adGroupID)
+		rows, err := client.FetchSearchTermDailyMetrics(ctx, startDate, endDate, options, campaignID, adGroupID)
 		if err != nil {
-			respondCommandError("searchterms", jsonOut, err)
-			return
+			return nil, err
 		}
 		for _, row := range rows {
 			key := strings.ToLower(strings.TrimSpace(row.SearchTermText))
 			if key == "" {
 				continue
 			}
+			if len(options.GroupBy) > 0 {
+				key += "\t" + dimensionKey(options.GroupBy, row.Dimensions)
+			}
 			entry := grouped[key]
 			if entry == nil {
-				entry = &agg{searchTerm: row.SearchTermText, adGroupIDs: map[int]struct{}{}, currency: row.CurrencyCode}
+				entry = &agg{searchTerm: row.SearchTermText, adGroupIDs: map[int]struct{}{}, currency: row.CurrencyCode, dimensions: row.Dimensions, periods: reportPeriods{}}
 				grouped[key] = entry
 			}
+			installs := 0
+			if row.Installs != nil {
+				installs = *row.Installs
+			}
 			entry.adGroupIDs[row.AdGroupID] = struct{}{}
 			entry.impressions += row.Impressions
 			entry.taps += row.Taps
-			if row.Installs != nil {
-				entry.installs += *row.Installs
-			}
+			entry.installs += installs
 			entry.spend += row.Spend
+			entry.periods.add(row.Date, row.Spend, row.Taps, row.Impressions, installs)
 			if entry.currency == nil {
 				entry.currency = row.CurrencyCode
 			}
@@ -106,6 +191,7 @@ func RunSearchTerms(ctx context.Context, client *appleads.Client, args []string,
 	}
 
 	rows := make([]map[string]any, 0, len(grouped))
+	items := make([]*agg, 0, len(grouped))
 	for _, item := range grouped {
 		cpt := 0.0
 		if item.taps > 0 {
@@ -135,15 +221,22 @@ func RunSearchTerms(ctx context.Context, client *appleads.Client, args []string,
 		} else {
 			row["currency"] = nil
 		}
+		if item.dimensions != nil {
+			row["dimensions"] = item.dimensions
+		}
 		rows = append(rows, row)
+		items = append(items, item)
 	}
 
 	filtered := make([]map[string]any, 0, len(rows))
-	for _, row := range rows {
-		taps, _ := row["taps"].(int)
-		spend, _ := row["spend"].(float64)
-		if taps >= minTaps && spend >= minSpend {
+	periods := reportPeriods{}
+	breakdown := newReportBreakdown(options.GroupBy)
+	for idx, row := range rows {
+		item := items[idx]
+		if item.taps >= minTaps && item.spend >= minSpend {
 			filtered = append(filtered, row)
+			periods.merge(item.periods)
+			breakdown.add(item.dimensions, derefString(item.currency), item.spend, item.taps, item.impressions, item.installs)
 		}
 	}
 	sort.Slice(filtered, func(i, j int) bool {
@@ -182,13 +275,11 @@ func RunSearchTerms(ctx context.Context, client *appleads.Client, args []string,
 		installRate = float64(totals.installs) / float64(totals.taps)
 	}
 
-	payload := map[string]any{
-		"ok":           true,
-		"campaignId":   campaignID,
-		"adGroupCount": len(adGroupIDs),
-		"startDate":    startRaw,
-		"endDate":      endRaw,
-		"totals": map[string]any{
+	return &searchTermReportWindow{
+		rows:         filtered,
+		all:          rows,
+		adGroupCount: len(adGroupIDs),
+		totals: map[string]any{
 			"impressions": totals.impressions,
 			"taps":        totals.taps,
 			"installs":    totals.installs,
@@ -197,27 +288,7 @@ func RunSearchTerms(ctx context.Context, client *appleads.Client, args []string,
 			"ttr":         ttr,
 			"installRate": installRate,
 		},
-		"rows": filtered,
-	}
-
-	if jsonOut {
-		printJSON(payload)
-		return
-	}
-	fmt.Printf("campaignId=%d adGroupCount=%d range=%s...%s\n", campaignID, len(adGroupIDs), startRaw, endRaw)
-	fmt.Printf("totals taps=%d installs=%d spend=%.4f cpt=%.4f ttr=%.4f\n", totals.taps, totals.installs, totals.spend, cpt, ttr)
-	limit := len(filtered)
-	if limit > 30 {
-		limit = 30
-	}
-	for i := 0; i < limit; i++ {
-		row := filtered[i]
-		term, _ := row["searchTerm"].(string)
-		taps, _ := row["taps"].(int)
-		installs, _ := row["installs"].(int)
-		spend, _ := row["spend"].(float64)
-		itemCPT, _ := row["cpt"].(float64)
-		ir, _ := row["installRate"].(float64)
-		fmt.Printf("%.4f\t%d\t%d\t%.4f\t%.4f\t%s\n", spend, taps, installs, itemCPT, ir, term)
-	}
+		periods:   periods,
+		breakdown: breakdown,
+	}, nil
 }
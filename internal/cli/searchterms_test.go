@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"searchads-cli/internal/appleads"
+)
+
+func TestSearchTermCompareAppliesMinimumsAcrossWindows(t *testing.T) {
+	t.Setenv("OE_ADS_CREDENTIALS_JSON", testCredentialsJSON(t))
+	term := func(text, date string, taps int, spend string) string {
+		return `{"metadata":{"searchTermText":"` + text + `","adGroupId":8},"granularity":[{"date":"` + date + `","localSpend":{"amount":"` + spend + `","currency":"USD"},"taps":` + strconv.Itoa(taps) + `,"impressions":100}]}`
+	}
+	reports := map[string]string{
+		"2026-10-08": term("maps", "2026-10-08", 5, "5.00") + "," + term("globe", "2026-10-08", 20, "20.00") + "," + term("atlas", "2026-10-08", 3, "3.00"),
+		"2026-10-07": term("maps", "2026-10-07", 50, "50.00") + "," + term("globe", "2026-10-07", 2, "2.00") + "," + term("atlas", "2026-10-07", 4, "4.00"),
+	}
+	client := appleads.NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.Host == "appleid.apple.com":
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.Path == "/api/v5/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.URL.Path == "/api/v5/reports/campaigns/7/adgroups/8/searchterms":
+				var body map[string]any
+				_ = json.NewDecoder(req.Body).Decode(&body)
+				start, _ := body["startTime"].(string)
+				return jsonResponse(http.StatusOK, `{"data":{"reportingDataResponse":{"row":[`+reports[start]+`]}}}`), nil
+			}
+			return jsonResponse(http.StatusNotFound, `{"error":"unexpected request"}`), nil
+		}),
+	})
+
+	stdout, _, failed, err := captureCommandOutput(func() {
+		RunSearchTerms(context.Background(), client, []string{"report", "--campaignId", "7", "--adGroupId", "8", "--startDate", "2026-10-08", "--endDate", "2026-10-08", "--compare", "previous", "--minTaps", "10", "--json"}, true)
+	})
+	if err != nil || failed {
+		t.Fatalf("report failed: %v %s", err, stdout)
+	}
+	var payload struct {
+		Rows       []map[string]any `json:"rows"`
+		Comparison []map[string]any `json:"comparison"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &payload); err != nil {
+		t.Fatalf("invalid JSON: %v %s", err, stdout)
+	}
+	if len(payload.Rows) != 1 || payload.Rows[0]["searchTerm"] != "globe" {
+		t.Fatalf("expected only globe to reach --minTaps this window, got %v", payload.Rows)
+	}
+	if len(payload.Comparison) != 2 {
+		t.Fatalf("expected maps and globe compared, atlas dropped, got %v", payload.Comparison)
+	}
+	for _, row := range payload.Comparison {
+		if _, flagged := row["flag"]; flagged {
+			t.Fatalf("expected no NEW/GONE for a term active in both windows, got %v", row)
+		}
+		current, _ := row["current"].(map[string]any)
+		previous, _ := row["previous"].(map[string]any)
+		switch row["searchTerm"] {
+		case "maps":
+			if current["taps"] != 5.0 || previous["taps"] != 50.0 {
+				t.Fatalf("expected maps 50->5 taps, got %v", row)
+			}
+		case "globe":
+			if current["taps"] != 20.0 || previous["taps"] != 2.0 {
+				t.Fatalf("expected globe 2->20 taps, got %v", row)
+			}
+		default:
+			t.Fatalf("unexpected compared term %v", row)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"searchads-cli/internal/appleads"
+)
+
+const (
+	defaultServeAddr      = "127.0.0.1:8080"
+	defaultServeRateLimit = 10.0
+	serveTokenEnv         = "SEARCHADS_SERVE_TOKEN"
+	maxServeBodyBytes     = 1 << 20
+)
+
+type serveOptions struct {
+	token          string
+	allowMutations bool
+	logOut         io.Writer
+}
+
+// serveRoute maps a REST path onto a command action. Path wildcards are passed
+// as the flag of the same name.
+type serveRoute struct {
+	pattern string
+	command string
+	action  string
+}
+
+var serveRoutes = []serveRoute{
+	{pattern: "GET /campaigns", command: "campaigns", action: "find"},
+	{pattern: "GET /campaigns/{campaignId}/adgroups", command: "adgroups", action: "find"},
+	{pattern: "GET /campaigns/{campaignId}/adgroups/{adGroupId}/ads", command: "ads", action: "list"},
+	{pattern: "GET /campaigns/{campaignId}/adgroups/{adGroupId}/keywords", command: "keywords", action: "find"},
+	{pattern: "GET /campaigns/{campaignId}/negatives", command: "negatives", action: "list"},
+	{pattern: "GET /campaigns/{campaignId}/adgroups/{adGroupId}/negatives", command: "negatives", action: "list"},
+	{pattern: "GET /reports/campaigns", command: "campaigns", action: "report"},
+	{pattern: "GET /reports/adgroups", command: "adgroups", action: "report"},
+	{pattern: "GET /reports/keywords", command: "keywords", action: "report"},
+	{pattern: "GET /reports/searchterms", command: "searchterms", action: "report"},
+	{pattern: "GET /custom-reports", command: "reports", action: "list"},
+	{pattern: "GET /custom-reports/{reportId}", command: "reports", action: "get"},
+	{pattern: "GET /creatives", command: "creatives", action: "find"},
+	{pattern: "GET /apps/{adamId}/product-pages", command: "product-pages", action: "list"},
+}
+
+// RunServe exposes read commands over a local HTTP API. Every request shares
+// one client, so the access token cache and rate limit apply across callers.
+func RunServe(ctx context.Context, client *appleads.Client, args []string, jsonOut bool) {
+	addr := firstNonEmptyString(strings.TrimSpace(valueForFlag(args, "--addr")), defaultServeAddr)
+	options := serveOptions{
+		token:          firstNonEmptyString(strings.TrimSpace(valueForFlag(args, "--token")), strings.TrimSpace(os.Getenv(serveTokenEnv))),
+		allowMutations: hasFlag(args, "--allowMutations"),
+		logOut:         os.Stderr,
+	}
+	rateLimit := defaultServeRateLimit
+	if raw := strings.TrimSpace(valueForFlag(args, "--rateLimit")); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed < 0 {
+			respondCommandError("serve", jsonOut, fmt.Errorf("Invalid --rateLimit %q (requests per second; 0 disables)", raw))
+			return
+		}
+		rateLimit = parsed
+	}
+	if options.token == "" {
+		generated, err := generateServeToken()
+		if err != nil {
+			respondCommandError("serve", jsonOut, err)
+			return
+		}
+		options.token = generated
+		fmt.Fprintf(options.logOut, "serve token=%s (set --token or %s to choose one)\n", generated, serveTokenEnv)
+	}
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			fmt.Fprintf(options.logOut, "warning: %s is reachable from other hosts; anyone with the token can read your Apple Ads data\n", addr)
+		}
+	}
+
+	client.SetRateLimit(rateLimit, int(rateLimit)+1)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		respondCommandError("serve", jsonOut, err)
+		return
+	}
+	server := &http.Server{
+		Handler:           newServeHandler(ctx, client, options),
+		ReadHeaderTimeout: 10 * time.Second,
+		ErrorLog:          log.New(options.logOut, "serve: ", 0),
+	}
+
+	signalCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-signalCtx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(options.logOut, "serve listening=http://%s mutations=%t rateLimit=%g/s\n", listener.Addr().String(), options.allowMutations, rateLimit)
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		respondCommandError("serve", jsonOut, err)
+	}
+}
+
+func generateServeToken() (string, error) {
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+func newServeHandler(ctx context.Context, client *appleads.Client, options serveOptions) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeServeJSON(w, http.StatusOK, map[string]any{"ok": true})
+	})
+	mux.HandleFunc("GET /schema", func(w http.ResponseWriter, r *http.Request) {
+		writeServeJSON(w, http.StatusOK, map[string]any{"version": schemaVersion, "globalFlags": globalFlagSpecs, "commands": commandSpecs()})
+	})
+	for _, route := range serveRoutes {
+		mux.HandleFunc(route.pattern, func(w http.ResponseWriter, r *http.Request) {
+			arguments := map[string]any{}
+			for _, name := range []string{"campaignId", "adGroupId", "reportId", "adamId"} {
+				if value := r.PathValue(name); value != "" {
+					arguments[name] = value
+				}
+			}
+			serveAction(ctx, client, options, w, r, route.command, route.action, arguments)
+		})
+	}
+	mux.HandleFunc("GET /cli/{command}/{action}", func(w http.ResponseWriter, r *http.Request) {
+		serveAction(ctx, client, options, w, r, r.PathValue("command"), r.PathValue("action"), map[string]any{})
+	})
+	mux.HandleFunc("POST /cli/{command}/{action}", func(w http.ResponseWriter, r *http.Request) {
+		arguments := map[string]any{}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxServeBodyBytes))
+		if err == nil && len(strings.TrimSpace(string(body))) > 0 {
+			err = json.Unmarshal(body, &arguments)
+		}
+		if err != nil {
+			writeServeJSON(w, http.StatusBadRequest, map[string]any{"ok": false, "error": "Request body must be a JSON object of flag arguments"})
+			return
+		}
+		serveAction(ctx, client, options, w, r, r.PathValue("command"), r.PathValue("action"), arguments)
+	})
+	return serveMiddleware(options, mux)
+}
+
+func serveMiddleware(options serveOptions, next http.Handler) http.Handler {
+	expected := []byte("Bearer " + options.token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		if r.URL.Path != "/healthz" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			recorder.Header().Set("WWW-Authenticate", `Bearer realm="searchads"`)
+			writeServeJSON(recorder, http.StatusUnauthorized, map[string]any{"ok": false, "error": "Missing or invalid bearer token"})
+		} else {
+			next.ServeHTTP(recorder, r)
+		}
+		fmt.Fprintf(options.logOut, "%s %s %d %s\n", r.Method, r.URL.Path, recorder.status, time.Since(started).Round(time.Millisecond))
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// serveAction runs one command action with query parameters (and route or
+// body arguments) as flags and returns the command's --json output. The
+// command runs under the request's context, so it stops with the request.
+func serveAction(ctx context.Context, client *appleads.Client, options serveOptions, w http.ResponseWriter, r *http.Request, command, actionName string, arguments map[string]any) {
+	spec, ok := findCommandSpec(command)
+	if _, excluded := remoteExcludedCommands[command]; !ok || excluded {
+		writeServeJSON(w, http.StatusNotFound, map[string]any{"ok": false, "error": fmt.Sprintf("Unknown command: %s", command)})
+		return
+	}
+	action, ok := spec.findActionSpec(actionName)
+	if !ok {
+		writeServeJSON(w, http.StatusNotFound, map[string]any{"ok": false, "error": fmt.Sprintf("Unknown %s action: %s", command, actionName)})
+		return
+	}
+	if action.Mutates {
+		if !options.allowMutations {
+			writeServeJSON(w, http.StatusForbidden, map[string]any{"ok": false, "error": "Mutations are disabled; restart with searchads serve --allowMutations"})
+			return
+		}
+		if r.Method != http.MethodPost {
+			writeServeJSON(w, http.StatusMethodNotAllowed, map[string]any{"ok": false, "error": "Mutations must use POST"})
+			return
+		}
+	}
+	for name, values := range r.URL.Query() {
+		if _, exists := arguments[name]; exists {
+			continue
+		}
+		if len(values) == 1 {
+			arguments[name] = values[0]
+			continue
+		}
+		items := make([]any, 0, len(values))
+		for _, value := range values {
+			items = append(items, value)
+		}
+		arguments[name] = items
+	}
+
+	commandArgs, err := actionCommandLine(spec.Name, action, arguments)
+	if err != nil {
+		writeServeJSON(w, http.StatusBadRequest, map[string]any{"ok": false, "error": err.Error()})
+		return
+	}
+	// Commands run one at a time, so work for a caller that has gone away
+	// must stop rather than hold up everyone else.
+	requestCtx, cancel := serveRequestContext(ctx, r)
+	defer cancel()
+	stdout, stderr, failed, err := captureCommandOutput(func() {
+		if requestCtx.Err() != nil {
+			return
+		}
+		Dispatch(requestCtx, client, commandArgs)
+	})
+	if requestCtx.Err() != nil {
+		return
+	}
+	if err != nil {
+		writeServeJSON(w, http.StatusInternalServerError, map[string]any{"ok": false, "error": err.Error()})
+		return
+	}
+	body := strings.TrimSpace(stdout)
+	if !json.Valid([]byte(body)) {
+		payload := map[string]any{"ok": !failed, "output": body}
+		if trimmed := strings.TrimSpace(stderr); trimmed != "" {
+			payload["error"] = trimmed
+		}
+		encoded, _ := json.Marshal(payload)
+		body = string(encoded)
+	}
+	status := http.StatusOK
+	if failed {
+		status = http.StatusBadRequest
+		if strings.Contains(body, "Apple Ads API error") {
+			status = http.StatusBadGateway
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, body+"\n")
+}
+
+// serveRequestContext ends when the request does (the caller disconnects or
+// times out) or when the server shuts down.
+func serveRequestContext(serverCtx context.Context, r *http.Request) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(r.Context())
+	stop := context.AfterFunc(serverCtx, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+func writeServeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"searchads-cli/internal/appleads"
+)
+
+func newTestServeServer(t *testing.T, options serveOptions) *httptest.Server {
+	t.Helper()
+
+	options.logOut = io.Discard
+	server := httptest.NewServer(newServeHandler(context.Background(), appleads.NewClient(nil), options))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func serveRequest(t *testing.T, server *httptest.Server, method, path, token, body string) (int, map[string]any) {
+	t.Helper()
+
+	request, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	response, err := server.Client().Do(request)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer response.Body.Close()
+	var payload map[string]any
+	if err := json.NewDecoder(response.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode %s %s: %v", method, path, err)
+	}
+	return response.StatusCode, payload
+}
+
+func TestServeRequiresBearerToken(t *testing.T) {
+	server := newTestServeServer(t, serveOptions{token: "secret"})
+
+	if status, _ := serveRequest(t, server, http.MethodGet, "/healthz", "", ""); status != http.StatusOK {
+		t.Fatalf("expected healthz without a token, got %d", status)
+	}
+	if status, payload := serveRequest(t, server, http.MethodGet, "/schema", "", ""); status != http.StatusUnauthorized || payload["ok"] != false {
+		t.Fatalf("expected 401 without a token, got %d %v", status, payload)
+	}
+	if status, _ := serveRequest(t, server, http.MethodGet, "/schema", "wrong", ""); status != http.StatusUnauthorized {
+		t.Fatalf("expected 401 with a wrong token, got %d", status)
+	}
+	status, payload := serveRequest(t, server, http.MethodGet, "/schema", "secret", "")
+	if status != http.StatusOK || payload["version"] != float64(schemaVersion) {
+		t.Fatalf("expected schema with a valid token, got %d %v", status, payload)
+	}
+}
+
+func TestServeRejectsMutationsUnlessAllowed(t *testing.T) {
+	server := newTestServeServer(t, serveOptions{token: "secret"})
+	if status, payload := serveRequest(t, server, http.MethodPost, "/cli/campaigns/pause", "secret", `{"campaignId":1}`); status != http.StatusForbidden {
+		t.Fatalf("expected 403 for a mutation, got %d %v", status, payload)
+	}
+	for _, path := range []string{"/cli/serve/serve", "/cli/mcp/mcp", "/cli/campaigns/bogus"} {
+		if status, _ := serveRequest(t, server, http.MethodGet, path, "secret", ""); status != http.StatusNotFound {
+			t.Fatalf("expected 404 for %s, got %d", path, status)
+		}
+	}
+
+	server = newTestServeServer(t, serveOptions{token: "secret", allowMutations: true})
+	if status, _ := serveRequest(t, server, http.MethodGet, "/cli/campaigns/pause?campaignId=1", "secret", ""); status != http.StatusMethodNotAllowed {
+		t.Fatalf("expected mutations over GET to be refused, got %d", status)
+	}
+}
+
+func TestServeRejectsPathFlags(t *testing.T) {
+	server := newTestServeServer(t, serveOptions{token: "secret", allowMutations: true})
+	out := filepath.Join(t.TempDir(), "report.csv")
+	requests := []struct{ method, path, body string }{
+		{http.MethodGet, "/cli/reports/download?reportId=1&out=" + out, ""},
+		{http.MethodPost, "/cli/reports/download", `{"reportId":1,"out":"` + out + `"}`},
+		{http.MethodGet, "/reports/campaigns?startDate=2026-01-01&fxRates=/etc/passwd&reportCurrency=USD", ""},
+		{http.MethodPost, "/cli/campaigns/pause", `{"campaignId":1,"resultFile":"` + out + `"}`},
+	}
+	for _, request := range requests {
+		status, payload := serveRequest(t, server, request.method, request.path, "secret", request.body)
+		if status != http.StatusBadRequest || !strings.Contains(fmt.Sprint(payload["error"]), "only available on the command line") {
+			t.Fatalf("expected %s %s to be refused, got %d %v", request.method, request.path, status, payload)
+		}
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Fatalf("expected nothing written to %s, got %v", out, err)
+	}
+}
+
+func TestServeRejectsFlagLikeValues(t *testing.T) {
+	server := newTestServeServer(t, serveOptions{token: "secret", allowMutations: true})
+	requests := []struct{ method, path, body string }{
+		{http.MethodPost, "/cli/campaigns/pause", `{"campaign":"--stdin"}`},
+		{http.MethodPost, "/cli/keywords/pause", `{"campaignId":1,"adGroupId":2,"text":["--continue-on-error"]}`},
+		{http.MethodGet, "/cli/campaigns/get?campaign=--stdin", ""},
+	}
+	for _, request := range requests {
+		status, payload := serveRequest(t, server, request.method, request.path, "secret", request.body)
+		if status != http.StatusBadRequest || !strings.Contains(fmt.Sprint(payload["error"]), "cannot start with --") {
+			t.Fatalf("expected %s %s to be refused, got %d %v", request.method, request.path, status, payload)
+		}
+	}
+}
+
+func TestServeRoutesRunCommandsWithJSONOutput(t *testing.T) {
+	for _, key := range []string{"OE_ADS_CREDENTIALS_JSON", "OE_ADS_CLIENT_ID", "OE_ADS_TEAM_ID", "OE_ADS_KEY_ID", "OE_ADS_PRIVATE_KEY"} {
+		t.Setenv(key, "")
+	}
+	server := newTestServeServer(t, serveOptions{token: "secret"})
+
+	status, payload := serveRequest(t, server, http.MethodGet, "/campaigns/12/adgroups?nameContains=brand", "secret", "")
+	if status != http.StatusBadRequest || payload["ok"] != false || !strings.Contains(payload["error"].(string), "Missing Apple Ads credentials") {
+		t.Fatalf("expected the command's JSON error, got %d %v", status, payload)
+	}
+	status, payload = serveRequest(t, server, http.MethodGet, "/campaigns?nope=1", "secret", "")
+	if status != http.StatusBadRequest || !strings.Contains(payload["error"].(string), `Unknown argument "nope"`) {
+		t.Fatalf("expected unknown argument error, got %d %v", status, payload)
+	}
+	status, payload = serveRequest(t, server, http.MethodPost, "/cli/campaigns/list", "secret", "[1]")
+	if status != http.StatusBadRequest || payload["ok"] != false {
+		t.Fatalf("expected invalid body error, got %d %v", status, payload)
+	}
+}
+
+func TestServeStopsCommandsForCallersThatHaveGone(t *testing.T) {
+	t.Setenv("OE_ADS_CREDENTIALS_JSON", testCredentialsJSON(t))
+	calls := 0
+	client := appleads.NewClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return jsonResponse(http.StatusOK, `{"data":[]}`), nil
+	})})
+	handler := newServeHandler(context.Background(), client, serveOptions{token: "secret", allowMutations: true, logOut: io.Discard})
+
+	requestCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	request := httptest.NewRequest(http.MethodPost, "/cli/campaigns/pause", strings.NewReader(`{"campaignId":1}`)).WithContext(requestCtx)
+	request.Header.Set("Authorization", "Bearer secret")
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	if calls != 0 {
+		t.Fatalf("expected no API calls for a cancelled request, got %d", calls)
+	}
+
+	serverCtx, shutdown := context.WithCancel(context.Background())
+	ctx, stop := serveRequestContext(serverCtx, httptest.NewRequest(http.MethodGet, "/campaigns", nil))
+	defer stop()
+	shutdown()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected server shutdown to end the request context")
+	}
+}
@@ -0,0 +1,564 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"searchads-cli/internal/appleads"
+)
+
+const (
+	shellHistoryFile  = "history"
+	shellHistoryLimit = 1000
+)
+
+// Commands that cannot run inside the shell.
+var shellExcludedCommands = map[string]struct{}{
+	"shell": {},
+	"mcp":   {},
+	"serve": {},
+}
+
+var shellBuiltins = []string{"use", "context", "history", "help", "exit", "quit"}
+
+// shellScope is the campaign and ad group that commands run against.
+type shellScope struct {
+	campaignID   int
+	campaignName string
+	adGroupID    int
+	adGroupName  string
+}
+
+type shellSession struct {
+	ctx       context.Context
+	client    *appleads.Client
+	out       io.Writer
+	scope     shellScope
+	history   []string
+	campaigns []namedEntity
+	adGroups  map[int][]namedEntity
+}
+
+// RunShell starts an interactive prompt that reuses one authenticated client.
+// `use campaign` and `use adgroup` set a context that fills in --campaignId and
+// --adGroupId for later commands. Without a terminal, lines are read from
+// stdin as a script.
+func RunShell(ctx context.Context, client *appleads.Client) {
+	session := &shellSession{ctx: ctx, client: client, out: os.Stdout, adGroups: map[int][]namedEntity{}}
+	if !isTerminal(os.Stdin) {
+		if !session.runScript(os.Stdin) {
+			markCommandFailed()
+		}
+		return
+	}
+
+	historyPath := shellHistoryPath()
+	session.history = loadShellHistory(historyPath)
+	editor := newLineEditor(os.Stdin, os.Stdout)
+	editor.complete = session.complete
+	fmt.Fprintln(session.out, "searchads shell. Type help for shell commands, exit or Ctrl-D to leave.")
+	for {
+		editor.history = session.history
+		restore, err := makeRaw(os.Stdin)
+		if err != nil {
+			failText("shell failed: %s", err.Error())
+			markCommandFailed()
+			return
+		}
+		line, err := editor.readLine(session.prompt())
+		restore()
+		if errors.Is(err, errLineInterrupted) {
+			continue
+		}
+		if err != nil {
+			break
+		}
+		session.remember(line)
+		if session.execute(line) {
+			break
+		}
+	}
+	saveShellHistory(historyPath, session.history)
+	ResetCommandFailure()
+}
+
+// runScript runs one command per line and reports whether all of them
+// succeeded.
+func (s *shellSession) runScript(in io.Reader) bool {
+	ok := true
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		s.remember(line)
+		ResetCommandFailure()
+		if s.execute(line) {
+			break
+		}
+		if CommandFailed() {
+			ok = false
+		}
+	}
+	return ok
+}
+
+func (s *shellSession) prompt() string {
+	parts := make([]string, 0, 2)
+	if s.scope.campaignID > 0 {
+		parts = append(parts, firstNonEmptyString(s.scope.campaignName, strconv.Itoa(s.scope.campaignID)))
+	}
+	if s.scope.adGroupID > 0 {
+		parts = append(parts, firstNonEmptyString(s.scope.adGroupName, strconv.Itoa(s.scope.adGroupID)))
+	}
+	if len(parts) == 0 {
+		return "searchads> "
+	}
+	return fmt.Sprintf("searchads [%s]> ", strings.Join(parts, " / "))
+}
+
+func (s *shellSession) remember(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" || (len(s.history) > 0 && s.history[len(s.history)-1] == line) {
+		return
+	}
+	s.history = append(s.history, line)
+	if len(s.history) > shellHistoryLimit {
+		s.history = s.history[len(s.history)-shellHistoryLimit:]
+	}
+}
+
+// execute runs one line and reports whether the shell should exit.
+func (s *shellSession) execute(line string) bool {
+	args, err := splitShellLine(line)
+	if err != nil {
+		failText("%s", err.Error())
+		markCommandFailed()
+		return false
+	}
+	if len(args) > 0 && args[0] == "searchads" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return false
+	}
+
+	command := strings.ToLower(args[0])
+	switch command {
+	case "exit", "quit":
+		return true
+	case "help":
+		s.printHelp()
+		return false
+	case "context":
+		s.printContext()
+		return false
+	case "history":
+		for idx, entry := range s.history {
+			fmt.Fprintf(s.out, "%4d  %s\n", idx+1, entry)
+		}
+		return false
+	case "use":
+		if err := s.use(args[1:]); err != nil {
+			failText("use failed: %s", err.Error())
+			markCommandFailed()
+			return false
+		}
+		s.printContext()
+		return false
+	}
+	if _, excluded := shellExcludedCommands[command]; excluded {
+		failText("%s is not available inside the shell", command)
+		markCommandFailed()
+		return false
+	}
+
+	commandCtx, stop := signal.NotifyContext(s.ctx, os.Interrupt)
+	defer stop()
+	if !Dispatch(commandCtx, s.client, s.withContext(args)) {
+		failText("Unknown command: %s (type help)", args[0])
+		markCommandFailed()
+	}
+	return false
+}
+
+func (s *shellSession) printHelp() {
+	fmt.Fprintln(s.out, `Shell commands:
+  use campaign <id|name|alias>   Set the campaign for later commands
+  use adgroup <id|name|alias>    Set the ad group within the current campaign
+  use none                       Clear the context
+  context                        Show the current context
+  history                        Show previous commands
+  exit                           Leave the shell (or Ctrl-D)
+
+Any searchads command runs as usual, e.g. keywords report --last 14d. Commands
+that take --campaignId or --adGroupId get them from the context unless given.
+Tab completes commands, actions, flags and campaign and ad group names.`)
+	names := make([]string, 0, 24)
+	for _, spec := range commandSpecs() {
+		if _, excluded := shellExcludedCommands[spec.Name]; !excluded {
+			names = append(names, spec.Name)
+		}
+	}
+	fmt.Fprintf(s.out, "\nCommands: %s\n", strings.Join(names, ", "))
+}
+
+func (s *shellSession) printContext() {
+	if s.scope.campaignID == 0 {
+		fmt.Fprintln(s.out, "context: none")
+		return
+	}
+	fmt.Fprintf(s.out, "campaignId=%d\t%s\n", s.scope.campaignID, s.scope.campaignName)
+	if s.scope.adGroupID > 0 {
+		fmt.Fprintf(s.out, "adGroupId=%d\t%s\n", s.scope.adGroupID, s.scope.adGroupName)
+	}
+}
+
+func (s *shellSession) use(args []string) error {
+	if len(args) == 0 {
+		return errors.New("Usage: use campaign <id|name> | use adgroup <id|name> | use none")
+	}
+	target := strings.TrimSpace(strings.Join(args[1:], " "))
+	switch strings.ToLower(args[0]) {
+	case "none", "clear":
+		s.scope = shellScope{}
+		return nil
+	case "campaign":
+		if target == "" {
+			return errors.New("Usage: use campaign <id|name|alias>")
+		}
+		campaign, err := s.resolveCampaign(target)
+		if err != nil {
+			return err
+		}
+		s.scope = shellScope{campaignID: campaign.id, campaignName: campaign.name}
+		return nil
+	case "adgroup", "ad-group":
+		if target == "" {
+			return errors.New("Usage: use adgroup <id|name|alias>")
+		}
+		if alias, ok := activeConfig.AdGroupAliases[strings.ToLower(target)]; ok {
+			if alias.CampaignID != s.scope.campaignID {
+				s.scope = shellScope{campaignID: alias.CampaignID, campaignName: s.campaignName(alias.CampaignID)}
+			}
+			s.scope.adGroupID, s.scope.adGroupName = alias.AdGroupID, target
+			return nil
+		}
+		if s.scope.campaignID == 0 {
+			return errors.New("use campaign <id|name> first")
+		}
+		if id, err := strconv.Atoi(target); err == nil && id > 0 {
+			s.scope.adGroupID, s.scope.adGroupName = id, entityName(s.adGroups[s.scope.campaignID], id)
+			return nil
+		}
+		adGroups, err := s.adGroupEntities(s.scope.campaignID)
+		if err != nil {
+			return err
+		}
+		adGroupID, err := selectNamedEntity("ad group", "adgroup", target, adGroups)
+		if err != nil {
+			return err
+		}
+		s.scope.adGroupID, s.scope.adGroupName = adGroupID, entityName(adGroups, adGroupID)
+		return nil
+	default:
+		return fmt.Errorf("Unknown use target %q; use campaign, adgroup or none", args[0])
+	}
+}
+
+func (s *shellSession) resolveCampaign(target string) (namedEntity, error) {
+	if alias, ok := activeConfig.CampaignAliases[strings.ToLower(target)]; ok {
+		return namedEntity{id: alias.CampaignID, name: target}, nil
+	}
+	// IDs need no lookup; the name shows once campaigns have been fetched.
+	if id, err := strconv.Atoi(target); err == nil && id > 0 {
+		return namedEntity{id: id, name: entityName(s.campaigns, id)}, nil
+	}
+	campaigns, err := s.campaignEntities()
+	if err != nil {
+		return namedEntity{}, err
+	}
+	campaignID, err := selectNamedEntity("campaign", "campaign", target, campaigns)
+	if err != nil {
+		return namedEntity{}, err
+	}
+	return namedEntity{id: campaignID, name: entityName(campaigns, campaignID)}, nil
+}
+
+func (s *shellSession) campaignName(campaignID int) string {
+	for alias, target := range activeConfig.CampaignAliases {
+		if target.CampaignID == campaignID {
+			return alias
+		}
+	}
+	return entityName(s.campaigns, campaignID)
+}
+
+func (s *shellSession) campaignEntities() ([]namedEntity, error) {
+	if s.campaigns != nil {
+		return s.campaigns, nil
+	}
+	campaigns, err := s.client.FetchCampaigns(s.ctx)
+	if err != nil {
+		return nil, err
+	}
+	entities := make([]namedEntity, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		entities = append(entities, namedEntity{id: campaign.ID, name: campaign.Name})
+	}
+	s.campaigns = entities
+	return entities, nil
+}
+
+func (s *shellSession) adGroupEntities(campaignID int) ([]namedEntity, error) {
+	if cached, ok := s.adGroups[campaignID]; ok {
+		return cached, nil
+	}
+	adGroups, err := s.client.FetchAdGroups(s.ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	entities := make([]namedEntity, 0, len(adGroups))
+	for _, group := range adGroups {
+		entities = append(entities, namedEntity{id: group.ID, name: group.Name})
+	}
+	s.adGroups[campaignID] = entities
+	return entities, nil
+}
+
+func entityName(entities []namedEntity, id int) string {
+	for _, entity := range entities {
+		if entity.id == id {
+			return entity.name
+		}
+	}
+	return ""
+}
+
+// withContext adds the context's --campaignId and --adGroupId to commands
+// whose action takes them and that don't select their own.
+func (s *shellSession) withContext(args []string) []string {
+	if s.scope.campaignID == 0 {
+		return args
+	}
+	action, ok := commandActionSpec(args)
+	if !ok {
+		return args
+	}
+	out := append([]string{}, args...)
+	if actionHasFlag(action, "--campaignId") && !hasFlag(args, "--campaignId") && !hasFlag(args, "--campaign") {
+		out = append(out, "--campaignId", strconv.Itoa(s.scope.campaignID))
+	}
+	sameCampaign := valueForFlag(out, "--campaignId") == strconv.Itoa(s.scope.campaignID)
+	if s.scope.adGroupID > 0 && sameCampaign && actionHasFlag(action, "--adGroupId") && !hasFlag(args, "--adGroupId") && !hasFlag(args, "--adGroup") {
+		out = append(out, "--adGroupId", strconv.Itoa(s.scope.adGroupID))
+	}
+	return out
+}
+
+// complete returns the word being typed and its candidate replacements.
+func (s *shellSession) complete(before string) (string, []string) {
+	words, partial, inWord, _ := scanShellWords(before)
+	value := ""
+	if inWord {
+		value = words[len(words)-1]
+		words = words[:len(words)-1]
+	} else {
+		partial = ""
+	}
+	if len(words) > 0 && words[0] == "searchads" {
+		words = words[1:]
+	}
+
+	var options []string
+	switch {
+	case len(words) == 0:
+		options = append(options, shellBuiltins...)
+		for _, spec := range commandSpecs() {
+			if _, excluded := shellExcludedCommands[spec.Name]; !excluded {
+				options = append(options, spec.Name)
+			}
+		}
+	case strings.ToLower(words[0]) == "use":
+		options = s.useCompletions(words[1:])
+	case strings.HasPrefix(value, "-"):
+		if action, ok := commandActionSpec(words); ok {
+			for _, flag := range action.Flags {
+				options = append(options, flag.Name)
+			}
+		}
+		for _, flag := range globalFlagSpecs {
+			options = append(options, flag.Name)
+		}
+	case len(words) == 1:
+		if spec, ok := findCommandSpec(strings.ToLower(words[0])); ok && len(spec.Actions) > 1 {
+			for _, action := range spec.Actions {
+				options = append(options, action.Name)
+			}
+		}
+	default:
+		if action, ok := commandActionSpec(words); ok {
+			previous := words[len(words)-1]
+			for _, flag := range action.Flags {
+				if flag.Name == previous && len(flag.Enum) > 0 {
+					options = append(options, flag.Enum...)
+				}
+			}
+		}
+	}
+
+	candidates := make([]string, 0, len(options))
+	seen := map[string]struct{}{}
+	for _, option := range options {
+		if !strings.HasPrefix(strings.ToLower(option), strings.ToLower(value)) {
+			continue
+		}
+		if _, dup := seen[option]; dup {
+			continue
+		}
+		seen[option] = struct{}{}
+		candidates = append(candidates, quoteShellWord(option))
+	}
+	sort.Strings(candidates)
+	return partial, candidates
+}
+
+func (s *shellSession) useCompletions(words []string) []string {
+	if len(words) == 0 {
+		return []string{"campaign", "adgroup", "none"}
+	}
+	options := make([]string, 0, 16)
+	switch strings.ToLower(words[0]) {
+	case "campaign":
+		for alias := range activeConfig.CampaignAliases {
+			options = append(options, alias)
+		}
+		if campaigns, err := s.campaignEntities(); err == nil {
+			for _, campaign := range campaigns {
+				options = append(options, campaign.name)
+			}
+		}
+	case "adgroup", "ad-group":
+		for alias := range activeConfig.AdGroupAliases {
+			options = append(options, alias)
+		}
+		if s.scope.campaignID > 0 {
+			if adGroups, err := s.adGroupEntities(s.scope.campaignID); err == nil {
+				for _, group := range adGroups {
+					options = append(options, group.name)
+				}
+			}
+		}
+	}
+	return options
+}
+
+// splitShellLine splits a line into words. Single and double quotes group
+// words and a backslash escapes the next character (not inside single quotes).
+func splitShellLine(line string) ([]string, error) {
+	words, _, _, err := scanShellWords(line)
+	return words, err
+}
+
+// scanShellWords also returns the raw text of the last word and whether the
+// line ends inside it, for completion.
+func scanShellWords(line string) ([]string, string, bool, error) {
+	words := make([]string, 0, 8)
+	var current, raw strings.Builder
+	inWord := false
+	escaped := false
+	var quote rune
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			raw.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+			raw.WriteRune(r)
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+			raw.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+			raw.WriteRune(r)
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				raw.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			raw.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+	if quote != 0 || escaped {
+		return words, raw.String(), inWord, errors.New("Unterminated quote or escape")
+	}
+	return words, raw.String(), inWord, nil
+}
+
+func quoteShellWord(word string) string {
+	if word != "" && !strings.ContainsAny(word, " \t'\"\\") {
+		return word
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(word) + `"`
+}
+
+func shellHistoryPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, userConfigDir, shellHistoryFile)
+}
+
+func loadShellHistory(path string) []string {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	trimmed := strings.TrimRight(string(data), "\n")
+	if trimmed == "" {
+		return nil
+	}
+	lines := strings.Split(trimmed, "\n")
+	if len(lines) > shellHistoryLimit {
+		lines = lines[len(lines)-shellHistoryLimit:]
+	}
+	return lines
+}
+
+func saveShellHistory(path string, history []string) {
+	if path == "" || len(history) == 0 {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, []byte(strings.Join(history, "\n")+"\n"), 0o600)
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"searchads-cli/internal/appleads"
+)
+
+func newTestShellSession(out io.Writer) *shellSession {
+	return &shellSession{ctx: context.Background(), client: appleads.NewClient(nil), out: out, adGroups: map[int][]namedEntity{}}
+}
+
+func TestSplitShellLine(t *testing.T) {
+	t.Parallel()
+
+	words, err := splitShellLine(`keywords add --text "running shoes" --text 'kid\s' trail\ shoes`)
+	if err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+	want := []string{"keywords", "add", "--text", "running shoes", "--text", `kid\s`, "trail shoes"}
+	if !reflect.DeepEqual(words, want) {
+		t.Fatalf("unexpected words: %q", words)
+	}
+	if _, err := splitShellLine(`use campaign "Brand`); err == nil {
+		t.Fatal("expected an unterminated quote to fail")
+	}
+}
+
+func TestShellContextFillsScopeFlags(t *testing.T) {
+	t.Parallel()
+
+	session := newTestShellSession(io.Discard)
+	session.scope = shellScope{campaignID: 12, adGroupID: 34}
+	cases := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"keywords", "report", "--last", "7d"}, []string{"keywords", "report", "--last", "7d", "--campaignId", "12", "--adGroupId", "34"}},
+		{[]string{"keywords", "report", "--campaignId", "99"}, []string{"keywords", "report", "--campaignId", "99"}},
+		{[]string{"keywords", "report", "--campaign", "Other"}, []string{"keywords", "report", "--campaign", "Other"}},
+		{[]string{"adgroups"}, []string{"adgroups", "--campaignId", "12"}},
+		{[]string{"apps", "search", "--query", "x"}, []string{"apps", "search", "--query", "x"}},
+	}
+	for _, tc := range cases {
+		if got := session.withContext(tc.args); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("withContext(%q) = %q, want %q", tc.args, got, tc.want)
+		}
+	}
+}
+
+func TestShellScriptRunsCommandsInContext(t *testing.T) {
+	for _, key := range []string{"OE_ADS_CREDENTIALS_JSON", "OE_ADS_CLIENT_ID", "OE_ADS_TEAM_ID", "OE_ADS_KEY_ID", "OE_ADS_PRIVATE_KEY"} {
+		t.Setenv(key, "")
+	}
+	var out bytes.Buffer
+	session := newTestShellSession(&out)
+	var ok bool
+	stdout, stderr, _, err := captureCommandOutput(func() {
+		ok = session.runScript(strings.NewReader("use campaign 12\ncontext\nkeywords report --last 7d --json\nshell\nexit\nstatus\n"))
+	})
+	if err != nil {
+		t.Fatalf("capture failed: %v", err)
+	}
+	if ok {
+		t.Fatal("expected the script to report the failed commands")
+	}
+	if !strings.Contains(out.String(), "campaignId=12") {
+		t.Fatalf("expected the context to be printed, got %q", out.String())
+	}
+	if !strings.Contains(stdout, "Missing Apple Ads credentials") || strings.Count(stdout, `"ok": false`) != 1 {
+		t.Fatalf("expected one command JSON error and nothing after exit, got %q", stdout)
+	}
+	if !strings.Contains(stderr, "shell is not available inside the shell") {
+		t.Fatalf("expected nested shell to be refused, got %q", stderr)
+	}
+	if len(session.history) != 5 {
+		t.Fatalf("expected history of the lines read, got %q", session.history)
+	}
+}
+
+func TestShellCompletion(t *testing.T) {
+	t.Parallel()
+
+	session := newTestShellSession(io.Discard)
+	session.campaigns = []namedEntity{{id: 1, name: "Brand UK"}, {id: 2, name: "Generic"}}
+	cases := []struct {
+		before      string
+		wantPartial string
+		want        []string
+	}{
+		{"keywords re", "re", []string{"rebid", "remove", "report"}},
+		{"keywords report --la", "--la", []string{"--last"}},
+		{"use campaign bra", "bra", []string{`"Brand UK"`}},
+		{"keywords find --matchType ", "", []string{"BROAD", "EXACT"}},
+		{"sha", "sha", nil},
+	}
+	for _, tc := range cases {
+		partial, candidates := session.complete(tc.before)
+		if partial != tc.wantPartial || (len(candidates) != 0 || len(tc.want) != 0) && !reflect.DeepEqual(candidates, tc.want) {
+			t.Fatalf("complete(%q) = %q %q, want %q %q", tc.before, partial, candidates, tc.wantPartial, tc.want)
+		}
+	}
+	if _, candidates := session.complete("ca"); !reflect.DeepEqual(candidates, []string{"campaigns"}) {
+		t.Fatalf("expected command completion, got %q", candidates)
+	}
+}
+
+func TestLineEditorEditingHistoryAndCompletion(t *testing.T) {
+	t.Parallel()
+
+	editor := newLineEditor(strings.NewReader("ca\t\rac\x1b[Db\r\x1b[A\x1b[A\rab\x7fc\r\x03\x04"), io.Discard)
+	editor.history = []string{"first", "second"}
+	editor.complete = func(before string) (string, []string) {
+		return before, []string{"campaigns"}
+	}
+	for _, want := range []string{"campaigns ", "abc", "first", "ac"} {
+		line, err := editor.readLine("> ")
+		if err != nil || line != want {
+			t.Fatalf("readLine = %q, %v; want %q", line, err, want)
+		}
+	}
+	if _, err := editor.readLine("> "); !errors.Is(err, errLineInterrupted) {
+		t.Fatalf("expected Ctrl-C to interrupt, got %v", err)
+	}
+	if _, err := editor.readLine("> "); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected Ctrl-D to end input, got %v", err)
+	}
+}
@@ -205,7 +205,7 @@ func parseSovOptions(args []string) (*sovOptions, error) {
 		return nil, fmt.Errorf("Missing required --adamId <id>")
 	}
 	countries := []string{}
-	for _, raw := range strings.Split(valueForFlag(args, "--country"), ",") {
+	for _, raw := range strings.Split(firstNonEmptyString(valueForFlag(args, "--country"), configDefault("countries")), ",") {
 		country := strings.ToUpper(strings.TrimSpace(raw))
 		if country != "" {
 			countries = append(countries, country)
@@ -216,7 +216,7 @@ func parseSovOptions(args []string) (*sovOptions, error) {
 		countries:  countries,
 		dateRange:  strings.ToUpper(firstNonEmptyString(valueForFlag(args, "--dateRange"), "LAST_4_WEEKS")),
 		name:       strings.TrimSpace(valueForFlag(args, "--name")),
-		outputRoot: firstNonEmptyString(valueForFlag(args, "--out"), "reports/sov"),
+		outputRoot: firstNonEmptyString(valueForFlag(args, "--out"), filepath.Join(configOutputRoot(), "sov")),
 		jsonOut:    hasFlag(args, "--json"),
 	}, nil
 }
@@ -0,0 +1,16 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package cli
+
+import (
+	"errors"
+	"os"
+)
+
+func isTerminal(file *os.File) bool {
+	return false
+}
+
+func makeRaw(file *os.File) (func(), error) {
+	return nil, errors.New("raw terminal mode is not supported on this platform")
+}
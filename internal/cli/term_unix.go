@@ -0,0 +1,51 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package cli
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+func getTermios(fd uintptr) (*syscall.Termios, error) {
+	var state syscall.Termios
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, uintptr(ioctlGetTermios), uintptr(unsafe.Pointer(&state))); errno != 0 {
+		return nil, errno
+	}
+	return &state, nil
+}
+
+func setTermios(fd uintptr, state *syscall.Termios) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, uintptr(ioctlSetTermios), uintptr(unsafe.Pointer(state))); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func isTerminal(file *os.File) bool {
+	_, err := getTermios(file.Fd())
+	return err == nil
+}
+
+// makeRaw turns off line buffering, echo and signal keys so the shell can
+// read keys one at a time. Output processing stays on, so "\n" still starts
+// a new line. The returned func restores the previous state.
+func makeRaw(file *os.File) (func(), error) {
+	fd := file.Fd()
+	previous, err := getTermios(fd)
+	if err != nil {
+		return nil, err
+	}
+	raw := *previous
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := setTermios(fd, &raw); err != nil {
+		return nil, err
+	}
+	return func() { _ = setTermios(fd, previous) }, nil
+}
@@ -0,0 +1,496 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"searchads-cli/internal/appleads"
+)
+
+// undoResult is the outcome of undoing one audit record.
+type undoResult struct {
+	AuditID  string   `json:"auditId"`
+	Command  string   `json:"command"`
+	Method   string   `json:"method"`
+	Path     string   `json:"path"`
+	Entities []string `json:"entities,omitempty"`
+	Outcome  string   `json:"outcome"`
+	Reason   string   `json:"reason,omitempty"`
+	Undo     *undoOp  `json:"undo,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// undoOp is the request that reverses a recorded change.
+type undoOp struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Body   any    `json:"body"`
+}
+
+// errUndoConflict marks an entity that changed again after the recorded
+// change, so restoring the prior state would overwrite someone's edit.
+var errUndoConflict = errors.New("changed since the recorded change")
+
+// errUndoNothing marks a record with nothing left to reverse.
+var errUndoNothing = errors.New("nothing to undo")
+
+// RunUndo reverses recorded changes using the prior state in the audit log:
+// updates get their previous field values back and deleted keywords and
+// negatives are added again.
+func RunUndo(ctx context.Context, client *appleads.Client, args []string, jsonOut bool) {
+	ids := make([]string, 0, 2)
+	for idx := 0; idx < len(args); idx++ {
+		if strings.HasPrefix(args[idx], "--") {
+			if args[idx] == "--last" {
+				idx++
+			}
+			continue
+		}
+		ids = append(ids, args[idx])
+	}
+	last := 0
+	if raw := valueForFlag(args, "--last"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			respondCommandError("undo", jsonOut, fmt.Errorf("Invalid --last value: %s", raw))
+			return
+		}
+		last = parsed
+	}
+	if (len(ids) == 0) == (last == 0) {
+		respondCommandError("undo", jsonOut, errors.New("Usage: searchads undo <auditId>... | --last N [--dryRun] [--force]"))
+		return
+	}
+	if err := ensureCredentialsPresent(); err != nil {
+		respondCommandError("undo", jsonOut, err)
+		return
+	}
+	entries, err := readAuditEntries(auditFilePath())
+	if err != nil {
+		respondCommandError("undo", jsonOut, err)
+		return
+	}
+	selected, err := selectUndoEntries(entries, ids, last)
+	if err != nil {
+		respondCommandError("undo", jsonOut, err)
+		return
+	}
+
+	dryRun := hasFlag(args, "--dryRun")
+	force := hasFlag(args, "--force")
+	results := make([]undoResult, 0, len(selected))
+	for _, entry := range selected {
+		result := undoResult{AuditID: entry.ID, Command: entry.Command, Method: entry.Method, Path: entry.Path, Entities: entry.Entities}
+		op, err := planUndo(ctx, client, entry, force)
+		result.Undo = op
+		switch {
+		case errors.Is(err, errUndoNothing):
+			result.Outcome, result.Reason = "skipped", err.Error()
+		case errors.Is(err, errUndoConflict):
+			result.Outcome, result.Reason = "conflict", err.Error()+"; rerun with --force to overwrite"
+		case err != nil:
+			result.Outcome, result.Reason = "unsupported", err.Error()
+		case dryRun:
+			result.Outcome = "planned"
+		default:
+			body, _ := json.Marshal(op.Body)
+			if _, err := client.Raw(ctx, op.Method, op.Path, body); err != nil {
+				result.Outcome, result.Error = "error", err.Error()
+			} else {
+				result.Outcome = "ok"
+			}
+		}
+		results = append(results, result)
+	}
+	respondUndoResults(jsonOut, dryRun, results)
+}
+
+// selectUndoEntries returns the records of the named commands, or of the
+// last N commands, newest change first.
+func selectUndoEntries(entries []auditEntry, ids []string, last int) ([]auditEntry, error) {
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	if last > 0 {
+		for idx := len(entries) - 1; idx >= 0 && len(wanted) < last; idx-- {
+			if entries[idx].ID != "" {
+				wanted[entries[idx].ID] = true
+			}
+		}
+	}
+	selected := make([]auditEntry, 0, len(wanted))
+	found := make(map[string]bool, len(wanted))
+	for idx := len(entries) - 1; idx >= 0; idx-- {
+		if wanted[entries[idx].ID] {
+			selected = append(selected, entries[idx])
+			found[entries[idx].ID] = true
+		}
+	}
+	for _, id := range ids {
+		if !found[id] {
+			return nil, fmt.Errorf("No audit records with ID %s", id)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, errors.New("The audit log has no changes to undo")
+	}
+	return selected, nil
+}
+
+// planUndo works out the request that restores entry's prior state and
+// checks the entity still looks the way the recorded change left it.
+func planUndo(ctx context.Context, client *appleads.Client, entry auditEntry, force bool) (*undoOp, error) {
+	if entry.StatusCode < 200 || entry.StatusCode > 299 {
+		return nil, fmt.Errorf("%w: the change failed (status %d)", errUndoNothing, entry.StatusCode)
+	}
+	collectionPath, collection := undoCollection(entry.Path)
+	deleted := entry.Method == http.MethodDelete || strings.HasSuffix(entry.Path, "/delete/bulk")
+	switch {
+	case deleted && (collection == "negativekeywords" || collection == "targetingkeywords"):
+		return planUndoReAdd(ctx, client, entry, collectionPath, collection)
+	case deleted:
+		return nil, fmt.Errorf("Apple Ads cannot restore a deleted %s", strings.TrimSuffix(collection, "s"))
+	case entry.Method == http.MethodPut || entry.Method == http.MethodPatch:
+		if strings.HasSuffix(entry.Path, "/bulk") {
+			return planUndoBulkUpdate(ctx, client, entry, collectionPath, force)
+		}
+		return planUndoUpdate(ctx, client, entry, force)
+	}
+	return nil, errors.New("undo does not delete entities a command created; remove them explicitly")
+}
+
+// undoCollection returns the collection path a record's path belongs to,
+// e.g. /api/v5/campaigns/1/negativekeywords for .../negativekeywords/7 or
+// .../negativekeywords/delete/bulk, and the collection's name.
+func undoCollection(path string) (string, string) {
+	trimmed := strings.TrimSuffix(strings.TrimSuffix(path, "/bulk"), "/delete")
+	if idx := strings.LastIndex(trimmed, "/"); idx >= 0 {
+		if _, err := strconv.Atoi(trimmed[idx+1:]); err == nil {
+			trimmed = trimmed[:idx]
+		}
+	}
+	return trimmed, trimmed[strings.LastIndex(trimmed, "/")+1:]
+}
+
+func planUndoUpdate(ctx context.Context, client *appleads.Client, entry auditEntry, force bool) (*undoOp, error) {
+	var request, before map[string]any
+	if json.Unmarshal(entry.Request, &request) != nil || json.Unmarshal(entry.Before, &before) != nil || before == nil {
+		return nil, errors.New("the audit record has no prior state to restore")
+	}
+	wrapper, fields := unwrapUndoRequest(request, before)
+	restore, err := undoFields(fields, before)
+	if err != nil {
+		return nil, err
+	}
+	current, err := fetchUndoData(ctx, client, entry.Path)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUndoItem(fields, before, mapFromUndo(current), force); err != nil {
+		return nil, err
+	}
+	var body any = restore
+	if wrapper != "" {
+		wrapped := make(map[string]any, len(request))
+		for key, value := range request {
+			wrapped[key] = value
+		}
+		wrapped[wrapper] = restore
+		body = wrapped
+	}
+	return &undoOp{Method: entry.Method, Path: entry.Path, Body: body}, nil
+}
+
+func planUndoBulkUpdate(ctx context.Context, client *appleads.Client, entry auditEntry, collectionPath string, force bool) (*undoOp, error) {
+	var request []any
+	if json.Unmarshal(entry.Request, &request) != nil {
+		var single map[string]any
+		if json.Unmarshal(entry.Request, &single) != nil || single["id"] == nil {
+			return nil, errors.New("the audit record's request body is not a list of items")
+		}
+		request = []any{single}
+	}
+	before := undoItemsByID(entry.Before)
+	current, err := fetchUndoData(ctx, client, collectionPath+"?limit=1000")
+	if err != nil {
+		return nil, err
+	}
+	currentByID := undoItemsFromAny(current)
+
+	restore := make([]any, 0, len(request))
+	var conflicts []string
+	for _, raw := range request {
+		fields := mapFromUndo(raw)
+		id := jsonIntValue(fields["id"])
+		prior, ok := before[id]
+		if !ok {
+			return nil, fmt.Errorf("the audit record has no prior state for ID %d", id)
+		}
+		item, err := undoFields(fields, prior)
+		if err != nil {
+			return nil, err
+		}
+		err = checkUndoItem(fields, prior, currentByID[id], force)
+		if errors.Is(err, errUndoNothing) {
+			continue
+		}
+		if err != nil {
+			conflicts = append(conflicts, fmt.Sprintf("id %d %s", id, strings.TrimPrefix(err.Error(), errUndoConflict.Error()+": ")))
+			continue
+		}
+		item["id"] = id
+		restore = append(restore, item)
+	}
+	if len(conflicts) > 0 {
+		return nil, fmt.Errorf("%w: %s", errUndoConflict, strings.Join(conflicts, "; "))
+	}
+	if len(restore) == 0 {
+		return nil, fmt.Errorf("%w: already restored", errUndoNothing)
+	}
+	return &undoOp{Method: entry.Method, Path: entry.Path, Body: restore}, nil
+}
+
+// planUndoReAdd recreates deleted keywords or negatives that are not present
+// again already. They come back with new IDs.
+func planUndoReAdd(ctx context.Context, client *appleads.Client, entry auditEntry, collectionPath, collection string) (*undoOp, error) {
+	var removed []any
+	if json.Unmarshal(entry.Before, &removed) != nil {
+		var single map[string]any
+		if json.Unmarshal(entry.Before, &single) != nil || single == nil {
+			return nil, errors.New("the audit record has no prior state to restore")
+		}
+		removed = []any{single}
+	}
+	current, err := fetchUndoData(ctx, client, collectionPath+"?limit=1000")
+	if err != nil {
+		return nil, err
+	}
+	present := map[string]bool{}
+	for _, raw := range toUndoSlice(current) {
+		item := mapFromUndo(raw)
+		if deleted, _ := item["deleted"].(bool); deleted || strings.EqualFold(fmt.Sprint(item["status"]), "DELETED") {
+			continue
+		}
+		present[undoKeywordKey(item)] = true
+	}
+
+	fields := []string{"text", "matchType", "status"}
+	if collection == "targetingkeywords" {
+		fields = append(fields, "bidAmount")
+	}
+	recreate := make([]any, 0, len(removed))
+	for _, raw := range removed {
+		item := mapFromUndo(raw)
+		if present[undoKeywordKey(item)] {
+			continue
+		}
+		payload := map[string]any{}
+		for _, field := range fields {
+			if value, ok := item[field]; ok && value != nil {
+				payload[field] = value
+			}
+		}
+		if status := strings.ToUpper(fmt.Sprint(payload["status"])); status == "DELETED" || status == "<NIL>" {
+			payload["status"] = "ACTIVE"
+		}
+		recreate = append(recreate, payload)
+	}
+	if len(recreate) == 0 {
+		return nil, fmt.Errorf("%w: the removed %s are present again", errUndoNothing, collection)
+	}
+	return &undoOp{Method: http.MethodPost, Path: collectionPath + "/bulk", Body: recreate}, nil
+}
+
+// unwrapUndoRequest handles bodies like {"campaign": {"status": ...}} whose
+// prior state was fetched unwrapped. Scalar options beside the wrapper, such
+// as clearGeoTargetingOnCountryOrRegionChange, are not fields.
+func unwrapUndoRequest(request, before map[string]any) (string, map[string]any) {
+	wrapper, fields := "", request
+	for key, value := range request {
+		if _, present := before[key]; present {
+			return "", request
+		}
+		inner, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		if wrapper != "" {
+			return "", request
+		}
+		wrapper, fields = key, inner
+	}
+	return wrapper, fields
+}
+
+// undoFields returns the prior value of each field the change set.
+func undoFields(fields, before map[string]any) (map[string]any, error) {
+	restore := make(map[string]any, len(fields))
+	for key := range fields {
+		if key == "id" {
+			continue
+		}
+		value, ok := before[key]
+		if !ok {
+			return nil, fmt.Errorf("the prior state has no %s to restore", key)
+		}
+		restore[key] = value
+	}
+	return restore, nil
+}
+
+// checkUndoItem compares the current entity with what the change set and
+// what it replaced.
+func checkUndoItem(fields, before, current map[string]any, force bool) error {
+	if current == nil {
+		return fmt.Errorf("%w: it no longer exists", errUndoConflict)
+	}
+	restored := true
+	for key, set := range fields {
+		if key == "id" {
+			continue
+		}
+		now := current[key]
+		if sameUndoValue(before[key], now) {
+			continue
+		}
+		restored = false
+		if !force && !sameUndoValue(set, now) {
+			return fmt.Errorf("%w: %s is now %s", errUndoConflict, key, compactUndoValue(now))
+		}
+	}
+	if restored {
+		return fmt.Errorf("%w: already restored", errUndoNothing)
+	}
+	return nil
+}
+
+// sameUndoValue compares API values loosely: numbers by value ("1.50" and
+// 1.5), strings case-insensitively, and maps by the keys expected sets.
+func sameUndoValue(expected, actual any) bool {
+	switch want := expected.(type) {
+	case map[string]any:
+		got, ok := actual.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, value := range want {
+			if !sameUndoValue(value, got[key]) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		got, ok := actual.([]any)
+		if !ok || len(got) != len(want) {
+			return false
+		}
+		for idx := range want {
+			if !sameUndoValue(want[idx], got[idx]) {
+				return false
+			}
+		}
+		return true
+	case nil:
+		return actual == nil
+	}
+	wantText, gotText := fmt.Sprint(expected), fmt.Sprint(actual)
+	if wantNumber, err := strconv.ParseFloat(wantText, 64); err == nil {
+		gotNumber, err := strconv.ParseFloat(gotText, 64)
+		return err == nil && math.Abs(wantNumber-gotNumber) < 1e-6
+	}
+	return strings.EqualFold(wantText, gotText)
+}
+
+func compactUndoValue(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+func fetchUndoData(ctx context.Context, client *appleads.Client, path string) (any, error) {
+	resp, err := client.Raw(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		var apiErr *appleads.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not fetch the current state: %w", err)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(resp.Body, &payload); err != nil {
+		return nil, fmt.Errorf("could not read the current state: %w", err)
+	}
+	return payload["data"], nil
+}
+
+func undoItemsByID(raw json.RawMessage) map[int]map[string]any {
+	var items any
+	_ = json.Unmarshal(raw, &items)
+	return undoItemsFromAny(items)
+}
+
+func undoItemsFromAny(items any) map[int]map[string]any {
+	byID := map[int]map[string]any{}
+	for _, raw := range toUndoSlice(items) {
+		item := mapFromUndo(raw)
+		if id := jsonIntValue(item["id"]); id > 0 {
+			byID[id] = item
+		}
+	}
+	return byID
+}
+
+func undoKeywordKey(item map[string]any) string {
+	return strings.ToLower(strings.TrimSpace(fmt.Sprint(item["text"]))) + "\x1f" + strings.ToUpper(fmt.Sprint(item["matchType"]))
+}
+
+func toUndoSlice(value any) []any {
+	items, _ := value.([]any)
+	return items
+}
+
+func mapFromUndo(value any) map[string]any {
+	item, _ := value.(map[string]any)
+	return item
+}
+
+func respondUndoResults(jsonOut, dryRun bool, results []undoResult) {
+	counts := map[string]int{}
+	for _, result := range results {
+		counts[result.Outcome]++
+	}
+	failed := counts["error"] + counts["conflict"] + counts["unsupported"]
+	if failed > 0 {
+		markCommandFailed()
+	}
+	if jsonOut {
+		printJSON(map[string]any{"ok": failed == 0, "dryRun": dryRun, "requested": len(results), "undone": counts["ok"], "planned": counts["planned"], "skipped": counts["skipped"], "failed": failed, "results": results})
+		return
+	}
+	for _, result := range results {
+		line := fmt.Sprintf("%s audit=%s %s %s", result.Outcome, result.AuditID, result.Method, result.Path)
+		if len(result.Entities) > 0 {
+			line += " entities=" + strings.Join(result.Entities, ",")
+		}
+		if result.Undo != nil && (result.Outcome == "planned" || result.Outcome == "ok") {
+			line += fmt.Sprintf(" undo=%s %s %s", result.Undo.Method, result.Undo.Path, compactUndoValue(result.Undo.Body))
+		}
+		if result.Reason != "" {
+			line += " reason=" + result.Reason
+		}
+		if result.Error != "" {
+			line += " error=" + result.Error
+		}
+		fmt.Println(line)
+	}
+	fmt.Printf("undo requested=%d undone=%d planned=%d skipped=%d failed=%d\n", len(results), counts["ok"], counts["planned"], counts["skipped"], failed)
+}
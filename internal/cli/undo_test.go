@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"searchads-cli/internal/appleads"
+)
+
+func TestPlanUndoRestoresPriorStateAndDetectsConflicts(t *testing.T) {
+	t.Setenv("OE_ADS_CREDENTIALS_JSON", testCredentialsJSON(t))
+	current := map[string]string{
+		"/api/v5/campaigns/7":                              `{"data":{"id":7,"name":"Brand","status":"PAUSED"}}`,
+		"/api/v5/campaigns/7/adgroups/8/targetingkeywords": `{"data":[{"id":1,"bidAmount":{"amount":"2","currency":"USD"}},{"id":2,"bidAmount":{"amount":"3.00","currency":"USD"}},{"id":3,"bidAmount":{"amount":"1.50","currency":"USD"}}]}`,
+		"/api/v5/campaigns/7/adgroups/8/negativekeywords":  `{"data":[{"id":9,"text":"cheap","matchType":"EXACT","status":"ACTIVE"}]}`,
+	}
+	client := appleads.NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.Host == "appleid.apple.com":
+				return jsonResponse(http.StatusOK, `{"access_token":"token","expires_in":3600}`), nil
+			case req.URL.Path == "/api/v5/me":
+				return jsonResponse(http.StatusOK, `{"data":{"parentOrgId":"123"}}`), nil
+			case req.Method == http.MethodGet && current[req.URL.Path] != "":
+				return jsonResponse(http.StatusOK, current[req.URL.Path]), nil
+			}
+			return jsonResponse(http.StatusNotFound, `{"error":"unexpected request"}`), nil
+		}),
+	})
+	ctx := context.Background()
+	record := func(method, path, request, before string) auditEntry {
+		return auditEntry{ID: "a1", AuditRecord: appleads.AuditRecord{Method: method, Path: path, Request: json.RawMessage(request), Before: json.RawMessage(before), StatusCode: http.StatusOK}}
+	}
+
+	op, err := planUndo(ctx, client, record(http.MethodPut, "/api/v5/campaigns/7", `{"campaign":{"status":"PAUSED"}}`, `{"id":7,"name":"Brand","status":"ENABLED"}`), false)
+	if err != nil || compactUndoValue(op.Body) != `{"campaign":{"status":"ENABLED"}}` {
+		t.Fatalf("expected the campaign status to be restored, got %+v %v", op, err)
+	}
+
+	op, err = planUndo(ctx, client, record(http.MethodPut, "/api/v5/campaigns/7", `{"campaign":{"name":"Brand"},"clearGeoTargetingOnCountryOrRegionChange":false}`, `{"id":7,"name":"Brand UK","status":"PAUSED"}`), false)
+	if err != nil || compactUndoValue(op.Body) != `{"campaign":{"name":"Brand UK"},"clearGeoTargetingOnCountryOrRegionChange":false}` {
+		t.Fatalf("expected the campaign name to be restored with its options, got %+v %v", op, err)
+	}
+
+	rebid := record(http.MethodPut, "/api/v5/campaigns/7/adgroups/8/targetingkeywords/bulk",
+		`[{"id":1,"bidAmount":{"amount":"2.0000","currency":"USD"}},{"id":2,"bidAmount":{"amount":"2.0000","currency":"USD"}},{"id":3,"bidAmount":{"amount":"2.0000","currency":"USD"}}]`,
+		`[{"id":1,"bidAmount":{"amount":"1.00","currency":"USD"}},{"id":2,"bidAmount":{"amount":"1.00","currency":"USD"}},{"id":3,"bidAmount":{"amount":"1.50","currency":"USD"}}]`)
+	if _, err := planUndo(ctx, client, rebid, false); !errors.Is(err, errUndoConflict) || !strings.Contains(err.Error(), "id 2 bidAmount is now") {
+		t.Fatalf("expected keyword 2 to conflict, got %v", err)
+	}
+	op, err = planUndo(ctx, client, rebid, true)
+	if err != nil || compactUndoValue(op.Body) != `[{"bidAmount":{"amount":"1.00","currency":"USD"},"id":1},{"bidAmount":{"amount":"1.00","currency":"USD"},"id":2}]` {
+		t.Fatalf("expected forced bids for keywords 1 and 2 only, got %+v %v", op, err)
+	}
+
+	removed := record(http.MethodDelete, "/api/v5/campaigns/7/adgroups/8/negativekeywords/delete/bulk", `[5,9]`,
+		`[{"id":5,"text":"free","matchType":"BROAD","status":"ACTIVE"},{"id":9,"text":"Cheap","matchType":"EXACT","status":"ACTIVE"}]`)
+	op, err = planUndo(ctx, client, removed, false)
+	if err != nil || op.Method != http.MethodPost || op.Path != "/api/v5/campaigns/7/adgroups/8/negativekeywords/bulk" ||
+		compactUndoValue(op.Body) != `[{"matchType":"BROAD","status":"ACTIVE","text":"free"}]` {
+		t.Fatalf("expected only the missing negative to be re-added, got %+v %v", op, err)
+	}
+
+	if _, err := planUndo(ctx, client, record(http.MethodDelete, "/api/v5/campaigns/7", "", `{"id":7}`), false); err == nil || !strings.Contains(err.Error(), "cannot restore a deleted campaign") {
+		t.Fatalf("expected campaign deletes to be unsupported, got %v", err)
+	}
+	failed := record(http.MethodPut, "/api/v5/campaigns/7", `{}`, `{}`)
+	failed.StatusCode = http.StatusBadRequest
+	if _, err := planUndo(ctx, client, failed, false); !errors.Is(err, errUndoNothing) {
+		t.Fatalf("expected failed changes to be skipped, got %v", err)
+	}
+}
+
+func TestSelectUndoEntriesGroupsByCommand(t *testing.T) {
+	t.Parallel()
+
+	entries := []auditEntry{
+		{ID: "a", AuditRecord: appleads.AuditRecord{Path: "/1"}},
+		{ID: "b", AuditRecord: appleads.AuditRecord{Path: "/2"}},
+		{ID: "b", AuditRecord: appleads.AuditRecord{Path: "/3"}},
+		{ID: "c", AuditRecord: appleads.AuditRecord{Path: "/4"}},
+	}
+	paths := func(selected []auditEntry) string {
+		parts := make([]string, 0, len(selected))
+		for _, entry := range selected {
+			parts = append(parts, entry.Path)
+		}
+		return strings.Join(parts, ",")
+	}
+	if selected, err := selectUndoEntries(entries, nil, 2); err != nil || paths(selected) != "/4,/3,/2" {
+		t.Fatalf("expected the last two commands newest first, got %q %v", paths(selected), err)
+	}
+	if selected, err := selectUndoEntries(entries, []string{"a"}, 0); err != nil || paths(selected) != "/1" {
+		t.Fatalf("expected command a, got %q %v", paths(selected), err)
+	}
+	if _, err := selectUndoEntries(entries, []string{"zzz"}, 0); err == nil {
+		t.Fatal("expected an unknown audit ID to fail")
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"searchads-cli/internal/appleads"
 )
 
 var commandFailed bool
@@ -78,6 +80,123 @@ func parseDate(value string) (time.Time, error) {
 	return time.Parse("2006-01-02", strings.TrimSpace(value))
 }
 
+// reportDateRange reads --startDate/--endDate or --last (e.g. 14d, 2w), which
+// counts back from today and includes it. With neither, the configured
+// dateWindow applies. A missing --endDate means today.
+func reportDateRange(args []string) (time.Time, time.Time, error) {
+	startRaw := strings.TrimSpace(valueForFlag(args, "--startDate"))
+	endRaw := strings.TrimSpace(valueForFlag(args, "--endDate"))
+	window := strings.TrimSpace(valueForFlag(args, "--last"))
+	if window != "" && (startRaw != "" || endRaw != "") {
+		return time.Time{}, time.Time{}, fmt.Errorf("Use either --last or --startDate/--endDate, not both")
+	}
+	if startRaw == "" && endRaw == "" {
+		window = firstNonEmptyString(window, configDefault("dateWindow"))
+	}
+	today := reportToday()
+	if window != "" {
+		days, err := parseDateWindow(window)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		return today.AddDate(0, 0, 1-days), today, nil
+	}
+
+	missing := fmt.Errorf("Missing/invalid --startDate YYYY-MM-DD and --endDate YYYY-MM-DD (or --last 14d)")
+	startDate, err := parseDate(startRaw)
+	if err != nil {
+		return time.Time{}, time.Time{}, missing
+	}
+	endDate := today
+	if endRaw != "" {
+		if endDate, err = parseDate(endRaw); err != nil {
+			return time.Time{}, time.Time{}, missing
+		}
+	}
+	if endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--endDate %s is before --startDate %s", endRaw, startRaw)
+	}
+	return startDate, endDate, nil
+}
+
+// parseDateWindow parses a window like 14d or 2w into days.
+func parseDateWindow(value string) (int, error) {
+	trimmed := strings.ToLower(strings.TrimSpace(value))
+	multiplier := 1
+	switch {
+	case strings.HasSuffix(trimmed, "d"):
+		trimmed = strings.TrimSuffix(trimmed, "d")
+	case strings.HasSuffix(trimmed, "w"):
+		trimmed = strings.TrimSuffix(trimmed, "w")
+		multiplier = 7
+	}
+	count, err := strconv.Atoi(trimmed)
+	if err != nil || count <= 0 {
+		return 0, fmt.Errorf("Invalid date window %q; use e.g. 14d or 2w", value)
+	}
+	return count * multiplier, nil
+}
+
+// reportTimeZoneFlag reads --timeZone UTC|ORTZ for report requests, falling
+// back to the reportTimeZone setting. It sets where Apple Ads starts and ends
+// each report day, not how --last counts days.
+func reportTimeZoneFlag(args []string) (string, error) {
+	raw := strings.ToUpper(strings.TrimSpace(firstNonEmptyString(valueForFlag(args, "--timeZone"), configDefault("reportTimeZone"))))
+	if raw == "" {
+		return appleads.DefaultReportTimeZone, nil
+	}
+	if !contains(appleads.ReportTimeZones(), raw) {
+		return "", fmt.Errorf("Invalid --timeZone %q. Use: %s", raw, strings.Join(appleads.ReportTimeZones(), "|"))
+	}
+	return raw, nil
+}
+
+// reportOptions reads --timeZone, --granularity and --groupBy, and checks the
+// date range against what Apple Ads accepts for the granularity.
+func reportOptions(args []string, startDate, endDate time.Time) (appleads.ReportOptions, error) {
+	timeZone, err := reportTimeZoneFlag(args)
+	if err != nil {
+		return appleads.ReportOptions{}, err
+	}
+	granularity := strings.ToUpper(strings.TrimSpace(valueForFlag(args, "--granularity")))
+	if granularity == "" {
+		granularity = appleads.ReportGranularityDaily
+	} else if !contains(appleads.ReportGranularities(), granularity) {
+		return appleads.ReportOptions{}, fmt.Errorf("Invalid --granularity %q. Use: %s", granularity, strings.Join(appleads.ReportGranularities(), "|"))
+	}
+	if err := appleads.ValidateReportRange(granularity, startDate, endDate, reportToday()); err != nil {
+		return appleads.ReportOptions{}, err
+	}
+	var groupBy []string
+	for _, name := range splitCSVValues(valuesForFlag(args, "--groupBy")) {
+		field, ok := appleads.LookupReportDimension(name)
+		if !ok {
+			return appleads.ReportOptions{}, fmt.Errorf("Invalid --groupBy %q. Use: %s", name, strings.Join(appleads.ReportDimensionNames(), ","))
+		}
+		if !contains(groupBy, field) {
+			groupBy = append(groupBy, field)
+		}
+	}
+	return appleads.ReportOptions{TimeZone: timeZone, Granularity: granularity, GroupBy: groupBy}, nil
+}
+
+// reportToday is today's date in the configured orgTimeZone (local time when
+// unset), as a UTC midnight like parseDate returns.
+func reportToday() time.Time {
+	now := time.Now().In(reportLocation())
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// reportLocation is the orgTimeZone setting, or the local zone.
+func reportLocation() *time.Location {
+	if name := configDefault("orgTimeZone"); name != "" {
+		if loaded, err := time.LoadLocation(name); err == nil {
+			return loaded
+		}
+	}
+	return time.Local
+}
+
 func failText(format string, a ...any) {
 	_, _ = fmt.Fprintf(os.Stderr, format+"\n", a...)
 }
@@ -16,3 +16,35 @@ func TestSafeDisplayURL(t *testing.T) {
 		t.Fatalf("expected non-url input to pass through")
 	}
 }
+
+func TestReportTimeZoneFlag(t *testing.T) {
+	cases := []struct {
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{nil, "ORTZ", false},
+		{[]string{"--timeZone", "utc"}, "UTC", false},
+		// Flags take their value as the next argument; --flag=value is not
+		// read, so the default applies.
+		{[]string{"--timeZone=UTC"}, "ORTZ", false},
+		{[]string{"--timeZone", "Europe/London"}, "", true},
+	}
+	for _, tc := range cases {
+		got, err := reportTimeZoneFlag(tc.args)
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Errorf("reportTimeZoneFlag(%v) = %q, %v; want %q, error %v", tc.args, got, err, tc.want, tc.wantErr)
+		}
+	}
+
+	previous := activeConfig
+	t.Cleanup(func() { activeConfig = previous })
+	activeConfig = newEffectiveConfig()
+	activeConfig.Values["reportTimeZone"] = configValue{Value: "UTC", Source: configSourceProject}
+	if got, err := reportTimeZoneFlag(nil); err != nil || got != "UTC" {
+		t.Fatalf("expected the reportTimeZone setting, got %q %v", got, err)
+	}
+	if got, err := reportTimeZoneFlag([]string{"--timeZone", "ORTZ"}); err != nil || got != "ORTZ" {
+		t.Fatalf("expected --timeZone to override the setting, got %q %v", got, err)
+	}
+}